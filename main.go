@@ -17,6 +17,7 @@ import (
 	"github.com/charmbracelet/glamour"
 	"github.com/charmbracelet/glamour/styles"
 	"github.com/dgnsrekt/glow-tts/pkg/tts"
+	"github.com/dgnsrekt/glow-tts/pkg/tts/engines"
 	"github.com/dgnsrekt/glow-tts/ui"
 	"github.com/dgnsrekt/glow-tts/utils"
 	"github.com/charmbracelet/lipgloss"
@@ -183,8 +184,8 @@ func validateOptions(cmd *cobra.Command) error {
 	if ttsEngine != "" {
 		// Normalize engine name
 		ttsEngine = strings.ToLower(ttsEngine)
-		if ttsEngine != "piper" && ttsEngine != "gtts" {
-			return fmt.Errorf("invalid TTS engine: %s (must be 'piper' or 'gtts')", ttsEngine)
+		if !engines.IsSupported(ttsEngine) {
+			return fmt.Errorf("invalid TTS engine: %s (must be one of: %s)", ttsEngine, strings.Join(engines.Names, ", "))
 		}
 		// Force TUI mode when TTS is enabled
 		tui = true
@@ -397,8 +398,22 @@ func runTUI(path string, content string) error {
 	cfg.PreserveNewLines = preserveNewLines
 	cfg.TTSEngine = ttsEngine
 
+	p := ui.NewProgram(cfg, content)
+
+	// Let other programs drive playback through the control socket
+	if cfg.TTSEngine != "" {
+		if ttsConfig, err := tts.LoadTTSConfig(); err == nil && ttsConfig.Control.Enabled {
+			server, err := ui.ServeTTSControl(p, ttsConfig.ControlSocket())
+			if err != nil {
+				log.Warn("TTS control socket unavailable", "error", err)
+			} else {
+				defer server.Close() //nolint:errcheck
+			}
+		}
+	}
+
 	// Run Bubble Tea program
-	if _, err := ui.NewProgram(cfg, content).Run(); err != nil {
+	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("unable to run tui program: %w", err)
 	}
 
@@ -456,6 +471,7 @@ func checkTTSDependencies() error {
 	fmt.Println("\nSummary:")
 	hasPiper := deps.Results["piper"].Installed && deps.Results["piper_models"].Installed
 	hasGTTS := deps.Results["gtts-cli"].Installed && deps.Results["ffmpeg"].Installed
+	hasESpeak := deps.Results["espeak-ng"].Installed
 	
 	if hasPiper && hasGTTS {
 		fmt.Println("✓ Both Piper and Google TTS engines are available")
@@ -465,11 +481,19 @@ func checkTTSDependencies() error {
 	} else if hasGTTS {
 		fmt.Println("✓ Google TTS engine is available")
 		fmt.Println("○ Piper TTS engine is not available (optional)")
+	} else if hasESpeak {
+		fmt.Println("○ Piper and Google TTS engines are not available (optional)")
 	} else {
 		fmt.Println("✗ No TTS engines are available")
 		fmt.Println("  Install at least one engine to use TTS features")
 	}
 	
+	if hasESpeak {
+		fmt.Println("✓ eSpeak NG engine is available")
+	} else if hasPiper || hasGTTS {
+		fmt.Println("○ eSpeak NG engine is not available (optional)")
+	}
+	
 	return nil
 }
 
@@ -541,7 +565,7 @@ func init() {
 	rootCmd.Flags().BoolVarP(&preserveNewLines, "preserve-new-lines", "n", false, "preserve newlines in the output")
 	rootCmd.Flags().BoolVarP(&mouse, "mouse", "m", false, "enable mouse wheel (TUI-mode only)")
 	_ = rootCmd.Flags().MarkHidden("mouse")
-	rootCmd.Flags().StringVar(&ttsEngine, "tts", "", "enable TTS with specified engine (piper or gtts)")
+	rootCmd.Flags().StringVar(&ttsEngine, "tts", "", "enable TTS with specified engine (piper, gtts, espeak, command or openai)")
 	rootCmd.Flags().BoolVar(&checkDeps, "check-deps", false, "check TTS dependencies and exit")
 	rootCmd.Flags().BoolVar(&generateTTSConfig, "generate-tts-config", false, "generate example TTS config file and exit")
 	rootCmd.Flags().BoolVar(&debugMode, "debug", false, "enable debug logging for TTS operations")
@@ -563,7 +587,7 @@ func init() {
 	viper.SetDefault("width", 0)
 	viper.SetDefault("all", true)
 
-	rootCmd.AddCommand(configCmd, manCmd)
+	rootCmd.AddCommand(configCmd, manCmd, ttsCmd)
 }
 
 func tryLoadConfigFromDefaultPlaces() {
@@ -23,4 +23,15 @@ const (
 	PlaybackPlaying
 	// PlaybackPaused indicates audio is paused
 	PlaybackPaused
-)
\ No newline at end of file
+)
+
+// String returns the state as reported by the control socket
+func (s PlaybackState) String() string {
+	switch s {
+	case PlaybackPlaying:
+		return "playing"
+	case PlaybackPaused:
+		return "paused"
+	}
+	return "stopped"
+}
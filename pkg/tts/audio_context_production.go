@@ -309,8 +309,7 @@ func (pap *ProductionAudioPlayer) Seek(offset int64, whence int) (int64, error)
 	return 0, fmt.Errorf("reader does not support seeking")
 }
 
-// BufferedDuration returns the duration of buffered audio
+// BufferedDuration returns the duration of audio read but not yet played
 func (pap *ProductionAudioPlayer) BufferedDuration() time.Duration {
-	// oto v3 doesn't provide BufferedDuration, return a default
-	return 100 * time.Millisecond
+	return pcmDuration(pap.player.BufferedSize(), DefaultPCMFormat())
 }
\ No newline at end of file
@@ -0,0 +1,218 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AudiobookFormat is the container an audiobook is encoded to
+type AudiobookFormat string
+
+const (
+	// AudiobookM4B is AAC in an MP4 container with chapter atoms
+	AudiobookM4B AudiobookFormat = "m4b"
+	// AudiobookMP3 is MP3 with ID3v2 CHAP frames
+	AudiobookMP3 AudiobookFormat = "mp3"
+)
+
+// ChapterLevel is the deepest heading level that starts a chapter
+const ChapterLevel = 2
+
+// DefaultDocumentPause is the silence between documents of an audiobook
+const DefaultDocumentPause = 1500 * time.Millisecond
+
+// AudiobookFormatFor returns the format matching the extension of path
+func AudiobookFormatFor(path string) (AudiobookFormat, error) {
+	switch ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(path), ".")); ext {
+	case "m4b", "m4a", "mp4":
+		return AudiobookM4B, nil
+	case "mp3":
+		return AudiobookMP3, nil
+	default:
+		return "", fmt.Errorf("unsupported audiobook format %q (use .m4b or .mp3)", ext)
+	}
+}
+
+// Chapter is a navigable section of an audiobook
+type Chapter struct {
+	Title string
+	Start time.Duration
+	End   time.Duration
+}
+
+// Chapters returns a chapter for each # and ## heading of the export. Audio
+// before the first heading belongs to the first chapter; an export without
+// headings is a single chapter called title.
+func (r *ExportResult) Chapters(title string) []Chapter {
+	var chapters []Chapter
+	for i, segment := range r.Segments {
+		s := segment.Sentence
+		if s.Type != SentenceTypeHeader || s.Level < 1 || s.Level > ChapterLevel || s.Text == "" {
+			continue
+		}
+		// A heading read as several sentences is one chapter
+		if i > 0 && len(chapters) > 0 {
+			prev := r.Segments[i-1].Sentence
+			if prev.Type == SentenceTypeHeader && prev.Block == s.Block && prev.Text != "" {
+				chapters[len(chapters)-1].Title += " " + s.Text
+				continue
+			}
+		}
+		chapters = append(chapters, Chapter{Title: s.Text, Start: segment.Offset})
+	}
+
+	if len(chapters) == 0 {
+		chapters = []Chapter{{Title: title}}
+	}
+	chapters[0].Start = 0
+	for i := range chapters {
+		if i+1 < len(chapters) {
+			chapters[i].End = chapters[i+1].Start
+		} else {
+			chapters[i].End = r.Duration()
+		}
+	}
+	return chapters
+}
+
+// AudiobookMetadata describes an audiobook
+type AudiobookMetadata struct {
+	Title  string
+	Author string
+}
+
+// Audiobook joins exported documents into one recording with chapters
+type Audiobook struct {
+	ExportResult
+	Metadata AudiobookMetadata
+	Chapters []Chapter
+	// DocumentPause is the silence between documents
+	DocumentPause time.Duration
+}
+
+// NewAudiobook creates an empty audiobook
+func NewAudiobook(metadata AudiobookMetadata) *Audiobook {
+	return &Audiobook{
+		ExportResult:  ExportResult{Format: DefaultPCMFormat()},
+		Metadata:      metadata,
+		DocumentPause: DefaultDocumentPause,
+	}
+}
+
+// AddDocument appends an exported document and its chapters. title names
+// the chapter of a document without headings, and path is recorded on its
+// segments to tell documents apart in transcripts.
+func (b *Audiobook) AddDocument(result *ExportResult, title, path string) {
+	if len(b.Audio) > 0 {
+		b.Audio = append(b.Audio, GenerateSilence(b.DocumentPause.Seconds(), b.Format)...)
+	}
+	offset := pcmDuration(len(b.Audio), b.Format)
+	// The pause belongs to the chapter before it, so chapters leave no gaps
+	if n := len(b.Chapters); n > 0 {
+		b.Chapters[n-1].End = offset
+	}
+
+	for _, segment := range result.Segments {
+		segment.Offset += offset
+		segment.Document = path
+		b.Segments = append(b.Segments, segment)
+	}
+	for _, chapter := range result.Chapters(title) {
+		chapter.Start += offset
+		chapter.End += offset
+		b.Chapters = append(b.Chapters, chapter)
+	}
+	b.Audio = append(b.Audio, result.Audio...)
+}
+
+// Encode writes the audiobook to output with ffmpeg. Both formats need a
+// seekable file, so output cannot be stdout.
+func (b *Audiobook) Encode(ctx context.Context, output string, format AudiobookFormat, bitrate string) error {
+	if len(b.Audio) == 0 {
+		return errors.New("the audiobook has no audio")
+	}
+	ffmpeg, err := exec.LookPath("ffmpeg")
+	if err != nil {
+		return errors.New("ffmpeg is required to encode audiobooks; run glow --check-deps for install instructions")
+	}
+
+	metadata, err := os.CreateTemp("", "glow-tts-chapters-*.txt")
+	if err != nil {
+		return fmt.Errorf("failed to create chapter metadata: %w", err)
+	}
+	defer os.Remove(metadata.Name())
+	if _, err := metadata.WriteString(ffmetadata(b.Metadata, b.Chapters)); err != nil {
+		metadata.Close()
+		return fmt.Errorf("failed to write chapter metadata: %w", err)
+	}
+	if err := metadata.Close(); err != nil {
+		return fmt.Errorf("failed to write chapter metadata: %w", err)
+	}
+
+	args, err := audiobookArgs(b.Format, metadata.Name(), output, format, bitrate)
+	if err != nil {
+		return err
+	}
+	cmd := exec.CommandContext(ctx, ffmpeg, args...)
+	cmd.Stdin = bytes.NewReader(b.Audio)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("ffmpeg encoding failed: %w\nstderr: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// audiobookArgs builds ffmpeg arguments that encode PCM from stdin with the
+// metadata and chapters in metadataPath
+func audiobookArgs(pcm PCMFormat, metadataPath, output string, format AudiobookFormat, bitrate string) ([]string, error) {
+	if bitrate == "" {
+		bitrate = "64k"
+	}
+	args := []string{
+		"-hide_banner", "-loglevel", "error", "-y",
+		"-f", "s16le", "-ar", fmt.Sprint(pcm.SampleRate), "-ac", fmt.Sprint(pcm.Channels), "-i", "pipe:0",
+		"-f", "ffmetadata", "-i", metadataPath,
+		"-map", "0:a", "-map_metadata", "1", "-map_chapters", "1",
+		"-b:a", bitrate,
+	}
+	switch format {
+	case AudiobookM4B:
+		args = append(args, "-c:a", "aac", "-movflags", "+faststart", "-f", "ipod")
+	case AudiobookMP3:
+		args = append(args, "-c:a", "libmp3lame", "-id3v2_version", "3", "-f", "mp3")
+	default:
+		return nil, fmt.Errorf("unsupported audiobook format %q", format)
+	}
+	return append(args, output), nil
+}
+
+// ffmetadata renders metadata and chapters in ffmpeg's FFMETADATA format
+func ffmetadata(metadata AudiobookMetadata, chapters []Chapter) string {
+	var b strings.Builder
+	b.WriteString(";FFMETADATA1\n")
+	if metadata.Title != "" {
+		fmt.Fprintf(&b, "title=%s\nalbum=%s\n", ffmetadataEscape(metadata.Title), ffmetadataEscape(metadata.Title))
+	}
+	if metadata.Author != "" {
+		fmt.Fprintf(&b, "artist=%s\nalbum_artist=%s\n", ffmetadataEscape(metadata.Author), ffmetadataEscape(metadata.Author))
+	}
+	b.WriteString("genre=Audiobook\n")
+	for _, chapter := range chapters {
+		fmt.Fprintf(&b, "\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=%d\nEND=%d\ntitle=%s\n",
+			chapter.Start.Milliseconds(), chapter.End.Milliseconds(), ffmetadataEscape(chapter.Title))
+	}
+	return b.String()
+}
+
+// ffmetadataEscape escapes the characters FFMETADATA treats specially
+func ffmetadataEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "=", `\=`, ";", `\;`, "#", `\#`, "\n", "\\\n").Replace(s)
+}
@@ -0,0 +1,164 @@
+package tts
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// exportTestDocument exports markdown with 100ms of audio per sentence
+func exportTestDocument(t *testing.T, markdown string) *ExportResult {
+	t.Helper()
+
+	format := DefaultPCMFormat()
+	engine := &mockQueueEngine{
+		name:      "mock",
+		available: true,
+		synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+			return make([]byte, 2205*format.BytesPerSample()), nil
+		},
+	}
+	exporter, err := NewExporter(engine, DefaultExportConfig())
+	if err != nil {
+		t.Fatalf("NewExporter failed: %v", err)
+	}
+	result, err := exporter.Export(markdown)
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	return result
+}
+
+func TestExportChapters(t *testing.T) {
+	result := exportTestDocument(t, "Preface text.\n\n# Install\n\nRun it.\n\n### Details\n\nMore.\n\n## Use\n\nRead it.")
+
+	chapters := result.Chapters("Guide")
+	var titles []string
+	for _, c := range chapters {
+		titles = append(titles, c.Title)
+	}
+	if strings.Join(titles, "|") != "Install|Use" {
+		t.Fatalf("Expected chapters at # and ## headings, got %q", titles)
+	}
+	if chapters[0].Start != 0 {
+		t.Errorf("Expected the preface in the first chapter, got start %v", chapters[0].Start)
+	}
+	if chapters[0].End != chapters[1].Start || chapters[1].End != result.Duration() {
+		t.Errorf("Expected chapters to cover the audio without gaps, got %+v", chapters)
+	}
+	for _, segment := range result.Segments {
+		if segment.Sentence.Text == "Use" && segment.Offset != chapters[1].Start {
+			t.Errorf("Expected the chapter to start at its heading, %v vs %v", chapters[1].Start, segment.Offset)
+		}
+	}
+
+	// Adjacent headings are separate chapters
+	adjacent := exportTestDocument(t, "## Install\n\n## Configure\n\nSet it up.\n\n## Usage\n\nRun it.").Chapters("Guide")
+	titles = nil
+	for _, c := range adjacent {
+		titles = append(titles, c.Title)
+	}
+	if strings.Join(titles, "|") != "Install|Configure|Usage" {
+		t.Errorf("Expected a chapter per heading, got %q", titles)
+	}
+
+	// A heading read as several sentences is one chapter
+	long := exportTestDocument(t, "# Part one. The setup\n\nText.").Chapters("Guide")
+	if len(long) != 1 || long[0].Title != "Part one The setup" {
+		t.Errorf("Expected one chapter for a long heading, got %+v", long)
+	}
+
+	untitled := exportTestDocument(t, "Just a note.").Chapters("Notes")
+	if len(untitled) != 1 || untitled[0].Title != "Notes" {
+		t.Errorf("Expected one chapter named after the document, got %+v", untitled)
+	}
+}
+
+func TestAudiobookAddDocument(t *testing.T) {
+	first := exportTestDocument(t, "# One\n\nFirst.")
+	second := exportTestDocument(t, "Second, without headings.")
+
+	book := NewAudiobook(AudiobookMetadata{Title: "Handbook"})
+	book.DocumentPause = time.Second
+	book.AddDocument(first, "one", "docs/one.md")
+	book.AddDocument(second, "two", "docs/two.md")
+
+	if len(book.Chapters) != 2 || book.Chapters[1].Title != "two" {
+		t.Fatalf("Expected a chapter per document, got %+v", book.Chapters)
+	}
+	start := first.Duration() + time.Second
+	if book.Chapters[1].Start != start || book.Chapters[0].End != start {
+		t.Errorf("Expected the second document at %v after the pause, got %+v", start, book.Chapters)
+	}
+	if book.Duration() != start+second.Duration() {
+		t.Errorf("Expected %v of audio, got %v", start+second.Duration(), book.Duration())
+	}
+	if last := book.Segments[len(book.Segments)-1]; last.Offset < start {
+		t.Errorf("Expected the second document's segments to be shifted, got %v", last.Offset)
+	}
+	if book.Segments[0].Document != "docs/one.md" || book.Segments[len(book.Segments)-1].Document != "docs/two.md" {
+		t.Errorf("Expected segments to record their document, got %q", book.Segments[0].Document)
+	}
+}
+
+func TestFFMetadata(t *testing.T) {
+	got := ffmetadata(AudiobookMetadata{Title: "A=B; C", Author: "Docs #1"}, []Chapter{
+		{Title: "Intro", Start: 0, End: 1500 * time.Millisecond},
+		{Title: `Back\slash`, Start: 1500 * time.Millisecond, End: 3 * time.Second},
+	})
+	for _, want := range []string{
+		";FFMETADATA1\n",
+		"title=A\\=B\\; C\n",
+		"artist=Docs \\#1\n",
+		"[CHAPTER]\nTIMEBASE=1/1000\nSTART=0\nEND=1500\ntitle=Intro\n",
+		"START=1500\nEND=3000\ntitle=Back\\\\slash\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("Expected metadata to contain %q, got:\n%s", want, got)
+		}
+	}
+}
+
+func TestAudiobookFormat(t *testing.T) {
+	for path, want := range map[string]AudiobookFormat{"book.m4b": AudiobookM4B, "BOOK.MP3": AudiobookMP3} {
+		if got, err := AudiobookFormatFor(path); err != nil || got != want {
+			t.Errorf("AudiobookFormatFor(%q) = %q, %v", path, got, err)
+		}
+	}
+	if _, err := AudiobookFormatFor("book.wav"); err == nil {
+		t.Error("Expected an error for WAV output")
+	}
+
+	args, err := audiobookArgs(DefaultPCMFormat(), "chapters.txt", "book.mp3", AudiobookMP3, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	joined := strings.Join(args, " ")
+	for _, want := range []string{"-f s16le -ar 22050 -ac 1 -i pipe:0", "-map_chapters 1", "-c:a libmp3lame", "-b:a 64k"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected %q in ffmpeg arguments: %s", want, joined)
+		}
+	}
+	if args[len(args)-1] != "book.mp3" {
+		t.Errorf("Expected the output last, got %q", args[len(args)-1])
+	}
+}
+
+func TestAudiobookEncode(t *testing.T) {
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		t.Skip("ffmpeg not installed")
+	}
+
+	book := NewAudiobook(AudiobookMetadata{Title: "Handbook", Author: "Docs"})
+	book.AddDocument(exportTestDocument(t, "# One\n\nFirst.\n\n# Two\n\nSecond."), "one", "")
+	for _, name := range []string{"book.m4b", "book.mp3"} {
+		output := filepath.Join(t.TempDir(), name)
+		format, _ := AudiobookFormatFor(output)
+		if err := book.Encode(context.Background(), output, format, ""); err != nil {
+			t.Errorf("Encode %s failed: %v", name, err)
+		}
+	}
+}
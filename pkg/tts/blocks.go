@@ -0,0 +1,146 @@
+package tts
+
+import (
+	"regexp"
+	"strings"
+)
+
+// tableDelimiterPattern matches the |---|:--:| row under a table header
+var tableDelimiterPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+
+// fencePattern matches the opening line of a fenced code block
+var fencePattern = regexp.MustCompile("^ {0,3}(`{3,}|~{3,})\\s*([^`\\s]*)")
+
+// listItemPattern matches the first line of a bullet or numbered list item
+var listItemPattern = regexp.MustCompile(`^ {0,3}(?:[-*+]|\d{1,9}[.)])(?:[ \t]+|$)`)
+
+// thematicBreakPattern matches rules such as --- or * * *
+var thematicBreakPattern = regexp.MustCompile(`^ {0,3}([-*_])(?:[ \t]*[-*_]){2,}[ \t]*$`)
+
+// blockKind identifies markdown blocks that are spoken specially
+type blockKind int
+
+const (
+	blockText blockKind = iota
+	blockTable
+	blockCode
+	blockHeading
+	blockList
+)
+
+// markdownBlock is a run of markdown lines. For code blocks text is the
+// code without its fences.
+type markdownBlock struct {
+	kind     blockKind
+	text     string
+	language string
+	// level is the heading level of ATX headings
+	level int
+	// items are the list items of list blocks
+	items []string
+}
+
+// splitMarkdownBlocks separates GFM tables, fenced code blocks, ATX
+// headings and lists from the surrounding markdown
+func splitMarkdownBlocks(markdown string) []markdownBlock {
+	lines := strings.Split(markdown, "\n")
+	var blocks []markdownBlock
+	var current []string
+
+	flush := func() {
+		if len(current) > 0 {
+			blocks = append(blocks, markdownBlock{kind: blockText, text: strings.Join(current, "\n")})
+			current = nil
+		}
+	}
+	isRow := func(line string) bool {
+		return strings.Contains(line, "|") && strings.TrimSpace(line) != ""
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+
+		if m := fencePattern.FindStringSubmatch(line); m != nil {
+			flush()
+			fence := m[1]
+			end := i + 1
+			for end < len(lines) && !isClosingFence(lines[end], fence) {
+				end++
+			}
+			blocks = append(blocks, markdownBlock{
+				kind:     blockCode,
+				text:     strings.Join(lines[i+1:min(end, len(lines))], "\n"),
+				language: m[2],
+			})
+			i = end
+			continue
+		}
+
+		if i+1 < len(lines) && isRow(line) &&
+			strings.Contains(lines[i+1], "|") && tableDelimiterPattern.MatchString(lines[i+1]) {
+			flush()
+			end := i + 2
+			for end < len(lines) && isRow(lines[end]) {
+				end++
+			}
+			blocks = append(blocks, markdownBlock{kind: blockTable, text: strings.Join(lines[i:end], "\n")})
+			i = end - 1
+			continue
+		}
+
+		if isListItem(line) {
+			flush()
+			end := i
+			var items []string
+		list:
+			for ; end < len(lines); end++ {
+				next := lines[end]
+				switch {
+				case isListItem(next):
+					items = append(items, next)
+				case strings.TrimSpace(next) == "":
+					// A blank line only continues a loose list
+					if end+1 >= len(lines) || !isListItem(lines[end+1]) && !isIndented(lines[end+1]) {
+						break list
+					}
+				case isIndented(next) && !fencePattern.MatchString(next):
+					items[len(items)-1] += "\n" + next
+				default:
+					break list
+				}
+			}
+			blocks = append(blocks, markdownBlock{kind: blockList, text: strings.Join(lines[i:end], "\n"), items: items})
+			i = end - 1
+			continue
+		}
+
+		if headingLinePattern.MatchString(line) {
+			flush()
+			trimmed := strings.TrimLeft(line, " ")
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			blocks = append(blocks, markdownBlock{kind: blockHeading, text: line, level: level})
+			continue
+		}
+
+		current = append(current, line)
+	}
+
+	flush()
+	return blocks
+}
+
+// isListItem reports whether line starts a list item
+func isListItem(line string) bool {
+	return listItemPattern.MatchString(line) && !thematicBreakPattern.MatchString(line)
+}
+
+// isIndented reports whether line continues the list item above it
+func isIndented(line string) bool {
+	return strings.TrimSpace(line) != "" && (strings.HasPrefix(line, "  ") || strings.HasPrefix(line, "\t"))
+}
+
+// isClosingFence reports whether line closes a block opened with fence
+func isClosingFence(line, fence string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == ""
+}
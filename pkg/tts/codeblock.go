@@ -0,0 +1,357 @@
+package tts
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CodeBlockStrategy selects how a code block is spoken
+type CodeBlockStrategy string
+
+const (
+	// CodeBlockSkip leaves code blocks out
+	CodeBlockSkip CodeBlockStrategy = "skip"
+	// CodeBlockAnnounce says "code block in Go, 24 lines"
+	CodeBlockAnnounce CodeBlockStrategy = "announce"
+	// CodeBlockComments reads only the comments
+	CodeBlockComments CodeBlockStrategy = "comments"
+	// CodeBlockIdentifiers reads each line as words, dropping brackets
+	// and verbalizing operators
+	CodeBlockIdentifiers CodeBlockStrategy = "identifiers"
+	// CodeBlockRead reads the code as written
+	CodeBlockRead CodeBlockStrategy = "read"
+)
+
+// ParseCodeBlockStrategy validates a strategy name
+func ParseCodeBlockStrategy(name string) (CodeBlockStrategy, error) {
+	switch s := CodeBlockStrategy(strings.ToLower(strings.TrimSpace(name))); s {
+	case CodeBlockSkip, CodeBlockAnnounce, CodeBlockComments, CodeBlockIdentifiers, CodeBlockRead:
+		return s, nil
+	}
+	return "", fmt.Errorf("unknown code block strategy %q (want skip, announce, comments, identifiers or read)", name)
+}
+
+// codeStrategy returns the strategy for a fenced language
+func (c *ParserConfig) codeStrategy(language string) CodeBlockStrategy {
+	if s, ok := c.CodeLanguages[codeLanguage(language)]; ok {
+		return s
+	}
+	if c.CodeBlocks != "" {
+		return c.CodeBlocks
+	}
+	if c.IncludeCodeBlocks {
+		return CodeBlockRead
+	}
+	return CodeBlockSkip
+}
+
+// codeLanguage normalizes a fence info string ("Go title=x") to "go"
+func codeLanguage(info string) string {
+	fields := strings.Fields(info)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ToLower(fields[0])
+}
+
+// codeLanguageNames are spoken names for common fence languages
+var codeLanguageNames = map[string]string{
+	"go": "Go", "golang": "Go",
+	"js": "JavaScript", "javascript": "JavaScript", "jsx": "JavaScript",
+	"ts": "TypeScript", "typescript": "TypeScript", "tsx": "TypeScript",
+	"py": "Python", "python": "Python",
+	"rb": "Ruby", "ruby": "Ruby",
+	"rs": "Rust", "rust": "Rust",
+	"sh": "shell", "bash": "shell", "zsh": "shell", "shell": "shell", "console": "shell",
+	"c": "C", "h": "C",
+	"cpp": "C plus plus", "c++": "C plus plus", "hpp": "C plus plus",
+	"cs": "C sharp", "csharp": "C sharp",
+	"java": "Java", "kotlin": "Kotlin", "kt": "Kotlin", "swift": "Swift",
+	"sql": "SQL", "yaml": "YAML", "yml": "YAML", "json": "JSON", "toml": "TOML",
+	"html": "HTML", "xml": "XML", "css": "CSS", "md": "Markdown", "markdown": "Markdown",
+	"dockerfile": "Dockerfile", "makefile": "Makefile", "diff": "diff",
+}
+
+// commentSyntax describes the comment markers of a language
+type commentSyntax struct {
+	line  []string
+	block [][2]string
+}
+
+var (
+	slashComments = commentSyntax{line: []string{"//"}, block: [][2]string{{"/*", "*/"}}}
+	hashComments  = commentSyntax{line: []string{"#"}}
+	dashComments  = commentSyntax{line: []string{"--"}, block: [][2]string{{"/*", "*/"}}}
+	cssComments   = commentSyntax{block: [][2]string{{"/*", "*/"}}}
+	xmlComments   = commentSyntax{block: [][2]string{{"<!--", "-->"}}}
+	// Used when the language is missing or unknown
+	anyComments = commentSyntax{line: []string{"//", "#"}, block: [][2]string{{"/*", "*/"}}}
+)
+
+var codeCommentSyntax = map[string]commentSyntax{
+	"go": slashComments, "golang": slashComments, "c": slashComments, "h": slashComments,
+	"cpp": slashComments, "c++": slashComments, "hpp": slashComments,
+	"cs": slashComments, "csharp": slashComments, "java": slashComments,
+	"js": slashComments, "javascript": slashComments, "jsx": slashComments,
+	"ts": slashComments, "typescript": slashComments, "tsx": slashComments,
+	"rs": slashComments, "rust": slashComments, "swift": slashComments,
+	"kotlin": slashComments, "kt": slashComments, "scala": slashComments,
+	"dart": slashComments, "proto": slashComments, "zig": slashComments, "css": cssComments,
+	"py": hashComments, "python": hashComments, "rb": hashComments, "ruby": hashComments,
+	"sh": hashComments, "bash": hashComments, "zsh": hashComments, "shell": hashComments,
+	"console": hashComments, "yaml": hashComments, "yml": hashComments,
+	"toml": hashComments, "perl": hashComments, "r": hashComments,
+	"dockerfile": hashComments, "makefile": hashComments, "conf": hashComments,
+	"sql": dashComments, "lua": dashComments, "haskell": dashComments, "hs": dashComments,
+	"html": xmlComments, "xml": xmlComments, "md": xmlComments, "markdown": xmlComments,
+}
+
+// splitComments separates comments from code. The returned code keeps its
+// line breaks; consecutive full-line comments are joined into one.
+func splitComments(language, code string) (string, []string) {
+	syntax, ok := codeCommentSyntax[codeLanguage(language)]
+	if !ok {
+		syntax = anyComments
+	}
+
+	var out strings.Builder
+	var comments []string
+	// merge is set while only whitespace and one line break separate the
+	// scan position from the previous line comment
+	merge := false
+	newlines := 0
+
+	add := func(text string, line bool) {
+		text = strings.TrimSpace(text)
+		if line && merge && newlines <= 1 && len(comments) > 0 {
+			if text != "" {
+				comments[len(comments)-1] += " " + text
+			}
+		} else if text != "" {
+			comments = append(comments, text)
+		}
+		merge = line
+		newlines = 0
+	}
+
+	var quote byte
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+
+		if quote != 0 {
+			out.WriteByte(c)
+			switch {
+			case c == '\\' && i+1 < len(code):
+				i++
+				out.WriteByte(code[i])
+			case c == quote, c == '\n' && quote != '`':
+				quote = 0
+			}
+			continue
+		}
+
+		if block, ok := hasBlockComment(code[i:], syntax); ok {
+			end := strings.Index(code[i+len(block[0]):], block[1])
+			body := code[i+len(block[0]):]
+			if end >= 0 {
+				body = body[:end]
+			}
+			add(cleanBlockComment(body), false)
+			out.WriteString(strings.Repeat("\n", strings.Count(body, "\n")))
+			i += len(block[0]) + len(body) + len(block[1]) - 1
+			continue
+		}
+
+		if marker, ok := hasLineComment(code[i:], syntax); ok {
+			end := strings.IndexByte(code[i:], '\n')
+			if end < 0 {
+				end = len(code) - i
+			}
+			body := code[i+len(marker) : i+end]
+			// Shebangs and doc markers such as /// or #! are not prose
+			if strings.HasPrefix(body, "!") {
+				body = ""
+			}
+			add(strings.TrimLeft(body, marker[:1]), true)
+			i += end - 1
+			continue
+		}
+
+		switch {
+		case c == '\n':
+			newlines++
+		case c == '"' || c == '\'' || c == '`':
+			quote = c
+			merge = false
+		case c != ' ' && c != '\t' && c != '\r':
+			merge = false
+		}
+		out.WriteByte(c)
+	}
+
+	return out.String(), comments
+}
+
+func hasBlockComment(s string, syntax commentSyntax) ([2]string, bool) {
+	for _, block := range syntax.block {
+		if strings.HasPrefix(s, block[0]) {
+			return block, true
+		}
+	}
+	return [2]string{}, false
+}
+
+func hasLineComment(s string, syntax commentSyntax) (string, bool) {
+	for _, marker := range syntax.line {
+		if strings.HasPrefix(s, marker) {
+			return marker, true
+		}
+	}
+	return "", false
+}
+
+// cleanBlockComment drops the leading * of each line in /* */ comments
+func cleanBlockComment(body string) string {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "*"))
+	}
+	return strings.Join(nonEmpty(lines), " ")
+}
+
+// codeOperators are spoken names for operators, longest first
+var codeOperators = []struct{ symbol, words string }{
+	{"===", "equals"}, {"!==", "not equals"},
+	{":=", "assign"}, {"==", "equals"}, {"!=", "not equals"},
+	{"<=", "less or equal"}, {">=", "greater or equal"},
+	{"->", "arrow"}, {"=>", "arrow"}, {"<-", "receive"},
+	{"&&", "and"}, {"||", "or"}, {"++", "increment"}, {"--", "decrement"},
+	{"+=", "plus equals"}, {"-=", "minus equals"}, {"*=", "times equals"}, {"/=", "divide equals"},
+	{"::", "colon colon"},
+	{"=", "equals"}, {"+", "plus"}, {"-", "minus"}, {"*", "times"}, {"/", "slash"},
+	{"%", "mod"}, {"<", "less than"}, {">", "greater than"}, {"!", "not"},
+	{".", "dot"}, {"|", "pipe"}, {"&", "and"},
+}
+
+var (
+	codeOperatorWords = map[string]string{}
+	codeTokenPattern  = func() *regexp.Regexp {
+		ops := make([]string, len(codeOperators))
+		for i, op := range codeOperators {
+			ops[i] = regexp.QuoteMeta(op.symbol)
+			codeOperatorWords[op.symbol] = op.words
+		}
+		return regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*|\d+(?:\.\d+)?|` + strings.Join(ops, "|"))
+	}()
+)
+
+var camelBoundary = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+
+// verbalizeCode reads a line of code as words: identifiers are split at
+// underscores and camel case, operators are named and brackets, commas
+// and semicolons are dropped
+func verbalizeCode(line string) string {
+	var words []string
+	for _, loc := range codeTokenPattern.FindAllStringIndex(line, -1) {
+		token := line[loc[0]:loc[1]]
+		// Command line flags: -f, --force
+		if (token == "-" || token == "--") && (loc[0] == 0 || line[loc[0]-1] == ' ') &&
+			loc[1] < len(line) && isWordRune(rune(line[loc[1]])) {
+			words = append(words, strings.TrimSpace(strings.Repeat("dash ", len(token))))
+			continue
+		}
+		if w, ok := codeOperatorWords[token]; ok {
+			words = append(words, w)
+			continue
+		}
+		token = camelBoundary.ReplaceAllString(token, "$1 $2")
+		token = strings.TrimSpace(strings.ReplaceAll(token, "_", " "))
+		if token != "" {
+			words = append(words, token)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// announceCode describes a code block without reading it
+func announceCode(language, code string) string {
+	lines := plural(strings.Count(strings.TrimRight(code, "\n"), "\n")+1, "line")
+	lang := codeLanguage(language)
+	if lang == "" {
+		return "Code block, " + lines
+	}
+	name, ok := codeLanguageNames[lang]
+	if !ok {
+		name = lang
+	}
+	return fmt.Sprintf("Code block in %s, %s", name, lines)
+}
+
+// codeLines describes a code block as pairs of display and spoken text.
+// Display text is what appears in the rendered block, so the spoken part
+// can be located on screen.
+func codeLines(strategy CodeBlockStrategy, language, code string) (display, spoken []string) {
+	if strings.TrimSpace(code) == "" {
+		return nil, nil
+	}
+
+	switch strategy {
+	case CodeBlockAnnounce:
+		for _, line := range strings.Split(code, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				return []string{line}, []string{announceCode(language, code)}
+			}
+		}
+
+	case CodeBlockComments:
+		_, comments := splitComments(language, code)
+		return comments, comments
+
+	case CodeBlockIdentifiers:
+		stripped, _ := splitComments(language, code)
+		for _, line := range strings.Split(stripped, "\n") {
+			words := verbalizeCode(line)
+			if words == "" {
+				continue
+			}
+			display = append(display, strings.TrimSpace(line))
+			spoken = append(spoken, words)
+		}
+
+	case CodeBlockRead:
+		for _, line := range strings.Split(code, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				display = append(display, line)
+				spoken = append(spoken, line)
+			}
+		}
+	}
+	return display, spoken
+}
+
+// codeSentences turns a fenced code block into sentences using the
+// strategy configured for its language
+func (p *SentenceParser) codeSentences(language, code, markdown string) []ParsedSentence {
+	strategy := p.config.codeStrategy(language)
+	display, spoken := codeLines(strategy, language, code)
+
+	var sentences []ParsedSentence
+	for i := range display {
+		if strategy == CodeBlockComments {
+			// Comments are prose, so split them into sentences
+			for _, s := range p.extractSentences(display[i], markdown) {
+				s.Type = SentenceTypeCodeBlock
+				sentences = append(sentences, s)
+			}
+			continue
+		}
+		sentences = append(sentences, ParsedSentence{
+			Text:     display[i],
+			Spoken:   spoken[i],
+			Original: display[i],
+			Type:     SentenceTypeCodeBlock,
+		})
+	}
+	return sentences
+}
@@ -0,0 +1,109 @@
+package tts
+
+import (
+	"reflect"
+	"testing"
+)
+
+const goSnippet = `// Package main says hello.
+// It is an example.
+package main
+
+/*
+ * main prints a greeting
+ */
+func main() {
+	msg := "// not a comment"
+	fmt.Println(msg) // print it
+}`
+
+func TestSplitComments(t *testing.T) {
+	_, comments := splitComments("go", goSnippet)
+	want := []string{
+		"Package main says hello. It is an example.",
+		"main prints a greeting",
+		"print it",
+	}
+	if !reflect.DeepEqual(comments, want) {
+		t.Errorf("Unexpected comments:\n got %q\nwant %q", comments, want)
+	}
+
+	_, comments = splitComments("bash", "#!/bin/sh\n# Install it\napt install glow # quietly")
+	if want := []string{"Install it", "quietly"}; !reflect.DeepEqual(comments, want) {
+		t.Errorf("Unexpected shell comments: %q", comments)
+	}
+}
+
+func TestVerbalizeCode(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"userName := getUserName()", "user Name assign get User Name"},
+		{"if a >= max_len {", "if a greater or equal max len"},
+		{"}", ""},
+		{"kubectl apply -f deploy.yaml --force", "kubectl apply dash f deploy dot yaml dash dash force"},
+	}
+	for _, tt := range tests {
+		if got := verbalizeCode(tt.line); got != tt.want {
+			t.Errorf("verbalizeCode(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestCodeBlockStrategies(t *testing.T) {
+	markdown := "Intro text here.\n\n```go\n" + goSnippet + "\n```\n\n```bash\nmake build\n```"
+
+	spoken := func(config *ParserConfig) []string {
+		t.Helper()
+		parser, err := NewSentenceParser(config)
+		if err != nil {
+			t.Fatalf("Failed to create parser: %v", err)
+		}
+		sentences, err := parser.Parse(markdown)
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		var out []string
+		for _, s := range sentences {
+			out = append(out, s.SpokenText())
+		}
+		return out
+	}
+
+	config := DefaultParserConfig()
+	if got := spoken(config); !reflect.DeepEqual(got, []string{"Intro text here"}) {
+		t.Errorf("Expected code to be skipped by default, got %q", got)
+	}
+
+	config.CodeBlocks = CodeBlockAnnounce
+	want := []string{"Intro text here", "Code block in Go, 11 lines", "Code block in shell, 1 line"}
+	if got := spoken(config); !reflect.DeepEqual(got, want) {
+		t.Errorf("Announce:\n got %q\nwant %q", got, want)
+	}
+
+	config.CodeBlocks = CodeBlockComments
+	config.CodeLanguages = map[string]CodeBlockStrategy{"bash": CodeBlockIdentifiers}
+	want = []string{"Intro text here", "Package main says hello", "It is an example", "main prints a greeting", "print it", "make build"}
+	if got := spoken(config); !reflect.DeepEqual(got, want) {
+		t.Errorf("Per-language strategies:\n got %q\nwant %q", got, want)
+	}
+}
+
+func TestMarkdownProcessorCodeStrategy(t *testing.T) {
+	config := DefaultParserConfig()
+	config.CodeBlocks = CodeBlockAnnounce
+	processor := NewMarkdownProcessor(config)
+
+	sentences, err := processor.ExtractSpeakableSentences("```python\nprint('hi')\n```")
+	if err != nil {
+		t.Fatalf("ExtractSpeakableSentences failed: %v", err)
+	}
+	if len(sentences) != 1 || sentences[0].SpokenText() != "Code block in Python, 1 line" {
+		t.Errorf("Unexpected sentences: %+v", sentences)
+	}
+
+	if _, err := ParseCodeBlockStrategy("whisper"); err == nil {
+		t.Error("Expected error for unknown strategy")
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/utils"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 )
@@ -24,14 +26,29 @@ type TTSConfig struct {
 	// Playback settings
 	Playback PlaybackConfig `yaml:"playback" mapstructure:"playback"`
 	
+	// Pronunciation lexicon settings
+	Lexicon LexiconConfig `yaml:"lexicon" mapstructure:"lexicon"`
+	
+	// How document structure is read aloud
+	Reading ReadingConfig `yaml:"reading" mapstructure:"reading"`
+	
+	// Language detection and per-language voices
+	Languages LanguageConfig `yaml:"languages" mapstructure:"languages"`
+	
+	// Control socket for driving playback from other programs
+	Control ControlConfig `yaml:"control" mapstructure:"control"`
+	
 	// Advanced settings
 	Advanced AdvancedConfig `yaml:"advanced" mapstructure:"advanced"`
 }
 
 // EngineConfigs holds configuration for each engine
 type EngineConfigs struct {
-	Piper PiperConfig `yaml:"piper" mapstructure:"piper"`
-	GTTS  GTTSConfig  `yaml:"gtts" mapstructure:"gtts"`
+	Piper   PiperConfig   `yaml:"piper" mapstructure:"piper"`
+	GTTS    GTTSConfig    `yaml:"gtts" mapstructure:"gtts"`
+	ESpeak  ESpeakConfig  `yaml:"espeak" mapstructure:"espeak"`
+	Command CommandConfig `yaml:"command" mapstructure:"command"`
+	OpenAI  OpenAIConfig  `yaml:"openai" mapstructure:"openai"`
 }
 
 // PiperConfig holds Piper-specific configuration
@@ -47,6 +64,12 @@ type PiperConfig struct {
 	
 	// Enable cache
 	EnableCache bool `yaml:"enable_cache" mapstructure:"enable_cache"`
+	
+	// Keep one piper process loaded instead of starting one per sentence
+	PersistentWorker bool `yaml:"persistent_worker" mapstructure:"persistent_worker"`
+	
+	// Extra directories searched for voice models
+	VoiceDirs []string `yaml:"voice_dirs" mapstructure:"voice_dirs"`
 }
 
 // GTTSConfig holds Google TTS-specific configuration
@@ -64,6 +87,71 @@ type GTTSConfig struct {
 	Slow bool `yaml:"slow" mapstructure:"slow"`
 }
 
+// ESpeakConfig holds espeak-ng-specific configuration
+type ESpeakConfig struct {
+	// Voice name (e.g., "en", "en-us", "en+f3")
+	Voice string `yaml:"voice" mapstructure:"voice"`
+	
+	// Pitch adjustment (0 to 99, 50 is neutral)
+	Pitch int `yaml:"pitch" mapstructure:"pitch"`
+	
+	// Extra pause between words in units of 10ms
+	WordGap int `yaml:"word_gap" mapstructure:"word_gap"`
+}
+
+// CommandConfig configures an external synthesizer driven by a command template
+type CommandConfig struct {
+	// Command template, e.g. "mimic3 --voice {voice} --length-scale {length_scale}".
+	// Placeholders: {text}, {voice}, {speed}, {length_scale}, {output}
+	Template string `yaml:"template" mapstructure:"template"`
+	
+	// Voice substituted for {voice}
+	Voice string `yaml:"voice" mapstructure:"voice"`
+	
+	// How text is passed: "stdin" or "argv"
+	Input string `yaml:"input" mapstructure:"input"`
+	
+	// Audio the command produces: "raw" (s16le mono), "wav" or "mp3"
+	Output string `yaml:"output" mapstructure:"output"`
+	
+	// Sample rate of raw output in Hz
+	SampleRate int `yaml:"sample_rate" mapstructure:"sample_rate"`
+	
+	// Synthesis timeout in seconds
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	
+	// The command reads SSML, so headings and pauses are sent as markup
+	SSML bool `yaml:"ssml" mapstructure:"ssml"`
+}
+
+// OpenAIConfig holds settings for OpenAI-compatible speech servers
+// (Kokoro-FastAPI, openedai-speech, LocalAI, ...)
+type OpenAIConfig struct {
+	// Server base URL; /v1/audio/speech is appended
+	BaseURL string `yaml:"base_url" mapstructure:"base_url"`
+	
+	// Model name sent with each request (e.g. "tts-1", "kokoro")
+	Model string `yaml:"model" mapstructure:"model"`
+	
+	// Voice name (e.g. "alloy", "af_bella")
+	Voice string `yaml:"voice" mapstructure:"voice"`
+	
+	// API key sent as a bearer token (optional for local servers)
+	APIKey string `yaml:"api_key" mapstructure:"api_key"`
+	
+	// Response format: "wav", "pcm", "mp3", "opus", "aac" or "flac"
+	ResponseFormat string `yaml:"response_format" mapstructure:"response_format"`
+	
+	// Sample rate of "pcm" responses in Hz
+	SampleRate int `yaml:"sample_rate" mapstructure:"sample_rate"`
+	
+	// Request timeout in seconds
+	Timeout int `yaml:"timeout" mapstructure:"timeout"`
+	
+	// Retries for failed requests
+	Retries int `yaml:"retries" mapstructure:"retries"`
+}
+
 // TTSCacheConfig holds cache-related settings
 type TTSCacheConfig struct {
 	// Enable caching
@@ -92,6 +180,88 @@ type PlaybackConfig struct {
 	
 	// Auto-play on document open
 	AutoPlay bool `yaml:"auto_play" mapstructure:"auto_play"`
+	
+	// Remember where each document was left and offer to continue
+	Resume bool `yaml:"resume" mapstructure:"resume"`
+	
+	// Reading progress file (defaults to ~/.local/state/glow/tts/progress.json)
+	ProgressFile string `yaml:"progress_file" mapstructure:"progress_file"`
+	
+	// Play the document as one continuous stream instead of sentence by sentence
+	Gapless bool `yaml:"gapless" mapstructure:"gapless"`
+	
+	// Overlap between adjacent sentences in gapless playback
+	CrossfadeMs int `yaml:"crossfade_ms" mapstructure:"crossfade_ms"`
+	
+	// Silences in gapless playback: between sentences, around headings,
+	// and around lists, tables and code blocks
+	SentencePauseMs int `yaml:"sentence_pause_ms" mapstructure:"sentence_pause_ms"`
+	HeadingPauseMs  int `yaml:"heading_pause_ms" mapstructure:"heading_pause_ms"`
+	BlockPauseMs    int `yaml:"block_pause_ms" mapstructure:"block_pause_ms"`
+	
+	// Highlight the word being spoken as well as the sentence, using
+	// timings estimated from the audio
+	HighlightWords bool `yaml:"highlight_words" mapstructure:"highlight_words"`
+}
+
+// LexiconConfig holds pronunciation lexicon settings
+type LexiconConfig struct {
+	// Rewrite terms with the lexicon before synthesis
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	
+	// User lexicon file (defaults to ~/.config/glow/tts/lexicon.yml)
+	File string `yaml:"file" mapstructure:"file"`
+	
+	// Also load .glow-lexicon.yml from the document's directory
+	ProjectFiles bool `yaml:"project_files" mapstructure:"project_files"`
+}
+
+// ControlConfig holds control socket settings
+type ControlConfig struct {
+	// Listen for play, pause and navigation requests while reading
+	Enabled bool `yaml:"enabled" mapstructure:"enabled"`
+	
+	// Socket path (defaults to $XDG_RUNTIME_DIR/glow-tts.sock); its
+	// directory must only be accessible by the user
+	Socket string `yaml:"socket" mapstructure:"socket"`
+}
+
+// ReadingConfig controls how markdown structure is spoken
+type ReadingConfig struct {
+	// How code blocks are spoken: skip, announce, comments, identifiers
+	// or read
+	CodeBlocks string `yaml:"code_blocks" mapstructure:"code_blocks"`
+	
+	// Per-language overrides of code_blocks, keyed by fence language
+	CodeLanguages map[string]string `yaml:"code_languages" mapstructure:"code_languages"`
+	
+	// Where footnotes are read: paragraph, section or skip
+	Footnotes string `yaml:"footnotes" mapstructure:"footnotes"`
+	
+	// How struck text is read: skip or announce ("deleted")
+	Strikethrough string `yaml:"strikethrough" mapstructure:"strikethrough"`
+	
+	// Summarize tables with more rows than this instead of reading each
+	// row (0 reads every row)
+	TableSummaryRows int `yaml:"table_summary_rows" mapstructure:"table_summary_rows"`
+	
+	// Announce the title and author from YAML front matter
+	FrontMatter bool `yaml:"front_matter" mapstructure:"front_matter"`
+}
+
+// LanguageConfig controls per-sentence language detection and the voice
+// used for each language
+type LanguageConfig struct {
+	// Detect the language of each sentence (lang hints apply regardless)
+	Detect bool `yaml:"detect" mapstructure:"detect"`
+	
+	// Languages to choose between when detecting (empty means all
+	// supported languages)
+	Candidates []string `yaml:"candidates" mapstructure:"candidates"`
+	
+	// Voice per language code for the active engine. An empty voice
+	// selects the language on engines that support it (gtts, espeak).
+	Voices map[string]string `yaml:"voices" mapstructure:"voices"`
 }
 
 // AdvancedConfig holds advanced settings
@@ -115,10 +285,11 @@ func DefaultTTSConfig() *TTSConfig {
 		DefaultEngine: "piper",
 		Engines: EngineConfigs{
 			Piper: PiperConfig{
-				ModelPath:    "",
-				Voice:        "",
-				DefaultSpeed: 1.0,
-				EnableCache:  true,
+				ModelPath:        "",
+				Voice:            "",
+				DefaultSpeed:     1.0,
+				EnableCache:      true,
+				PersistentWorker: true,
 			},
 			GTTS: GTTSConfig{
 				Language:     "en",
@@ -126,6 +297,26 @@ func DefaultTTSConfig() *TTSConfig {
 				TLD:          "com",
 				Slow:         false,
 			},
+			ESpeak: ESpeakConfig{
+				Voice:   "en",
+				Pitch:   50,
+				WordGap: 0,
+			},
+			Command: CommandConfig{
+				Input:      "stdin",
+				Output:     "wav",
+				SampleRate: 22050,
+				Timeout:    30,
+			},
+			OpenAI: OpenAIConfig{
+				BaseURL:        "http://localhost:8880",
+				Model:          "tts-1",
+				Voice:          "alloy",
+				ResponseFormat: "wav",
+				SampleRate:     24000,
+				Timeout:        30,
+				Retries:        2,
+			},
 		},
 		Cache: TTSCacheConfig{
 			Enabled:         true,
@@ -138,6 +329,23 @@ func DefaultTTSConfig() *TTSConfig {
 			SpeedIncrement:     0.25,
 			LookaheadSentences: 3,
 			AutoPlay:           false,
+			Resume:             true,
+			Gapless:            true,
+			CrossfadeMs:        DefaultCrossfadeMs,
+			SentencePauseMs:    200,
+			HeadingPauseMs:     600,
+			BlockPauseMs:       400,
+		},
+		Lexicon: LexiconConfig{
+			Enabled:      true,
+			ProjectFiles: true,
+		},
+		Reading: ReadingConfig{
+			CodeBlocks:       string(CodeBlockAnnounce),
+			Footnotes:        string(FootnotesParagraph),
+			Strikethrough:    string(StrikethroughSkip),
+			TableSummaryRows: 20,
+			FrontMatter:      true,
 		},
 		Advanced: AdvancedConfig{
 			SynthesisTimeout: 30,
@@ -277,6 +485,105 @@ func (c *TTSConfig) ApplyToController(cfg *ControllerConfig) {
 	// cfg.WorkerThreads = c.Advanced.WorkerThreads
 }
 
+// LoadLexicon loads the pronunciation lexicon for a document (documentPath
+// may be empty). It returns nil when the lexicon is disabled.
+func (c *TTSConfig) LoadLexicon(documentPath string) (*Lexicon, error) {
+	if !c.Lexicon.Enabled {
+		return nil, nil
+	}
+	if !c.Lexicon.ProjectFiles {
+		documentPath = ""
+	}
+	return LoadLexicons(utils.ExpandPath(c.Lexicon.File), documentPath)
+}
+
+// ParserConfig returns sentence parser settings for the reading options
+func (c *TTSConfig) ParserConfig() *ParserConfig {
+	config := DefaultParserConfig()
+	config.TableSummaryRows = c.Reading.TableSummaryRows
+	config.AnnounceFrontMatter = c.Reading.FrontMatter
+	config.DetectLanguage = c.Languages.Detect
+	config.Languages = c.Languages.Candidates
+	
+	if c.Reading.CodeBlocks != "" {
+		strategy, err := ParseCodeBlockStrategy(c.Reading.CodeBlocks)
+		if err != nil {
+			log.Warn("Ignoring invalid code block setting", "error", err)
+		} else {
+			config.CodeBlocks = strategy
+		}
+	}
+	if c.Reading.Footnotes != "" {
+		placement, err := ParseFootnotePlacement(c.Reading.Footnotes)
+		if err != nil {
+			log.Warn("Ignoring invalid footnote setting", "error", err)
+		} else {
+			config.Footnotes = placement
+		}
+	}
+	if c.Reading.Strikethrough != "" {
+		mode, err := ParseStrikethroughMode(c.Reading.Strikethrough)
+		if err != nil {
+			log.Warn("Ignoring invalid strikethrough setting", "error", err)
+		} else {
+			config.Strikethrough = mode
+		}
+	}
+	for lang, name := range c.Reading.CodeLanguages {
+		strategy, err := ParseCodeBlockStrategy(name)
+		if err != nil {
+			log.Warn("Ignoring invalid code block setting", "language", lang, "error", err)
+			continue
+		}
+		if config.CodeLanguages == nil {
+			config.CodeLanguages = make(map[string]CodeBlockStrategy)
+		}
+		config.CodeLanguages[codeLanguage(lang)] = strategy
+	}
+	return config
+}
+
+// GaplessConfig returns the gapless playback settings, or nil when
+// sentences are played one at a time
+func (c *TTSConfig) GaplessConfig() *GaplessConfig {
+	if !c.Playback.Gapless {
+		return nil
+	}
+	return &GaplessConfig{
+		CrossfadeMs:   max(c.Playback.CrossfadeMs, 0),
+		SentencePause: time.Duration(max(c.Playback.SentencePauseMs, 0)) * time.Millisecond,
+		HeadingPause:  time.Duration(max(c.Playback.HeadingPauseMs, 0)) * time.Millisecond,
+		BlockPause:    time.Duration(max(c.Playback.BlockPauseMs, 0)) * time.Millisecond,
+	}
+}
+
+// CacheConfig returns the synthesis cache settings, or nil when caching is
+// disabled
+func (c *TTSConfig) CacheConfig() *CacheConfig {
+	if !c.Cache.Enabled {
+		return nil
+	}
+	config := DefaultCacheConfig()
+	if c.Cache.Directory != "" {
+		config.CacheDir = utils.ExpandPath(c.Cache.Directory)
+	}
+	if c.Cache.MaxSizeMB > 0 {
+		config.L2SizeLimit = int64(c.Cache.MaxSizeMB) * 1024 * 1024
+	}
+	if c.Cache.ExpirationHours > 0 {
+		config.L2TTL = time.Duration(c.Cache.ExpirationHours) * time.Hour
+	}
+	return config
+}
+
+// ControlSocket returns the control socket path
+func (c *TTSConfig) ControlSocket() string {
+	if c.Control.Socket != "" {
+		return utils.ExpandPath(c.Control.Socket)
+	}
+	return DefaultControlSocket()
+}
+
 // GetEngineOrDefault returns the specified engine or the default if empty
 func (c *TTSConfig) GetEngineOrDefault(engine string) string {
 	if engine == "" {
@@ -0,0 +1,368 @@
+package tts
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// Control methods understood by the control socket
+const (
+	ControlPlay     = "play"
+	ControlPause    = "pause"
+	ControlNext     = "next"
+	ControlPrevious = "previous"
+	ControlSeek     = "seek"
+	ControlSpeed    = "speed"
+	ControlStatus   = "status"
+)
+
+// JSON-RPC error codes
+const (
+	controlParseError     = -32700
+	controlInvalidRequest = -32600
+	controlMethodNotFound = -32601
+	controlInvalidParams  = -32602
+	controlFailed         = -32000
+)
+
+// controlMaxRequest caps the size of one request line
+const controlMaxRequest = 64 * 1024
+
+// ControlTarget is what the control socket drives: the TUI, or a headless
+// reader around a Controller
+type ControlTarget interface {
+	Play() error
+	Pause() error
+	Next() error
+	Previous() error
+	// Seek plays from a sentence, counted from 0
+	Seek(sentence int) error
+	SetSpeed(speed float64) error
+	Status() (ControlStatusResult, error)
+}
+
+// ControlStatusResult is the result of the status method
+type ControlStatusResult struct {
+	// State is a PlaybackState: "playing", "paused" or "stopped"
+	State string `json:"state"`
+	// Sentence is the current sentence counted from 0, or -1
+	Sentence  int     `json:"sentence"`
+	Sentences int     `json:"sentences"`
+	Speed     float64 `json:"speed"`
+	Text      string  `json:"text,omitempty"`
+	Document  string  `json:"document,omitempty"`
+}
+
+// ControlSeekParams are the parameters of the seek method
+type ControlSeekParams struct {
+	Sentence int `json:"sentence"`
+}
+
+// ControlSpeedParams are the parameters of the speed method
+type ControlSpeedParams struct {
+	Speed float64 `json:"speed"`
+}
+
+// ControlError is an error returned over the control socket
+type ControlError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *ControlError) Error() string {
+	return e.Message
+}
+
+// controlRequest is a JSON-RPC 2.0 request, one per line
+type controlRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// controlResponse is a JSON-RPC 2.0 response, one per line
+type controlResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *ControlError   `json:"error,omitempty"`
+}
+
+// DefaultControlSocket returns the control socket path: glow-tts.sock in
+// $XDG_RUNTIME_DIR, or in a per-user directory in the temporary directory
+func DefaultControlSocket() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "glow-tts.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("glow-tts-%d", os.Getuid()), "glow-tts.sock")
+}
+
+// ControlServer serves the control protocol on a Unix domain socket
+type ControlServer struct {
+	path     string
+	target   ControlTarget
+	listener net.Listener
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// ListenControl listens on the socket at path and serves requests for
+// target in the background. The socket's directory is created if needed
+// and must be private to the user. A socket left behind by a process that
+// exited is replaced; one that is still answering is an error.
+func ListenControl(path string, target ControlTarget) (*ControlServer, error) {
+	if target == nil {
+		return nil, errors.New("control target is required")
+	}
+	// Nobody else can enter the directory, so nobody else can connect or
+	// put something at the socket path
+	if err := privateControlDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a socket", path)
+		}
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("control socket %s is in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale control socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on control socket: %w", err)
+	}
+	// Only the user may drive playback, whatever the umask
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to secure control socket: %w", err)
+	}
+
+	s := &ControlServer{
+		path:     path,
+		target:   target,
+		listener: listener,
+		conns:    make(map[net.Conn]struct{}),
+	}
+	s.wg.Add(1)
+	go s.accept()
+	log.Debug("Control socket listening", "path", path)
+	return s, nil
+}
+
+// Path returns the socket path
+func (s *ControlServer) Path() string {
+	return s.path
+}
+
+// Close stops serving, disconnects clients and removes the socket
+func (s *ControlServer) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	err := s.listener.Close()
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	// The listener normally unlinks the socket; make sure it is gone
+	if rmErr := os.Remove(s.path); rmErr != nil && !os.IsNotExist(rmErr) && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+func (s *ControlServer) accept() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.mu.Lock()
+			closed := s.closed
+			s.mu.Unlock()
+			if !closed {
+				log.Warn("Control socket stopped accepting", "error", err)
+			}
+			return
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+
+		go s.serve(conn)
+	}
+}
+
+// serve answers the requests of one client, one line each
+func (s *ControlServer) serve(conn net.Conn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), controlMaxRequest)
+	enc := json.NewEncoder(conn)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		resp, reply := s.handle(scanner.Bytes())
+		if !reply {
+			continue
+		}
+		if err := enc.Encode(resp); err != nil {
+			return
+		}
+	}
+}
+
+// handle decodes one request and carries it out. Notifications, requests
+// without an id, get no reply.
+func (s *ControlServer) handle(line []byte) (controlResponse, bool) {
+	var req controlRequest
+	if err := json.Unmarshal(line, &req); err != nil {
+		return controlErrorResponse(nil, controlParseError, "invalid JSON"), true
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return controlErrorResponse(req.ID, controlInvalidRequest, "invalid request"), true
+	}
+	resp := s.call(req)
+	return resp, req.ID != nil
+}
+
+// call carries out a valid request
+func (s *ControlServer) call(req controlRequest) controlResponse {
+	var result any = true
+	var err error
+	switch req.Method {
+	case ControlPlay:
+		err = s.target.Play()
+	case ControlPause:
+		err = s.target.Pause()
+	case ControlNext:
+		err = s.target.Next()
+	case ControlPrevious:
+		err = s.target.Previous()
+	case ControlSeek:
+		var params ControlSeekParams
+		if json.Unmarshal(req.Params, &params) != nil || params.Sentence < 0 {
+			return controlErrorResponse(req.ID, controlInvalidParams, "seek needs a sentence number of 0 or more")
+		}
+		err = s.target.Seek(params.Sentence)
+	case ControlSpeed:
+		var params ControlSpeedParams
+		if json.Unmarshal(req.Params, &params) != nil || params.Speed < MinSpeed || params.Speed > MaxSpeed {
+			return controlErrorResponse(req.ID, controlInvalidParams, fmt.Sprintf("speed must be between %.2f and %.2f", MinSpeed, MaxSpeed))
+		}
+		err = s.target.SetSpeed(params.Speed)
+	case ControlStatus:
+		result, err = s.target.Status()
+	default:
+		return controlErrorResponse(req.ID, controlMethodNotFound, "unknown method "+req.Method)
+	}
+	if err != nil {
+		return controlErrorResponse(req.ID, controlFailed, err.Error())
+	}
+	return controlResponse{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+func controlErrorResponse(id json.RawMessage, code int, message string) controlResponse {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return controlResponse{JSONRPC: "2.0", ID: id, Error: &ControlError{Code: code, Message: message}}
+}
+
+// ControlClient calls methods on a control socket
+type ControlClient struct {
+	conn    net.Conn
+	scanner *bufio.Scanner
+	id      int
+}
+
+// DialControl connects to the control socket at path
+func DialControl(path string) (*ControlClient, error) {
+	conn, err := net.DialTimeout("unix", path, 2*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf("no glow instance is listening on %s: %w", path, err)
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), controlMaxRequest)
+	return &ControlClient{conn: conn, scanner: scanner}, nil
+}
+
+// Call invokes method with params (which may be nil) and decodes the
+// result into result (which may be nil)
+func (c *ControlClient) Call(method string, params, result any) error {
+	c.id++
+	req := controlRequest{JSONRPC: "2.0", ID: json.RawMessage(fmt.Sprint(c.id)), Method: method}
+	if params != nil {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		req.Params = raw
+	}
+	if err := json.NewEncoder(c.conn).Encode(req); err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	if !c.scanner.Scan() {
+		if err := c.scanner.Err(); err != nil {
+			return fmt.Errorf("failed to read response: %w", err)
+		}
+		return errors.New("control socket closed the connection")
+	}
+	var resp struct {
+		Result json.RawMessage `json:"result"`
+		Error  *ControlError   `json:"error"`
+	}
+	if err := json.Unmarshal(c.scanner.Bytes(), &resp); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if result != nil && len(resp.Result) > 0 {
+		return json.Unmarshal(resp.Result, result)
+	}
+	return nil
+}
+
+// Close closes the connection
+func (c *ControlClient) Close() error {
+	return c.conn.Close()
+}
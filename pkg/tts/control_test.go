@@ -0,0 +1,259 @@
+package tts
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockControlTarget records the calls made through the control socket
+type mockControlTarget struct {
+	mu    sync.Mutex
+	calls []string
+	seek  int
+	speed float64
+	err   error
+}
+
+func (m *mockControlTarget) record(call string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, call)
+	return m.err
+}
+
+func (m *mockControlTarget) Play() error     { return m.record(ControlPlay) }
+func (m *mockControlTarget) Pause() error    { return m.record(ControlPause) }
+func (m *mockControlTarget) Next() error     { return m.record(ControlNext) }
+func (m *mockControlTarget) Previous() error { return m.record(ControlPrevious) }
+
+func (m *mockControlTarget) Seek(sentence int) error {
+	m.seek = sentence
+	return m.record(ControlSeek)
+}
+
+func (m *mockControlTarget) SetSpeed(speed float64) error {
+	m.speed = speed
+	return m.record(ControlSpeed)
+}
+
+func (m *mockControlTarget) Status() (ControlStatusResult, error) {
+	return ControlStatusResult{State: PlaybackPlaying.String(), Sentence: 2, Sentences: 10, Speed: 1.5, Text: "Hello."}, m.record(ControlStatus)
+}
+
+func (m *mockControlTarget) recorded() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.calls...)
+}
+
+// listenTestControl serves target on a socket in a temporary directory
+func listenTestControl(t *testing.T, target ControlTarget) *ControlServer {
+	t.Helper()
+
+	// Socket paths are limited to about 100 bytes, which t.TempDir can exceed
+	dir, err := os.MkdirTemp("", "ctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	server, err := ListenControl(filepath.Join(dir, "glow-tts.sock"), target)
+	if err != nil {
+		t.Fatalf("ListenControl failed: %v", err)
+	}
+	t.Cleanup(func() { server.Close() })
+	return server
+}
+
+func TestControlSocket(t *testing.T) {
+	target := &mockControlTarget{}
+	server := listenTestControl(t, target)
+
+	client, err := DialControl(server.Path())
+	if err != nil {
+		t.Fatalf("DialControl failed: %v", err)
+	}
+	defer client.Close()
+
+	for _, method := range []string{ControlPlay, ControlPause, ControlNext, ControlPrevious} {
+		if err := client.Call(method, nil, nil); err != nil {
+			t.Errorf("%s failed: %v", method, err)
+		}
+	}
+	if err := client.Call(ControlSeek, ControlSeekParams{Sentence: 4}, nil); err != nil || target.seek != 4 {
+		t.Errorf("Expected seek to sentence 4, got %d: %v", target.seek, err)
+	}
+	if err := client.Call(ControlSpeed, ControlSpeedParams{Speed: 1.25}, nil); err != nil || target.speed != 1.25 {
+		t.Errorf("Expected speed 1.25, got %v: %v", target.speed, err)
+	}
+
+	var status ControlStatusResult
+	if err := client.Call(ControlStatus, nil, &status); err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	if status.State != "playing" || status.Sentence != 2 || status.Sentences != 10 || status.Text != "Hello." {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	want := []string{ControlPlay, ControlPause, ControlNext, ControlPrevious, ControlSeek, ControlSpeed, ControlStatus}
+	if got := target.recorded(); strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("Expected calls %v, got %v", want, got)
+	}
+}
+
+func TestControlSocketErrors(t *testing.T) {
+	target := &mockControlTarget{}
+	server := listenTestControl(t, target)
+
+	client, err := DialControl(server.Path())
+	if err != nil {
+		t.Fatalf("DialControl failed: %v", err)
+	}
+	defer client.Close()
+
+	tests := []struct {
+		method string
+		params any
+		code   int
+	}{
+		{"rewind", nil, controlMethodNotFound},
+		{ControlSeek, ControlSeekParams{Sentence: -1}, controlInvalidParams},
+		{ControlSeek, nil, controlInvalidParams},
+		{ControlSpeed, ControlSpeedParams{Speed: 5}, controlInvalidParams},
+	}
+	for _, tt := range tests {
+		err := client.Call(tt.method, tt.params, nil)
+		var ctlErr *ControlError
+		if !errors.As(err, &ctlErr) || ctlErr.Code != tt.code {
+			t.Errorf("%s %v: expected error code %d, got %v", tt.method, tt.params, tt.code, err)
+		}
+	}
+	if len(target.recorded()) != 0 {
+		t.Errorf("Expected invalid requests not to reach the target, got %v", target.recorded())
+	}
+
+	target.err = errors.New("no document is open")
+	if err := client.Call(ControlPlay, nil, nil); err == nil || err.Error() != "no document is open" {
+		t.Errorf("Expected the target's error, got %v", err)
+	}
+}
+
+func TestControlSocketRaw(t *testing.T) {
+	target := &mockControlTarget{}
+	server := listenTestControl(t, target)
+
+	conn, err := net.Dial("unix", server.Path())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	// A notification gets no reply, so the next line answers the status
+	if _, err := conn.Write([]byte(`{"jsonrpc":"2.0","method":"pause"}` + "\n" + `{"jsonrpc":"2.0","id":"a","method":"status"}` + "\n" + "not json\n")); err != nil {
+		t.Fatal(err)
+	}
+	scanner := bufio.NewScanner(conn)
+	var lines []string
+	for len(lines) < 2 && scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if len(lines) != 2 {
+		t.Fatalf("Expected two replies, got %q", lines)
+	}
+	if !strings.HasPrefix(lines[0], `{"jsonrpc":"2.0","id":"a","result":{"state":"playing"`) {
+		t.Errorf("Unexpected status reply %s", lines[0])
+	}
+	if !strings.Contains(lines[1], `"code":-32700`) {
+		t.Errorf("Expected a parse error, got %s", lines[1])
+	}
+	if calls := target.recorded(); len(calls) != 2 || calls[0] != ControlPause {
+		t.Errorf("Expected the notification to be carried out, got %v", calls)
+	}
+}
+
+func TestListenControlSocketInUse(t *testing.T) {
+	server := listenTestControl(t, &mockControlTarget{})
+
+	if _, err := ListenControl(server.Path(), &mockControlTarget{}); err == nil {
+		t.Error("Expected an error for a socket another instance is serving")
+	}
+
+	// A socket left behind by a process that exited is replaced
+	stale := filepath.Join(filepath.Dir(server.Path()), "stale.sock")
+	listener, err := net.Listen("unix", stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	listener.Close()
+
+	replaced, err := ListenControl(stale, &mockControlTarget{})
+	if err != nil {
+		t.Fatalf("Expected the stale socket to be replaced: %v", err)
+	}
+	replaced.Close()
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("Expected Close to remove the socket, got %v", err)
+	}
+}
+
+func TestListenControlPrivateDirectory(t *testing.T) {
+	dir, err := os.MkdirTemp("", "ctl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	// A missing directory is created private to the user
+	nested := filepath.Join(dir, "run")
+	server, err := ListenControl(filepath.Join(nested, "glow-tts.sock"), &mockControlTarget{})
+	if err != nil {
+		t.Fatalf("ListenControl failed: %v", err)
+	}
+	server.Close()
+	info, err := os.Stat(nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0700 {
+		t.Errorf("Expected the socket directory to be created with mode 0700, got %v", perm)
+	}
+
+	// A directory others can enter is refused
+	shared := filepath.Join(dir, "shared")
+	if err := os.Mkdir(shared, 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(shared, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ListenControl(filepath.Join(shared, "glow-tts.sock"), &mockControlTarget{}); err == nil {
+		t.Error("Expected an error for a socket directory others can access")
+	}
+
+	// Whatever is at the socket path is left alone unless it is a socket
+	file := filepath.Join(dir, "file.sock")
+	if err := os.WriteFile(file, []byte("data"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ListenControl(file, &mockControlTarget{}); err == nil {
+		t.Error("Expected an error for a path that is not a socket")
+	}
+	if data, err := os.ReadFile(file); err != nil || string(data) != "data" {
+		t.Errorf("Expected the file to be left alone, got %q, %v", data, err)
+	}
+}
+
+func TestControlDisabledByDefault(t *testing.T) {
+	if DefaultTTSConfig().Control.Enabled {
+		t.Error("Expected the control socket to be opt-in")
+	}
+}
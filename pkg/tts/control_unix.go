@@ -0,0 +1,32 @@
+//go:build !windows
+// +build !windows
+
+package tts
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// privateControlDir creates dir if needed and checks that it is a directory
+// owned by the user that no one else can access
+func privateControlDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create control socket directory: %w", err)
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return fmt.Errorf("failed to check control socket directory: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("control socket directory %s is not a directory", dir)
+	}
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok && int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("control socket directory %s is owned by another user", dir)
+	}
+	if info.Mode().Perm()&0077 != 0 {
+		return fmt.Errorf("control socket directory %s must only be accessible by its owner (mode 0700), has %v", dir, info.Mode().Perm())
+	}
+	return nil
+}
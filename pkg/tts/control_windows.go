@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package tts
+
+import (
+	"fmt"
+	"os"
+)
+
+// privateControlDir creates dir if needed. Access to it is left to the
+// profile's permissions, which Windows keeps per user.
+func privateControlDir(dir string) error {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create control socket directory: %w", err)
+	}
+	return nil
+}
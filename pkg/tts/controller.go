@@ -64,6 +64,15 @@ type Controller struct {
 	// speedCtrl manages playback speed control
 	speedCtrl SpeedController
 
+	// lexicon rewrites terms into their spoken form before synthesis
+	lexicon *Lexicon
+
+	// languageEngines speak sentences tagged with other languages
+	languageEngines map[string]TTSEngine
+
+	// gapless plays the queue as one continuous stream when set
+	gapless *GaplessConfig
+
 	// state management
 	stateMu sync.RWMutex
 	state   ControllerState
@@ -81,7 +90,7 @@ type Controller struct {
 
 // ControllerConfig holds configuration for the TTS controller.
 type ControllerConfig struct {
-	// Engine specifies which TTS engine to use ("piper" or "gtts")
+	// Engine specifies which TTS engine to use (see engines.Names)
 	Engine string
 
 	// EnableCache enables/disables caching
@@ -176,6 +185,62 @@ func (c *Controller) SetSpeedController(speedCtrl SpeedController) error {
 	return nil
 }
 
+// SetLexicon sets the pronunciation lexicon. Unlike the other setters it
+// may be called while running; segments already synthesized keep their audio.
+func (c *Controller) SetLexicon(lexicon *Lexicon) {
+	c.stateMu.Lock()
+	c.lexicon = lexicon
+	queue := c.queue
+	c.stateMu.Unlock()
+
+	if queue != nil {
+		queue.SetLexicon(lexicon)
+	}
+}
+
+// SetLanguageEngines sets the engines that speak sentences tagged with a
+// language, keyed by language code. Like SetLexicon it may be called while
+// running.
+func (c *Controller) SetLanguageEngines(engines map[string]TTSEngine) {
+	c.stateMu.Lock()
+	c.languageEngines = engines
+	queue := c.queue
+	c.stateMu.Unlock()
+
+	if queue != nil {
+		queue.SetLanguageEngines(engines)
+	}
+}
+
+// UseEngine switches the engine used for synthesis, for example to a
+// document's own voice. Like SetLexicon it may be called while running;
+// segments already synthesized keep the previous voice.
+func (c *Controller) UseEngine(engine TTSEngine) error {
+	if engine == nil {
+		return fmt.Errorf("engine is required")
+	}
+
+	c.stateMu.Lock()
+	c.engine = engine
+	queue := c.queue
+	c.stateMu.Unlock()
+
+	if queue != nil {
+		queue.SetEngine(engine)
+	}
+	return nil
+}
+
+// SetGapless enables gapless playback, which crossfades sentences and
+// separates them with the configured pauses instead of playing each one on
+// its own. A nil config plays sentence by sentence. Like SetLexicon it may
+// be called while running; it applies from the next sentence played.
+func (c *Controller) SetGapless(config *GaplessConfig) {
+	c.stateMu.Lock()
+	defer c.stateMu.Unlock()
+	c.gapless = config
+}
+
 // SetSpeed sets the playback speed.
 func (c *Controller) SetSpeed(speed float64) error {
 	if c.speedCtrl == nil {
@@ -194,6 +259,41 @@ func (c *Controller) GetSpeed() float64 {
 
 // Play starts or resumes TTS playback.
 func (c *Controller) Play(text string) error {
+	return c.play(text)
+}
+
+// PlayFrom starts playback of text at the given sentence index, replacing
+// anything already queued. It is used to resume a document where the reader
+// left off.
+func (c *Controller) PlayFrom(text string, sentence int) error {
+	if sentence < 0 {
+		return fmt.Errorf("invalid sentence index %d", sentence)
+	}
+	if c.queue == nil {
+		return fmt.Errorf("queue not initialized")
+	}
+	if c.parser == nil {
+		return fmt.Errorf("text parser not initialized")
+	}
+
+	sentences, err := c.parser.ParseSentences(text)
+	if err != nil {
+		return fmt.Errorf("failed to parse text: %w", err)
+	}
+	if sentence >= len(sentences) {
+		return fmt.Errorf("sentence %d out of range (document has %d)", sentence, len(sentences))
+	}
+
+	if player := GetGlobalAudioPlayer(); player != nil {
+		player.Stop()
+	}
+	c.queue.Clear()
+	c.queue.SetStartPosition(sentence)
+	return c.play(text)
+}
+
+// play queues text and starts playing the segment at the queue's start position.
+func (c *Controller) play(text string) error {
 	c.stateMu.Lock()
 	state := c.state
 	// Set state to running when we start playing
@@ -230,21 +330,12 @@ func (c *Controller) Play(text string) error {
 		}
 		
 		if segment != nil {
-			// Use ProcessedAudio if available, otherwise fall back to raw Audio
-			audioToPlay := segment.ProcessedAudio
-			if len(audioToPlay) == 0 {
-				log.Debug("Controller: ProcessedAudio not available, using raw Audio",
-					"hasAudio", segment.Audio != nil,
-					"audioSize", len(segment.Audio))
-				audioToPlay = segment.Audio
+			player := GetGlobalAudioPlayer()
+			if player == nil {
+				return fmt.Errorf("audio player not initialized")
 			}
-			
-			if len(audioToPlay) > 0 {
-				player := GetGlobalAudioPlayer()
-				if player == nil {
-					return fmt.Errorf("audio player not initialized")
-				}
-				return player.PlayPCM(audioToPlay)
+			if played, err := c.playSegment(player, segment); played {
+				return err
 			}
 		}
 		
@@ -276,14 +367,18 @@ func (c *Controller) Play(text string) error {
 		// Concatenate all sentences with brief pauses
 		var fullText strings.Builder
 		for i, sentence := range sentencesToProcess {
-			fullText.WriteString(sentence.Text)
+			fullText.WriteString(sentence.SpokenText())
 			if i < len(sentencesToProcess)-1 {
 				fullText.WriteString(". ") // Add pause between sentences
 			}
 		}
 		
 		// Synthesize the full text
-		audio, err := c.engine.Synthesize(fullText.String(), c.GetSpeed())
+		c.stateMu.RLock()
+		spoken := c.lexicon.Apply(fullText.String())
+		engine := c.engine
+		c.stateMu.RUnlock()
+		audio, err := SynthesizeContext(c.ctx, engine, spoken, c.GetSpeed())
 		if err != nil {
 			return fmt.Errorf("synthesis failed: %w", err)
 		}
@@ -350,13 +445,15 @@ func (c *Controller) Next() error {
 		return fmt.Errorf("failed to get next segment: %w", err)
 	}
 	
-	if segment != nil && segment.ProcessedAudio != nil {
+	if segment != nil {
 		// Stop current playback
 		player := GetGlobalAudioPlayer()
 		if player != nil {
 			player.Stop()
 			// Play the next segment
-			return player.PlayPCM(segment.ProcessedAudio)
+			if played, err := c.playSegment(player, segment); played {
+				return err
+			}
 		}
 	}
 	
@@ -375,19 +472,72 @@ func (c *Controller) Previous() error {
 		return fmt.Errorf("failed to get previous segment: %w", err)
 	}
 	
-	if segment != nil && segment.ProcessedAudio != nil {
+	if segment != nil {
 		// Stop current playback
 		player := GetGlobalAudioPlayer()
 		if player != nil {
 			player.Stop()
 			// Play the previous segment
-			return player.PlayPCM(segment.ProcessedAudio)
+			if played, err := c.playSegment(player, segment); played {
+				return err
+			}
 		}
 	}
 	
 	return fmt.Errorf("no previous segment available")
 }
 
+// playSegment plays the best audio available for a segment: the
+// preprocessed audio, the raw audio, or the stream of a segment that is
+// still being synthesized. With gapless playback the rest of the queue
+// follows it. It reports false if the segment has no audio yet.
+func (c *Controller) playSegment(player *TTSAudioPlayer, segment *AudioSegment) (bool, error) {
+	c.queue.mu.RLock()
+	processed, audio, stream := segment.ProcessedAudio, segment.Audio, segment.Stream
+	c.queue.mu.RUnlock()
+
+	c.stateMu.RLock()
+	gapless := c.gapless
+	c.stateMu.RUnlock()
+	
+	switch {
+	case gapless != nil && (len(processed) > 0 || len(audio) > 0 || stream != nil):
+		return true, player.PlayGapless(NewGaplessReader(c.queue, gapless))
+	case len(processed) > 0:
+		return true, player.PlayPCM(processed)
+	case len(audio) > 0:
+		log.Debug("Controller: ProcessedAudio not available, using raw Audio", "audioSize", len(audio))
+		return true, player.PlayPCM(audio)
+	case stream != nil:
+		log.Debug("Controller: playing segment while it is synthesized")
+		return true, player.PlayStream(stream)
+	}
+	return false, nil
+}
+
+// CurrentSentence returns the document position of the sentence being played,
+// or -1 if nothing has been queued yet.
+func (c *Controller) CurrentSentence() int {
+	if c.queue == nil {
+		return -1
+	}
+	return c.queue.CurrentPosition()
+}
+
+// CurrentWord returns the index of the word being spoken in the current
+// sentence, counting the words of its text as TimingWords does, or -1 when
+// it is not known.
+func (c *Controller) CurrentWord() int {
+	player := GetGlobalAudioPlayer()
+	if c.queue == nil || player == nil {
+		return -1
+	}
+	segmentID, offset, ok := player.SegmentPosition()
+	if !ok {
+		return -1
+	}
+	return c.queue.wordAt(segmentID, offset)
+}
 
 // GetState returns the current controller state.
 func (c *Controller) GetState() ControllerState {
@@ -414,11 +564,32 @@ type Sentence struct {
 	// Text is the cleaned text ready for synthesis
 	Text string
 
+	// Spoken is the normalized form of Text, if it differs
+	Spoken string
+
 	// Position is the sentence index in the document
 	Position int
 
 	// Original is the original text with formatting
 	Original string
+
+	// Language is the sentence's language code, or "" for the default
+	Language string
+
+	// Type is the markdown element the sentence comes from
+	Type SentenceType
+
+	// Speech adds pauses, emphasis and prosody to the spoken text, or is
+	// nil when the sentence is read as plain text
+	Speech *Speech
+}
+
+// SpokenText returns the text to synthesize for the sentence.
+func (s Sentence) SpokenText() string {
+	if s.Spoken != "" {
+		return s.Spoken
+	}
+	return s.Text
 }
 
 // CacheManager defines the interface for managing TTS cache.
@@ -500,6 +671,11 @@ func (c *Controller) Initialize() error {
 		queueConfig := DefaultQueueConfig()
 		queueConfig.Engine = c.engine
 		queueConfig.Parser = c.parser
+		queueConfig.Lexicon = c.lexicon
+		queueConfig.LanguageEngines = c.languageEngines
+		if c.gapless != nil {
+			queueConfig.CrossfadeDurationMs = c.gapless.CrossfadeMs
+		}
 		// Note: Cache manager types are incompatible for now
 		// TODO: Create adapter or unify cache interfaces
 		
@@ -578,6 +754,12 @@ func (c *Controller) Stop() error {
 		c.cancel()
 	}
 
+	// Abandon synthesis in progress so workers exit promptly, even if the
+	// controller was never started
+	if c.queue != nil {
+		c.queue.CancelSynthesis()
+	}
+
 	// Wait for goroutines with timeout
 	done := make(chan struct{})
 	go func() {
@@ -135,6 +135,18 @@ func (sd *SystemDependencies) PrintReport() string {
 		}
 	}
 	
+	if status, ok := sd.Results["espeak-ng"]; ok {
+		report.WriteString("\neSpeak NG Engine:\n")
+		if status.Installed {
+			report.WriteString(installedStyle.Render("  ✓ espeak-ng: "))
+			report.WriteString(fmt.Sprintf("%s %s\n", status.Path, status.Version))
+		} else {
+			report.WriteString(optionalStyle.Render("  ○ espeak-ng: "))
+			report.WriteString("Not installed (optional)\n")
+			report.WriteString(fmt.Sprintf("    %s\n", status.Instructions))
+		}
+	}
+
 	if status, ok := sd.Results["ffmpeg"]; ok {
 		if status.Installed {
 			report.WriteString(installedStyle.Render("  ✓ ffmpeg: "))
@@ -200,7 +212,10 @@ func (pc *PiperChecker) GetInstructions() string {
 }
 
 // PiperModelsChecker checks for ONNX models
-type PiperModelsChecker struct{}
+type PiperModelsChecker struct {
+	// Dirs overrides the standard voice locations
+	Dirs []string
+}
 
 func (pmc *PiperModelsChecker) Check() DependencyStatus {
 	status := DependencyStatus{
@@ -208,35 +223,16 @@ func (pmc *PiperModelsChecker) Check() DependencyStatus {
 		Required: false, // Optional since we have GTTS
 	}
 	
-	// Check common model locations
-	modelPaths := []string{
-		"/usr/share/piper-voices",
-		"/usr/local/share/piper-voices",
-		filepath.Join(os.Getenv("HOME"), ".local", "share", "piper-voices"),
-		filepath.Join(os.Getenv("HOME"), ".config", "piper", "voices"),
-		filepath.Join(os.Getenv("HOME"), "piper-voices"),
+	dirs := pmc.Dirs
+	if len(dirs) == 0 {
+		dirs = DefaultPiperVoiceDirs()
 	}
 	
-	for _, basePath := range modelPaths {
-		// Look for any .onnx files
-		pattern := filepath.Join(basePath, "**", "*.onnx")
-		if matches, err := filepath.Glob(pattern); err == nil && len(matches) > 0 {
-			status.Installed = true
-			status.Path = basePath
-			status.Version = fmt.Sprintf("%d models found", len(matches))
-			return status
-		}
-		
-		// Also check direct subdirectories
-		if entries, err := os.ReadDir(basePath); err == nil {
-			for _, entry := range entries {
-				if strings.HasSuffix(entry.Name(), ".onnx") {
-					status.Installed = true
-					status.Path = basePath
-					return status
-				}
-			}
-		}
+	if voices := ScanPiperVoices(dirs...); len(voices) > 0 {
+		status.Installed = true
+		status.Path = filepath.Dir(voices[0].Path)
+		status.Version = fmt.Sprintf("%d models found", len(voices))
+		return status
 	}
 	
 	status.Instructions = pmc.GetInstructions()
@@ -299,6 +295,68 @@ func (gc *GTTSChecker) GetInstructions() string {
 		"    Or: pipx install gtts"
 }
 
+// ESpeakChecker checks for espeak-ng
+type ESpeakChecker struct{}
+
+func (ec *ESpeakChecker) Check() DependencyStatus {
+	status := DependencyStatus{
+		Name:     "espeak-ng",
+		Required: false, // Optional, offline fallback engine
+	}
+
+	paths := []string{
+		"espeak-ng",
+		"/usr/local/bin/espeak-ng",
+		"/usr/bin/espeak-ng",
+		"/opt/homebrew/bin/espeak-ng",
+	}
+
+	for _, path := range paths {
+		fullPath, err := exec.LookPath(path)
+		if err != nil {
+			continue
+		}
+		// espeak-ng --version prints e.g. "eSpeak NG text-to-speech: 1.51  Data at: ..."
+		cmd := exec.Command(fullPath, "--version")
+		if output, err := cmd.CombinedOutput(); err == nil {
+			status.Installed = true
+			status.Path = fullPath
+			fields := strings.Fields(string(output))
+			for i, field := range fields {
+				if strings.HasSuffix(field, "text-to-speech:") && i+1 < len(fields) {
+					status.Version = fields[i+1]
+					break
+				}
+			}
+			return status
+		}
+	}
+
+	status.Instructions = ec.GetInstructions()
+	return status
+}
+
+func (ec *ESpeakChecker) GetInstructions() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "Install with: brew install espeak-ng"
+	case "linux":
+		distro := detectLinuxDistro()
+		if strings.Contains(distro, "debian") || strings.Contains(distro, "ubuntu") {
+			return "Install with: sudo apt-get install espeak-ng"
+		} else if strings.Contains(distro, "fedora") || strings.Contains(distro, "rhel") {
+			return "Install with: sudo dnf install espeak-ng"
+		} else if strings.Contains(distro, "arch") {
+			return "Install with: sudo pacman -S espeak-ng"
+		}
+		return "Install with your package manager: espeak-ng"
+	case "windows":
+		return "Download from: https://github.com/espeak-ng/espeak-ng/releases\n    Install and add to PATH"
+	default:
+		return "Install espeak-ng from: https://github.com/espeak-ng/espeak-ng"
+	}
+}
+
 // FFmpegChecker checks for ffmpeg
 type FFmpegChecker struct{}
 
@@ -376,23 +434,37 @@ func detectLinuxDistro() string {
 func CheckSystemDependencies(engine string) (*SystemDependencies, error) {
 	deps := NewSystemDependencies()
 	
+	// Models are looked up where the engine would find them, so configured
+	// voice_dirs and model_path count
+	config, _ := LoadTTSConfig()
+	models := &PiperModelsChecker{Dirs: PiperVoiceDirs(config)}
+	
 	switch engine {
 	case "piper":
 		// Piper engine dependencies
 		deps.AddChecker("piper", &PiperChecker{})
-		deps.AddChecker("piper_models", &PiperModelsChecker{})
+		deps.AddChecker("piper_models", models)
 		
 	case "gtts":
 		// Google TTS dependencies
 		deps.AddChecker("gtts-cli", &GTTSChecker{})
 		deps.AddChecker("ffmpeg", &FFmpegChecker{})
 		
+	case "espeak":
+		// eSpeak NG dependencies
+		deps.AddChecker("espeak-ng", &ESpeakChecker{})
+		
+	case "command", "openai":
+		// The command engine's binary comes from its template and the
+		// OpenAI-compatible server is health checked by the engine itself
+		
 	case "":
 		// Check all dependencies
 		deps.AddChecker("piper", &PiperChecker{})
-		deps.AddChecker("piper_models", &PiperModelsChecker{})
+		deps.AddChecker("piper_models", models)
 		deps.AddChecker("gtts-cli", &GTTSChecker{})
 		deps.AddChecker("ffmpeg", &FFmpegChecker{})
+		deps.AddChecker("espeak-ng", &ESpeakChecker{})
 		
 	default:
 		return nil, fmt.Errorf("unknown engine: %s", engine)
@@ -424,6 +496,10 @@ func ValidateEngineAvailability(engine string) error {
 			if !deps.Results["ffmpeg"].Installed {
 				return fmt.Errorf("ffmpeg not installed: %s", deps.Results["ffmpeg"].Instructions)
 			}
+		case "espeak":
+			if !deps.Results["espeak-ng"].Installed {
+				return fmt.Errorf("espeak-ng not installed: %s", deps.Results["espeak-ng"].Instructions)
+			}
 		}
 	}
 	return nil
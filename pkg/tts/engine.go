@@ -1,5 +1,10 @@
 package tts
 
+import (
+	"context"
+	"io"
+)
+
 // TTSEngine defines the interface that all TTS engine implementations must satisfy.
 // Engines are responsible for converting text to speech audio data.
 type TTSEngine interface {
@@ -27,6 +32,69 @@ type TTSEngine interface {
 	IsAvailable() bool
 }
 
+// ContextEngine is implemented by engines whose synthesis can be cancelled,
+// so work on sentences the reader has skipped past is abandoned promptly.
+type ContextEngine interface {
+	TTSEngine
+
+	// SynthesizeContext behaves like Synthesize but stops when ctx is done,
+	// terminating any helper process and returning ctx.Err().
+	SynthesizeContext(ctx context.Context, text string, speed float64) ([]byte, error)
+}
+
+// StreamingEngine is implemented by engines that can return audio while it
+// is still being synthesized, so playback of long sentences starts sooner.
+type StreamingEngine interface {
+	TTSEngine
+
+	// SynthesizeStream starts synthesis and returns the PCM audio as it is
+	// produced, in the same format as Synthesize. Cancelling ctx or closing
+	// the reader stops synthesis. Read errors report synthesis failures.
+	SynthesizeStream(ctx context.Context, text string, speed float64) (io.ReadCloser, error)
+}
+
+// SSMLEngine is implemented by engines that can read SSML, so pauses,
+// emphasis and prosody reach the synthesizer instead of being approximated.
+type SSMLEngine interface {
+	TTSEngine
+
+	// SupportsSSML reports whether the engine, as configured, accepts SSML.
+	SupportsSSML() bool
+
+	// SynthesizeSSML synthesizes a <speak> document to PCM audio in the same
+	// format as Synthesize, stopping when ctx is done.
+	SynthesizeSSML(ctx context.Context, ssml string, speed float64) ([]byte, error)
+}
+
+// SynthesizeContext synthesizes text, honouring ctx even for engines that do
+// not implement ContextEngine. Their synthesis runs to completion in the
+// background, but the caller is released as soon as ctx is done.
+func SynthesizeContext(ctx context.Context, engine TTSEngine, text string, speed float64) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ce, ok := engine.(ContextEngine); ok {
+		return ce.SynthesizeContext(ctx, text, speed)
+	}
+
+	type result struct {
+		audio []byte
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		audio, err := engine.Synthesize(text, speed)
+		done <- result{audio, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.audio, r.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // EngineConfig holds common configuration for TTS engines.
 type EngineConfig struct {
 	// Voice specifies the voice/model to use for synthesis
@@ -40,4 +108,4 @@ type EngineConfig struct {
 
 	// Timeout specifies the maximum time for synthesis operations
 	Timeout int // in seconds
-}
\ No newline at end of file
+}
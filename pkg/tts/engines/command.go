@@ -0,0 +1,398 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// Command engine input modes
+const (
+	// CommandInputStdin writes the text to the command's stdin
+	CommandInputStdin = "stdin"
+	// CommandInputArgv passes the text as an argument ({text} or appended)
+	CommandInputArgv = "argv"
+)
+
+// Command engine output formats
+const (
+	// CommandOutputRaw is 16-bit mono little-endian PCM
+	CommandOutputRaw = "raw"
+	// CommandOutputWAV is a WAV file
+	CommandOutputWAV = "wav"
+	// CommandOutputMP3 is compressed audio decoded with ffmpeg
+	CommandOutputMP3 = "mp3"
+)
+
+// CommandEngine implements the TTSEngine interface for any synthesizer that
+// can be driven from a command line template
+type CommandEngine struct {
+	config tts.CommandConfig
+	// argv is the template split into arguments, placeholders unexpanded
+	argv []string
+	// binaryPath is the resolved path of argv[0]
+	binaryPath   string
+	ffmpegBinary string
+
+	speed       float64
+	speedMapper *tts.TTSSpeedController
+	subprocess  *tts.SubprocessManager
+	timeout     time.Duration
+}
+
+// NewCommandEngine creates an engine from a command template configuration
+func NewCommandEngine(config tts.CommandConfig) (*CommandEngine, error) {
+	if strings.TrimSpace(config.Template) == "" {
+		return nil, fmt.Errorf("command engine requires a template (engines.command.template)")
+	}
+
+	argv, err := splitCommand(config.Template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid command template: %w", err)
+	}
+
+	if config.Input == "" {
+		config.Input = CommandInputStdin
+	}
+	if config.Input != CommandInputStdin && config.Input != CommandInputArgv {
+		return nil, fmt.Errorf("invalid command input mode %q (must be stdin or argv)", config.Input)
+	}
+
+	if config.Output == "" {
+		config.Output = CommandOutputWAV
+	}
+	switch config.Output {
+	case CommandOutputRaw, CommandOutputWAV, CommandOutputMP3:
+	default:
+		return nil, fmt.Errorf("invalid command output format %q (must be raw, wav or mp3)", config.Output)
+	}
+
+	if config.SampleRate <= 0 {
+		config.SampleRate = tts.SampleRate
+	}
+
+	timeout := 30 * time.Second
+	if config.Timeout > 0 {
+		timeout = time.Duration(config.Timeout) * time.Second
+	}
+
+	engine := &CommandEngine{
+		config:      config,
+		argv:        argv,
+		speed:       DefaultSpeed,
+		speedMapper: tts.NewSpeedController(),
+		subprocess:  tts.NewSubprocessManager(timeout),
+		timeout:     timeout,
+	}
+
+	if path, err := exec.LookPath(argv[0]); err == nil {
+		engine.binaryPath = path
+	}
+	if path, err := findFFmpeg(); err == nil {
+		engine.ffmpegBinary = path
+	}
+
+	log.Debug("Command engine initialized", "command", argv[0], "input", config.Input, "output", config.Output)
+	return engine, nil
+}
+
+// Synthesize runs the command template for text and returns PCM audio
+func (e *CommandEngine) Synthesize(text string, speed float64) ([]byte, error) {
+	return e.SynthesizeContext(context.Background(), text, speed)
+}
+
+// SynthesizeContext runs the command template, killing the command if ctx
+// is cancelled
+func (e *CommandEngine) SynthesizeContext(ctx context.Context, text string, speed float64) ([]byte, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("empty text")
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	if speed <= 0 {
+		speed = e.speed
+	}
+
+	var outputFile string
+	if e.usesPlaceholder("{output}") {
+		dir, err := os.MkdirTemp("", "glow-command-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp directory: %w", err)
+		}
+		defer os.RemoveAll(dir)
+		outputFile = filepath.Join(dir, "speech."+e.config.Output)
+	}
+
+	args := e.expandArgs(text, speed, outputFile)
+	opts := tts.SafeProcessOptions{
+		Command:    e.binaryPath,
+		Args:       args,
+		Timeout:    e.timeout,
+		StdoutOnly: true,
+	}
+	if e.config.Input == CommandInputStdin {
+		opts.Input = text
+	}
+
+	audio, err := e.subprocess.ExecuteSafe(ctx, opts)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("%s failed: %w", filepath.Base(e.argv[0]), err)
+	}
+	if outputFile != "" {
+		if audio, err = os.ReadFile(outputFile); err != nil {
+			return nil, fmt.Errorf("failed to read command output: %w", err)
+		}
+	}
+	if len(audio) == 0 {
+		return nil, fmt.Errorf("no audio data generated")
+	}
+
+	// Commands that cannot change their own rate are sped up with atempo
+	tempo := 1.0
+	if !e.usesPlaceholder("{speed}") && !e.usesPlaceholder("{length_scale}") {
+		tempo = e.speedMapper.GetEngineParameter(tts.EngineTypeGoogle, speed).Value.(float64)
+	}
+
+	return e.toPCM(ctx, audio, tempo)
+}
+
+// SupportsSSML reports whether the command is configured to read SSML
+func (e *CommandEngine) SupportsSSML() bool {
+	return e.config.SSML
+}
+
+// SynthesizeSSML passes an SSML document to the command in place of text
+func (e *CommandEngine) SynthesizeSSML(ctx context.Context, ssml string, speed float64) ([]byte, error) {
+	return e.SynthesizeContext(ctx, ssml, speed)
+}
+
+// SynthesizeStream pipes raw PCM from the command as it is produced. Only
+// templates writing 22050Hz raw audio to stdout at their own speed can be
+// streamed; anything needing conversion is synthesized in full first.
+func (e *CommandEngine) SynthesizeStream(ctx context.Context, text string, speed float64) (io.ReadCloser, error) {
+	if speed <= 0 {
+		speed = e.speed
+	}
+	if !e.canStream(speed) {
+		audio, err := e.SynthesizeContext(ctx, text, speed)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(audio)), nil
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("empty text")
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	input := ""
+	if e.config.Input == CommandInputStdin {
+		input = text
+	}
+	stream, err := e.subprocess.StreamProcess(ctx, input, e.binaryPath, e.expandArgs(text, speed, "")...)
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %w", filepath.Base(e.argv[0]), err)
+	}
+	return stream, nil
+}
+
+// canStream reports whether stdout can be played without conversion
+func (e *CommandEngine) canStream(speed float64) bool {
+	if e.config.Output != CommandOutputRaw || e.config.SampleRate != tts.SampleRate {
+		return false
+	}
+	if e.usesPlaceholder("{output}") {
+		return false
+	}
+	return speed == 1.0 || e.usesPlaceholder("{speed}") || e.usesPlaceholder("{length_scale}")
+}
+
+// expandArgs substitutes the template placeholders for one request. All
+// placeholders are replaced in one pass, so placeholders in the text are
+// passed through as written.
+func (e *CommandEngine) expandArgs(text string, speed float64, outputFile string) []string {
+	lengthScale := e.speedMapper.GetEngineParameter(tts.EngineTypePiper, speed).Value.(float64)
+	argText := text
+	if e.config.Input != CommandInputArgv {
+		argText = ""
+	}
+	replacer := strings.NewReplacer(
+		"{text}", argText,
+		"{voice}", e.config.Voice,
+		"{speed}", fmt.Sprintf("%.2f", speed),
+		"{length_scale}", fmt.Sprintf("%.2f", lengthScale),
+		"{output}", outputFile,
+	)
+
+	hasText := false
+	args := make([]string, 0, len(e.argv))
+	for _, arg := range e.argv[1:] {
+		if strings.Contains(arg, "{text}") {
+			hasText = true
+		}
+		args = append(args, replacer.Replace(arg))
+	}
+
+	if e.config.Input == CommandInputArgv && !hasText {
+		args = append(args, text)
+	}
+	return args
+}
+
+// toPCM converts the command's output to the 22050Hz mono s16le contract
+func (e *CommandEngine) toPCM(ctx context.Context, audio []byte, tempo float64) ([]byte, error) {
+	if e.config.Output == CommandOutputMP3 || tempo != 1.0 {
+		return e.convertWithFFmpeg(ctx, audio, tempo)
+	}
+
+	switch e.config.Output {
+	case CommandOutputWAV:
+		return tts.WAVToPCM(audio)
+	default:
+		source := tts.DefaultPCMFormat()
+		source.SampleRate = e.config.SampleRate
+		audio = audio[:len(audio)-len(audio)%tts.BytesPerSample]
+		return tts.ResamplePCM(audio, source, tts.DefaultPCMFormat())
+	}
+}
+
+// convertWithFFmpeg decodes audio through ffmpeg, as the Google engine does
+func (e *CommandEngine) convertWithFFmpeg(ctx context.Context, audio []byte, tempo float64) ([]byte, error) {
+	if e.ffmpegBinary == "" {
+		if e.config.Output == CommandOutputMP3 {
+			return nil, fmt.Errorf("ffmpeg is required to decode mp3 command output")
+		}
+		log.Warn("ffmpeg not found, ignoring speed for command engine")
+		return e.toPCM(ctx, audio, 1.0)
+	}
+
+	var inputArgs []string
+	if e.config.Output == CommandOutputRaw {
+		inputArgs = []string{"-f", "s16le", "-ar", strconv.Itoa(e.config.SampleRate), "-ac", "1"}
+	}
+	return decodeWithFFmpeg(ctx, e.subprocess, e.ffmpegBinary, audio, inputArgs, tempo, e.timeout)
+}
+
+// usesPlaceholder reports whether the template contains placeholder
+func (e *CommandEngine) usesPlaceholder(placeholder string) bool {
+	return strings.Contains(e.config.Template, placeholder)
+}
+
+// SetSpeed sets the default speaking speed
+func (e *CommandEngine) SetSpeed(speed float64) error {
+	if speed < MinSpeed || speed > MaxSpeed {
+		return fmt.Errorf("speed must be between %.1f and %.1f", MinSpeed, MaxSpeed)
+	}
+	e.speed = speed
+	return nil
+}
+
+// SetVoice sets the value substituted for {voice}
+func (e *CommandEngine) SetVoice(voice string) {
+	e.config.Voice = voice
+}
+
+// GetName returns the engine name
+func (e *CommandEngine) GetName() string {
+	name := filepath.Base(e.argv[0])
+	if e.config.Voice != "" {
+		return fmt.Sprintf("Command (%s, %s)", name, e.config.Voice)
+	}
+	return fmt.Sprintf("Command (%s)", name)
+}
+
+// Validate checks if the engine is properly configured
+func (e *CommandEngine) Validate() error {
+	if e.binaryPath == "" {
+		return fmt.Errorf("command not found: %s", e.argv[0])
+	}
+	if e.config.Output == CommandOutputMP3 && e.ffmpegBinary == "" {
+		return fmt.Errorf("ffmpeg is required to decode mp3 command output")
+	}
+	return nil
+}
+
+// IsAvailable checks if the engine can be used
+func (e *CommandEngine) IsAvailable() bool {
+	return e.Validate() == nil
+}
+
+// GetInfo returns information about the engine configuration
+func (e *CommandEngine) GetInfo() map[string]string {
+	return map[string]string{
+		"engine":   "command",
+		"template": e.config.Template,
+		"binary":   e.binaryPath,
+		"voice":    e.config.Voice,
+		"input":    e.config.Input,
+		"output":   e.config.Output,
+		"ssml":     strconv.FormatBool(e.config.SSML),
+		"format":   "PCM 16-bit mono",
+	}
+}
+
+// Cleanup performs cleanup for the command engine
+func (e *CommandEngine) Cleanup() error {
+	// Each request runs a short-lived process and removes its temp files
+	return nil
+}
+
+// splitCommand splits a template into arguments, honouring single and double
+// quotes. No shell is involved, so text never needs escaping.
+func splitCommand(template string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	for _, r := range template {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	return args, nil
+}
@@ -0,0 +1,272 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// fakeSynth writes a script that records its arguments and stdin, then
+// prints audioFile (or copies it to the path after --out)
+func fakeSynth(t *testing.T, audio []byte) (binary, argsFile, stdinFile string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake synthesizer requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	audioFile := filepath.Join(dir, "audio")
+	if err := os.WriteFile(audioFile, audio, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	argsFile = filepath.Join(dir, "args")
+	stdinFile = filepath.Join(dir, "stdin")
+	binary = filepath.Join(dir, "synth")
+	script := `#!/bin/sh
+printf '%s\n' "$@" > ` + argsFile + `
+cat > ` + stdinFile + `
+out=""
+while [ $# -gt 0 ]; do
+	if [ "$1" = "--out" ]; then out="$2"; fi
+	shift
+done
+echo "synth: working" >&2
+if [ -n "$out" ]; then cp ` + audioFile + ` "$out"; else cat ` + audioFile + `; fi
+`
+	if err := os.WriteFile(binary, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return binary, argsFile, stdinFile
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+}
+
+func TestCommandEngineStdinWAV(t *testing.T) {
+	var wav bytes.Buffer
+	if err := tts.WriteWAV(&wav, make([]byte, 2205*2), tts.DefaultPCMFormat()); err != nil {
+		t.Fatal(err)
+	}
+	binary, argsFile, stdinFile := fakeSynth(t, wav.Bytes())
+
+	engine, err := NewCommandEngine(tts.CommandConfig{
+		Template: binary + " --voice {voice} --length-scale {length_scale}",
+		Voice:    "en_US/vctk_low",
+	})
+	if err != nil {
+		t.Fatalf("NewCommandEngine failed: %v", err)
+	}
+
+	pcm, err := engine.Synthesize("Hello there.", 2.0)
+	if err != nil {
+		t.Fatalf("Synthesize failed: %v", err)
+	}
+	if len(pcm) != 2205*2 {
+		t.Errorf("Expected %d bytes of PCM, got %d", 2205*2, len(pcm))
+	}
+
+	args := readLines(t, argsFile)
+	expected := []string{"--voice", "en_US/vctk_low", "--length-scale", "0.50"}
+	if strings.Join(args, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected args %q, got %q", expected, args)
+	}
+
+	stdin, _ := os.ReadFile(stdinFile)
+	if string(stdin) != "Hello there." {
+		t.Errorf("Expected text on stdin, got %q", stdin)
+	}
+}
+
+func TestCommandEngineArgvRawOutputFile(t *testing.T) {
+	// 11025Hz raw output is resampled to the 22050Hz contract
+	binary, argsFile, _ := fakeSynth(t, make([]byte, 1000*2))
+
+	engine, err := NewCommandEngine(tts.CommandConfig{
+		Template:   binary + " --out {output} --text '{text}' --speed {speed}",
+		Input:      CommandInputArgv,
+		Output:     CommandOutputRaw,
+		SampleRate: 11025,
+	})
+	if err != nil {
+		t.Fatalf("NewCommandEngine failed: %v", err)
+	}
+
+	pcm, err := engine.Synthesize("It's a test.", 1.0)
+	if err != nil {
+		t.Fatalf("Synthesize failed: %v", err)
+	}
+	if diff := len(pcm) - 2000*2; diff < -4 || diff > 4 {
+		t.Errorf("Expected about %d bytes of PCM, got %d", 2000*2, len(pcm))
+	}
+
+	args := readLines(t, argsFile)
+	if len(args) != 6 || args[3] != "It's a test." || args[5] != "1.00" {
+		t.Errorf("Unexpected args %q", args)
+	}
+	if !strings.HasSuffix(args[1], "speech.raw") {
+		t.Errorf("Expected {output} to be a temp file, got %q", args[1])
+	}
+}
+
+func TestCommandEnginePlaceholdersInText(t *testing.T) {
+	binary, argsFile, _ := fakeSynth(t, make([]byte, 1000*2))
+
+	engine, err := NewCommandEngine(tts.CommandConfig{
+		Template:   binary + " --out {output} --text {text} --voice {voice}",
+		Input:      CommandInputArgv,
+		Output:     CommandOutputRaw,
+		SampleRate: 22050,
+		Voice:      "amy",
+	})
+	if err != nil {
+		t.Fatalf("NewCommandEngine failed: %v", err)
+	}
+
+	text := "Write to {output} with {voice} at {speed}."
+	if _, err := engine.Synthesize(text, 1.0); err != nil {
+		t.Fatalf("Synthesize failed: %v", err)
+	}
+	args := readLines(t, argsFile)
+	if len(args) != 6 || args[3] != text || args[5] != "amy" {
+		t.Errorf("Expected the text passed as written, got %q", args)
+	}
+}
+
+func TestCommandEngineStream(t *testing.T) {
+	binary, _, stdinFile := fakeSynth(t, make([]byte, 4410*2))
+
+	engine, err := NewCommandEngine(tts.CommandConfig{
+		Template: binary + " --length-scale {length_scale}",
+		Output:   CommandOutputRaw,
+	})
+	if err != nil {
+		t.Fatalf("NewCommandEngine failed: %v", err)
+	}
+	if !engine.canStream(1.5) {
+		t.Fatal("Expected 22050Hz raw stdout to be streamable")
+	}
+
+	stream, err := engine.SynthesizeStream(context.Background(), "Streamed text.", 1.5)
+	if err != nil {
+		t.Fatalf("SynthesizeStream failed: %v", err)
+	}
+	pcm, err := io.ReadAll(stream)
+	if err != nil {
+		t.Fatalf("Reading stream failed: %v", err)
+	}
+	if err := stream.Close(); err != nil {
+		t.Errorf("Close failed: %v", err)
+	}
+	if len(pcm) != 4410*2 {
+		t.Errorf("Expected %d bytes of PCM, got %d", 4410*2, len(pcm))
+	}
+
+	stdin, _ := os.ReadFile(stdinFile)
+	if string(stdin) != "Streamed text." {
+		t.Errorf("Expected text on stdin, got %q", stdin)
+	}
+
+	// Output that needs converting is synthesized in full
+	wav, err := NewCommandEngine(tts.CommandConfig{Template: binary})
+	if err != nil {
+		t.Fatalf("NewCommandEngine failed: %v", err)
+	}
+	if wav.canStream(1.0) {
+		t.Error("Expected wav output not to be streamed")
+	}
+	raw, err := NewCommandEngine(tts.CommandConfig{Template: binary, Output: CommandOutputRaw})
+	if err != nil {
+		t.Fatalf("NewCommandEngine failed: %v", err)
+	}
+	if raw.canStream(1.5) {
+		t.Error("Expected a template without a speed placeholder not to be streamed at 1.5x")
+	}
+}
+
+func TestCommandEngineCancel(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake synthesizer requires a POSIX shell")
+	}
+	binary := filepath.Join(t.TempDir(), "slow-synth")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\nexec sleep 10\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	engine, err := NewCommandEngine(tts.CommandConfig{Template: binary, Output: CommandOutputRaw})
+	if err != nil {
+		t.Fatalf("NewCommandEngine failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	start := time.Now()
+	if _, err := engine.SynthesizeContext(ctx, "Never finished.", 1.0); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the command to be killed promptly, took %v", elapsed)
+	}
+}
+
+func TestCommandEngineConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config tts.CommandConfig
+	}{
+		{"empty template", tts.CommandConfig{}},
+		{"unterminated quote", tts.CommandConfig{Template: "synth 'oops"}},
+		{"bad input mode", tts.CommandConfig{Template: "synth", Input: "file"}},
+		{"bad output format", tts.CommandConfig{Template: "synth", Output: "ogg"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCommandEngine(tt.config); err == nil {
+				t.Error("Expected configuration error")
+			}
+		})
+	}
+
+	engine, err := NewCommandEngine(tts.CommandConfig{Template: "nonexistent_synth_xyz {text}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if engine.IsAvailable() {
+		t.Error("Expected engine with missing command to be unavailable")
+	}
+	if engine.GetName() != "Command (nonexistent_synth_xyz)" {
+		t.Errorf("Unexpected name %q", engine.GetName())
+	}
+}
+
+func TestSplitCommand(t *testing.T) {
+	args, err := splitCommand(`mimic3 --voice "en_US/vctk low" -x '' {text}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"mimic3", "--voice", "en_US/vctk low", "-x", "", "{text}"}
+	if len(args) != len(expected) {
+		t.Fatalf("Expected %q, got %q", expected, args)
+	}
+	for i := range expected {
+		if args[i] != expected[i] {
+			t.Errorf("Arg %d: expected %q, got %q", i, expected[i], args[i])
+		}
+	}
+}
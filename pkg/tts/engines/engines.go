@@ -0,0 +1,179 @@
+package engines
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+	"github.com/dgnsrekt/glow-tts/utils"
+)
+
+// Names lists the engine names accepted by NewEngine
+var Names = []string{"piper", "gtts", "espeak", "command", "openai"}
+
+// IsSupported reports whether name refers to a known engine
+func IsSupported(name string) bool {
+	name = strings.ToLower(name)
+	for _, n := range Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// NewEngine creates the named TTS engine and applies its settings from config
+func NewEngine(name string, config *tts.TTSConfig) (tts.TTSEngine, error) {
+	if config == nil {
+		config = tts.DefaultTTSConfig()
+	}
+
+	switch strings.ToLower(name) {
+	case "piper":
+		log.Debug("creating Piper engine")
+		var engine *PiperEngine
+		var err error
+		if modelPath := config.Engines.Piper.ModelPath; modelPath != "" {
+			engine, err = NewPiperEngineWithModel(utils.ExpandPath(modelPath))
+		} else {
+			engine, err = NewPiperEngine()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Piper engine: %w", err)
+		}
+		engine.SetPersistent(config.Engines.Piper.PersistentWorker)
+		engine.SetVoiceDirs(tts.PiperVoiceDirs(config))
+		if engine.GetModelPath() == "" {
+			// Configured voice directories may hold a model the defaults missed
+			_ = engine.findDefaultModel()
+		}
+		return engine, nil
+
+	case "gtts":
+		log.Debug("creating Google TTS engine")
+		engine, err := NewGTTSEngine()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Google TTS engine: %w", err)
+		}
+		if lang := config.Engines.GTTS.Language; lang != "" {
+			if err := engine.SetLanguage(lang); err != nil {
+				log.Warn("Ignoring configured gTTS language", "language", lang, "error", err)
+			}
+		}
+		if tld := config.Engines.GTTS.TLD; tld != "" {
+			if err := engine.SetTLD(tld); err != nil {
+				log.Warn("Ignoring configured gTTS domain", "tld", tld, "error", err)
+			}
+		}
+		return engine, nil
+
+	case "espeak":
+		log.Debug("creating eSpeak NG engine")
+		engine, err := NewESpeakEngine()
+		if err != nil {
+			return nil, fmt.Errorf("failed to create eSpeak NG engine: %w", err)
+		}
+		cfg := config.Engines.ESpeak
+		if cfg.Voice != "" {
+			if err := engine.SetVoice(cfg.Voice); err != nil {
+				log.Warn("Ignoring configured espeak-ng voice", "voice", cfg.Voice, "error", err)
+			}
+		}
+		if err := engine.SetPitch(cfg.Pitch); err != nil {
+			log.Warn("Ignoring configured espeak-ng pitch", "pitch", cfg.Pitch, "error", err)
+		}
+		if err := engine.SetWordGap(cfg.WordGap); err != nil {
+			log.Warn("Ignoring configured espeak-ng word gap", "wordGap", cfg.WordGap, "error", err)
+		}
+		return engine, nil
+
+	case "command":
+		log.Debug("creating command engine", "template", config.Engines.Command.Template)
+		engine, err := NewCommandEngine(config.Engines.Command)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create command engine: %w", err)
+		}
+		return engine, nil
+
+	case "openai":
+		log.Debug("creating OpenAI-compatible engine", "url", config.Engines.OpenAI.BaseURL)
+		engine, err := NewOpenAIEngine(config.Engines.OpenAI)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create OpenAI-compatible engine: %w", err)
+		}
+		return engine, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported engine: %s", name)
+	}
+}
+
+// NewLanguageEngines creates the named engine once for every language with
+// a voice in config.Languages.Voices. Languages whose engine cannot be
+// created are logged and left to the main engine.
+func NewLanguageEngines(name string, config *tts.TTSConfig) map[string]tts.TTSEngine {
+	if config == nil || len(config.Languages.Voices) == 0 {
+		return nil
+	}
+
+	voices := make(map[string]tts.TTSEngine, len(config.Languages.Voices))
+	for lang, voice := range config.Languages.Voices {
+		doc := tts.DocumentSettings{Voice: voice, Language: lang}
+		if !doc.SelectsVoice(name) {
+			log.Warn("No voice configured for language", "engine", name, "language", lang)
+			continue
+		}
+		engine, err := NewEngine(name, config.ForDocument(name, doc))
+		if err == nil {
+			err = engine.Validate()
+		}
+		if err != nil {
+			log.Warn("Ignoring voice for language", "language", lang, "voice", voice, "error", err)
+			continue
+		}
+		voices[strings.ToLower(lang)] = engine
+	}
+	return voices
+}
+
+// ListVoices returns the voices available to the named engine. Piper and
+// Google voices are listed without starting the engine; engines that cannot
+// enumerate voices report their configured one.
+func ListVoices(name string, config *tts.TTSConfig) ([]tts.Voice, error) {
+	if config == nil {
+		config = tts.DefaultTTSConfig()
+	}
+
+	switch strings.ToLower(name) {
+	case "piper":
+		return tts.ScanPiperVoices(tts.PiperVoiceDirs(config)...), nil
+
+	case "gtts":
+		return GTTSVoices(), nil
+
+	case "espeak":
+		path, err := findESpeakBinary()
+		if err != nil {
+			return nil, err
+		}
+		return newESpeakEngine(path).ListVoices()
+
+	case "command":
+		return configuredVoice("command", config.Engines.Command.Voice), nil
+
+	case "openai":
+		return configuredVoice("openai", config.Engines.OpenAI.Voice), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported engine: %s", name)
+	}
+}
+
+// configuredVoice describes the voice set in the configuration, if any
+func configuredVoice(engine, voice string) []tts.Voice {
+	if voice == "" {
+		return nil
+	}
+	return []tts.Voice{{Engine: engine, ID: voice, Name: voice}}
+}
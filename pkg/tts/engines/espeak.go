@@ -0,0 +1,351 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// espeak-ng parameter ranges
+const (
+	// ESpeakDefaultVoice is the voice used when none is configured
+	ESpeakDefaultVoice = "en"
+	// ESpeakDefaultWPM is espeak-ng's normal speaking rate in words per minute
+	ESpeakDefaultWPM = 175
+	// ESpeakMinWPM is the slowest rate espeak-ng accepts
+	ESpeakMinWPM = 80
+	// ESpeakMaxWPM is the fastest rate espeak-ng accepts
+	ESpeakMaxWPM = 450
+	// ESpeakDefaultPitch is the neutral pitch adjustment
+	ESpeakDefaultPitch = 50
+	// ESpeakMaxPitch is the highest pitch adjustment
+	ESpeakMaxPitch = 99
+	// ESpeakMaxWordGap is the longest pause between words, in units of 10ms
+	ESpeakMaxWordGap = 100
+)
+
+// ESpeakEngine implements the TTSEngine interface using espeak-ng
+type ESpeakEngine struct {
+	// binaryPath is the path to the espeak-ng executable
+	binaryPath string
+	// voice is the espeak-ng voice name (e.g. "en-us", "de", "en+f3")
+	voice string
+	// pitch is the pitch adjustment (0-99)
+	pitch int
+	// wordGap is the extra pause between words in units of 10ms
+	wordGap int
+	// speed is the current speaking speed multiplier
+	speed float64
+	// timeout for synthesis operations
+	timeout time.Duration
+
+	mu sync.RWMutex
+}
+
+// NewESpeakEngine creates a new espeak-ng engine instance
+func NewESpeakEngine() (*ESpeakEngine, error) {
+	path, err := findESpeakBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	engine := newESpeakEngine(path)
+	log.Debug("eSpeak engine initialized", "binary", path)
+	return engine, nil
+}
+
+// newESpeakEngine creates an engine using the given binary with default settings
+func newESpeakEngine(binaryPath string) *ESpeakEngine {
+	return &ESpeakEngine{
+		binaryPath: binaryPath,
+		voice:      ESpeakDefaultVoice,
+		pitch:      ESpeakDefaultPitch,
+		speed:      DefaultSpeed,
+		timeout:    30 * time.Second,
+	}
+}
+
+// findESpeakBinary locates the espeak-ng executable
+func findESpeakBinary() (string, error) {
+	if path, err := exec.LookPath("espeak-ng"); err == nil {
+		return path, nil
+	}
+
+	commonPaths := []string{
+		"/usr/bin/espeak-ng",
+		"/usr/local/bin/espeak-ng",
+		"/opt/homebrew/bin/espeak-ng",
+		filepath.Join(os.Getenv("HOME"), ".local", "bin", "espeak-ng"),
+	}
+	for _, path := range commonPaths {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+
+	return "", fmt.Errorf("espeak-ng not found. Install with your package manager (apt/brew/etc)")
+}
+
+// Synthesize converts text to speech audio using espeak-ng
+func (e *ESpeakEngine) Synthesize(text string, speed float64) ([]byte, error) {
+	return e.SynthesizeContext(context.Background(), text, speed)
+}
+
+// SynthesizeContext converts text to speech, killing espeak-ng if ctx is
+// cancelled
+func (e *ESpeakEngine) SynthesizeContext(ctx context.Context, text string, speed float64) ([]byte, error) {
+	return e.synthesize(ctx, text, speed, false)
+}
+
+// SupportsSSML reports that espeak-ng reads SSML
+func (e *ESpeakEngine) SupportsSSML() bool {
+	return true
+}
+
+// SynthesizeSSML synthesizes an SSML document, interpreting its markup
+func (e *ESpeakEngine) SynthesizeSSML(ctx context.Context, ssml string, speed float64) ([]byte, error) {
+	return e.synthesize(ctx, ssml, speed, true)
+}
+
+// synthesize runs espeak-ng on text, as SSML markup when ssml is set
+func (e *ESpeakEngine) synthesize(ctx context.Context, text string, speed float64, ssml bool) ([]byte, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("empty text")
+	}
+
+	e.mu.RLock()
+	if speed <= 0 {
+		speed = e.speed
+	}
+	args := e.buildArgs(speed)
+	if ssml {
+		args = append(args, "-m")
+	}
+	binary := e.binaryPath
+	timeout := e.timeout
+	e.mu.RUnlock()
+
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(runCtx, binary, args...)
+	cmd.Stdin = strings.NewReader(text)
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if runCtx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("espeak-ng timed out after %v", timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("espeak-ng failed: %w: %s", err, msg)
+		}
+		return nil, fmt.Errorf("espeak-ng failed: %w", err)
+	}
+
+	pcm, err := tts.WAVToPCM(stdout.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode espeak-ng output: %w", err)
+	}
+	if len(pcm) == 0 {
+		return nil, fmt.Errorf("no audio data generated")
+	}
+
+	log.Debug("eSpeak: Synthesis complete", "textLen", len(text), "pcmSize", len(pcm))
+	return pcm, nil
+}
+
+// buildArgs builds the espeak-ng command line for the current settings
+func (e *ESpeakEngine) buildArgs(speed float64) []string {
+	wpm := int(float64(ESpeakDefaultWPM) * speed)
+	if wpm < ESpeakMinWPM {
+		wpm = ESpeakMinWPM
+	} else if wpm > ESpeakMaxWPM {
+		wpm = ESpeakMaxWPM
+	}
+
+	args := []string{
+		"--stdout",
+		"--stdin",
+		"-b", "1", // UTF-8 input
+		"-v", e.voice,
+		"-s", strconv.Itoa(wpm),
+		"-p", strconv.Itoa(e.pitch),
+	}
+	if e.wordGap > 0 {
+		args = append(args, "-g", strconv.Itoa(e.wordGap))
+	}
+	return args
+}
+
+// SetSpeed sets the speaking speed
+func (e *ESpeakEngine) SetSpeed(speed float64) error {
+	if speed < MinSpeed || speed > MaxSpeed {
+		return fmt.Errorf("speed must be between %.1f and %.1f", MinSpeed, MaxSpeed)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.speed = speed
+	return nil
+}
+
+// SetVoice sets the espeak-ng voice (e.g. "en-us", "fr", "en+f3")
+func (e *ESpeakEngine) SetVoice(voice string) error {
+	voice = strings.TrimSpace(voice)
+	if voice == "" || strings.ContainsAny(voice, " \t\n") {
+		return fmt.Errorf("invalid espeak-ng voice: %q", voice)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.voice = voice
+	return nil
+}
+
+// SetPitch sets the pitch adjustment (0-99, 50 is neutral)
+func (e *ESpeakEngine) SetPitch(pitch int) error {
+	if pitch < 0 || pitch > ESpeakMaxPitch {
+		return fmt.Errorf("pitch must be between 0 and %d", ESpeakMaxPitch)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.pitch = pitch
+	return nil
+}
+
+// SetWordGap sets the extra pause between words in units of 10ms
+func (e *ESpeakEngine) SetWordGap(gap int) error {
+	if gap < 0 || gap > ESpeakMaxWordGap {
+		return fmt.Errorf("word gap must be between 0 and %d", ESpeakMaxWordGap)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.wordGap = gap
+	return nil
+}
+
+// SetLanguage selects the voice for a language code
+func (e *ESpeakEngine) SetLanguage(lang string) error {
+	return e.SetVoice(lang)
+}
+
+// GetVoice returns the current voice name
+func (e *ESpeakEngine) GetVoice() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.voice
+}
+
+// ListVoices returns the voices reported by espeak-ng --voices
+func (e *ESpeakEngine) ListVoices() ([]tts.Voice, error) {
+	e.mu.RLock()
+	binary := e.binaryPath
+	timeout := e.timeout
+	e.mu.RUnlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	output, err := exec.CommandContext(ctx, binary, "--voices").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list espeak-ng voices: %w", err)
+	}
+	return parseESpeakVoices(string(output)), nil
+}
+
+// parseESpeakVoices parses the table printed by espeak-ng --voices:
+//
+//	Pty Language       Age/Gender VoiceName          File                 Other Languages
+//	 5  af              --/M      Afrikaans          gmw/af
+func parseESpeakVoices(output string) []tts.Voice {
+	var voices []tts.Voice
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 5 || fields[0] == "Pty" {
+			continue
+		}
+		voices = append(voices, tts.Voice{
+			Engine:   "espeak",
+			ID:       fields[1],
+			Name:     strings.ReplaceAll(fields[3], "_", " "),
+			Language: fields[1],
+			Path:     fields[4],
+		})
+	}
+	return voices
+}
+
+// SetTimeout sets the synthesis timeout duration
+func (e *ESpeakEngine) SetTimeout(timeout time.Duration) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.timeout = timeout
+}
+
+// GetName returns the engine name
+func (e *ESpeakEngine) GetName() string {
+	return fmt.Sprintf("eSpeak NG (%s)", e.GetVoice())
+}
+
+// Validate checks if the engine is properly configured
+func (e *ESpeakEngine) Validate() error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.binaryPath == "" {
+		return fmt.Errorf("espeak-ng binary not configured")
+	}
+	if _, err := os.Stat(e.binaryPath); err != nil {
+		return fmt.Errorf("espeak-ng binary not found at %s: %w", e.binaryPath, err)
+	}
+	if e.voice == "" {
+		return fmt.Errorf("no espeak-ng voice configured")
+	}
+	return nil
+}
+
+// IsAvailable checks if the engine can be used
+func (e *ESpeakEngine) IsAvailable() bool {
+	return e.Validate() == nil
+}
+
+// GetInfo returns information about the engine configuration
+func (e *ESpeakEngine) GetInfo() map[string]string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return map[string]string{
+		"engine":     "espeak",
+		"binary":     e.binaryPath,
+		"voice":      e.voice,
+		"pitch":      strconv.Itoa(e.pitch),
+		"wordGap":    strconv.Itoa(e.wordGap),
+		"speed":      fmt.Sprintf("%.1f", e.speed),
+		"sampleRate": fmt.Sprintf("%d", tts.SampleRate),
+		"format":     "PCM 16-bit mono",
+	}
+}
+
+// Cleanup performs cleanup for the espeak-ng engine
+func (e *ESpeakEngine) Cleanup() error {
+	// espeak-ng runs one short-lived process per sentence and leaves no temp files
+	return nil
+}
@@ -0,0 +1,152 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// fakeESpeak writes a shell script that records its arguments and stdin and
+// prints a 16kHz WAV file, like espeak-ng --stdout does
+func fakeESpeak(t *testing.T) (binary, argsFile, stdinFile string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake espeak-ng requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	source := tts.PCMFormat{SampleRate: 16000, Channels: 1, BitDepth: 16, IsSigned: true}
+	var wav bytes.Buffer
+	if err := tts.WriteWAV(&wav, make([]byte, 16000*2), source); err != nil {
+		t.Fatal(err)
+	}
+	wavFile := filepath.Join(dir, "out.wav")
+	if err := os.WriteFile(wavFile, wav.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	argsFile = filepath.Join(dir, "args")
+	stdinFile = filepath.Join(dir, "stdin")
+	binary = filepath.Join(dir, "espeak-ng")
+	script := "#!/bin/sh\necho \"$@\" > " + argsFile + "\ncat > " + stdinFile + "\ncat " + wavFile + "\n"
+	if err := os.WriteFile(binary, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return binary, argsFile, stdinFile
+}
+
+func TestESpeakEngineSynthesize(t *testing.T) {
+	binary, argsFile, stdinFile := fakeESpeak(t)
+
+	engine := newESpeakEngine(binary)
+	if err := engine.SetVoice("en-us"); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.SetPitch(70); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.SetWordGap(5); err != nil {
+		t.Fatal(err)
+	}
+
+	pcm, err := engine.Synthesize("Hello world", 2.0)
+	if err != nil {
+		t.Fatalf("Synthesize failed: %v", err)
+	}
+
+	// One second of 16kHz audio resampled to the 22050Hz contract
+	expected := tts.SampleRate * tts.BytesPerSample
+	if diff := len(pcm) - expected; diff < -4 || diff > 4 {
+		t.Errorf("Expected about %d bytes of PCM, got %d", expected, len(pcm))
+	}
+
+	args, _ := os.ReadFile(argsFile)
+	for _, want := range []string{"--stdout", "-v en-us", "-s 350", "-p 70", "-g 5"} {
+		if !strings.Contains(string(args), want) {
+			t.Errorf("Expected arguments to contain %q, got %q", want, args)
+		}
+	}
+
+	stdin, _ := os.ReadFile(stdinFile)
+	if string(stdin) != "Hello world" {
+		t.Errorf("Expected text on stdin, got %q", stdin)
+	}
+
+	t.Run("clamps rate", func(t *testing.T) {
+		if _, err := engine.Synthesize("Hi", 0.1); err != nil {
+			t.Fatal(err)
+		}
+		args, _ := os.ReadFile(argsFile)
+		if !strings.Contains(string(args), "-s 80") {
+			t.Errorf("Expected rate to be clamped to 80, got %q", args)
+		}
+	})
+
+	t.Run("empty text", func(t *testing.T) {
+		if _, err := engine.Synthesize("  ", 1.0); err == nil {
+			t.Error("Expected error for empty text")
+		}
+	})
+
+	t.Run("ssml", func(t *testing.T) {
+		ssml := `<speak><break time="500ms"/>Hello</speak>`
+		if _, err := engine.SynthesizeSSML(context.Background(), ssml, 1.0); err != nil {
+			t.Fatal(err)
+		}
+		args, _ := os.ReadFile(argsFile)
+		if !strings.Contains(string(args), "-m") {
+			t.Errorf("Expected SSML markup flag, got %q", args)
+		}
+		stdin, _ := os.ReadFile(stdinFile)
+		if string(stdin) != ssml {
+			t.Errorf("Expected SSML on stdin, got %q", stdin)
+		}
+	})
+}
+
+func TestESpeakEngineSettings(t *testing.T) {
+	engine := newESpeakEngine("/nonexistent/espeak-ng")
+
+	if engine.GetVoice() != ESpeakDefaultVoice {
+		t.Errorf("Expected default voice %q, got %q", ESpeakDefaultVoice, engine.GetVoice())
+	}
+	if err := engine.SetVoice("en us"); err == nil {
+		t.Error("Expected error for voice containing whitespace")
+	}
+	if err := engine.SetPitch(ESpeakMaxPitch + 1); err == nil {
+		t.Error("Expected error for pitch out of range")
+	}
+	if err := engine.SetWordGap(-1); err == nil {
+		t.Error("Expected error for negative word gap")
+	}
+	if err := engine.SetSpeed(MaxSpeed + 1); err == nil {
+		t.Error("Expected error for speed out of range")
+	}
+
+	if engine.IsAvailable() {
+		t.Error("Expected engine with missing binary to be unavailable")
+	}
+	if info := engine.GetInfo(); info["engine"] != "espeak" || info["pitch"] != "50" {
+		t.Errorf("Unexpected engine info: %v", info)
+	}
+}
+
+func TestParseESpeakVoices(t *testing.T) {
+	output := `Pty Language       Age/Gender VoiceName          File                 Other Languages
+ 5  af              --/M      Afrikaans          gmw/af
+ 2  en-gb           --/M      English_(Great_Britain) gmw/en           (en 2)
+`
+	voices := parseESpeakVoices(output)
+	if len(voices) != 2 {
+		t.Fatalf("Expected 2 voices, got %+v", voices)
+	}
+	if voices[1].ID != "en-gb" || voices[1].Name != "English (Great Britain)" || voices[1].Path != "gmw/en" {
+		t.Errorf("Unexpected voice %+v", voices[1])
+	}
+}
@@ -0,0 +1,76 @@
+package engines
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// ffmpeg atempo accepts factors between 0.5 and 2.0 per filter
+const (
+	minAtempo = 0.5
+	maxAtempo = 2.0
+)
+
+// pcmConversionArgs builds ffmpeg arguments that decode input (a file path or
+// "pipe:0" for stdin) to 16-bit mono 22050Hz PCM on stdout, applying an
+// atempo filter when speed is not 1.0
+func pcmConversionArgs(input string, speed float64) []string {
+	args := []string{
+		"-i", input,
+		"-f", "s16le", // 16-bit signed little-endian
+		"-ar", strconv.Itoa(tts.SampleRate),
+		"-ac", strconv.Itoa(tts.Channels),
+	}
+
+	if speed != 1.0 {
+		tempo := speed
+		if tempo < minAtempo {
+			tempo = minAtempo
+		} else if tempo > maxAtempo {
+			tempo = maxAtempo
+		}
+		args = append(args, "-filter:a", fmt.Sprintf("atempo=%.2f", tempo))
+	}
+
+	// Output to pipe (stdout)
+	return append(args, "-")
+}
+
+// findFFmpeg locates the ffmpeg executable
+func findFFmpeg() (string, error) {
+	for _, path := range []string{"ffmpeg", "/usr/local/bin/ffmpeg", "/usr/bin/ffmpeg", "/opt/homebrew/bin/ffmpeg"} {
+		if full, err := exec.LookPath(path); err == nil {
+			return full, nil
+		}
+	}
+	return "", fmt.Errorf("ffmpeg not found. Install with your package manager (apt/brew/etc)")
+}
+
+// decodeWithFFmpeg pipes encoded audio through ffmpeg and returns PCM in the
+// default format. inputArgs describe headerless input such as raw PCM.
+func decodeWithFFmpeg(ctx context.Context, sm *tts.SubprocessManager, ffmpegBinary string, audio []byte, inputArgs []string, tempo float64, timeout time.Duration) ([]byte, error) {
+	args := append([]string{"-hide_banner", "-loglevel", "error"}, inputArgs...)
+	args = append(args, pcmConversionArgs("pipe:0", tempo)...)
+
+	pcm, err := sm.ExecuteSafe(ctx, tts.SafeProcessOptions{
+		Input:   string(audio),
+		Command: ffmpegBinary,
+		Args:    args,
+		Timeout: timeout,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("ffmpeg conversion failed: %w", err)
+	}
+	if len(pcm) == 0 {
+		return nil, fmt.Errorf("no audio data generated")
+	}
+	return pcm, nil
+}
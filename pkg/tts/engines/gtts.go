@@ -2,6 +2,7 @@ package engines
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -26,6 +27,7 @@ func min(a, b int) int {
 type GTTSEngine struct {
 	// Configuration
 	language string
+	tld      string
 	speed    float64
 	
 	// Dependencies
@@ -52,6 +54,7 @@ func NewGTTSEngine() (*GTTSEngine, error) {
 	
 	engine := &GTTSEngine{
 		language: "en",     // Default to English
+		tld:      "com",    // Default accent
 		speed:    1.0,      // Normal speed
 		tempDir:  tempDir,
 	}
@@ -168,6 +171,12 @@ func (e *GTTSEngine) detectDependencies() error {
 
 // Synthesize converts text to speech audio using Google TTS
 func (e *GTTSEngine) Synthesize(text string, speed float64) ([]byte, error) {
+	return e.SynthesizeContext(context.Background(), text, speed)
+}
+
+// SynthesizeContext converts text to speech, killing gtts-cli or ffmpeg if
+// ctx is cancelled
+func (e *GTTSEngine) SynthesizeContext(ctx context.Context, text string, speed float64) ([]byte, error) {
 	e.mu.RLock()
 	if !e.initialized {
 		e.mu.RUnlock()
@@ -191,12 +200,17 @@ func (e *GTTSEngine) Synthesize(text string, speed float64) ([]byte, error) {
 	log.Debug("GTTS: Generating MP3", "textLen", len(text))
 	
 	// Build gtts-cli command
-	// gtts-cli "text" --output file.mp3 --lang en
+	// gtts-cli "text" --output file.mp3 --lang en [--tld co.uk]
+	e.mu.RLock()
 	args := []string{
 		text,
 		"--output", mp3File,
 		"--lang", e.language,
 	}
+	if e.tld != "" && e.tld != "com" {
+		args = append(args, "--tld", e.tld)
+	}
+	e.mu.RUnlock()
 	
 	cmd := exec.Command(e.gttsBinary, args...)
 	
@@ -209,7 +223,10 @@ func (e *GTTSEngine) Synthesize(text string, speed float64) ([]byte, error) {
 	timeoutConfig.Timeout = 10 * time.Second
 	executor := tts.NewTimeoutExecutor(timeoutConfig)
 	
-	if err := executor.RunWithTimeout(cmd); err != nil {
+	if err := executor.RunWithTimeoutContext(ctx, cmd); err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
 		log.Error("GTTS: Failed to generate MP3", 
 			"error", err,
 			"stderr", stderr.String())
@@ -227,34 +244,8 @@ func (e *GTTSEngine) Synthesize(text string, speed float64) ([]byte, error) {
 	// Step 2: Convert MP3 to PCM using ffmpeg
 	log.Debug("GTTS: Converting MP3 to PCM", "speed", speed)
 	
-	// Build ffmpeg command to convert MP3 to raw PCM
-	// We need: 16-bit, mono, 22050Hz PCM data
-	ffmpegArgs := []string{
-		"-i", mp3File,           // Input MP3 file
-		"-f", "s16le",           // 16-bit signed little-endian
-		"-ar", "22050",          // Sample rate 22050Hz
-		"-ac", "1",              // Mono (1 channel)
-	}
-	
-	// Add speed control using atempo filter if needed
-	if speed != 1.0 {
-		// ffmpeg atempo filter accepts values between 0.5 and 2.0
-		// For values outside this range, we need to chain multiple filters
-		tempoValue := speed
-		
-		if tempoValue < 0.5 {
-			tempoValue = 0.5
-		} else if tempoValue > 2.0 {
-			tempoValue = 2.0
-		}
-		
-		ffmpegArgs = append(ffmpegArgs, "-filter:a", fmt.Sprintf("atempo=%.2f", tempoValue))
-	}
-	
-	// Output to pipe (stdout)
-	ffmpegArgs = append(ffmpegArgs, "-")
-	
-	ffmpegCmd := exec.Command(e.ffmpegBinary, ffmpegArgs...)
+	// Convert MP3 to 16-bit, mono, 22050Hz PCM on stdout
+	ffmpegCmd := exec.Command(e.ffmpegBinary, pcmConversionArgs(mp3File, speed)...)
 	
 	// Capture the PCM output
 	var pcmBuffer bytes.Buffer
@@ -263,7 +254,10 @@ func (e *GTTSEngine) Synthesize(text string, speed float64) ([]byte, error) {
 	ffmpegCmd.Stderr = &ffmpegStderr
 	
 	// Run ffmpeg with timeout protection
-	if err := executor.RunWithTimeout(ffmpegCmd); err != nil {
+	if err := executor.RunWithTimeoutContext(ctx, ffmpegCmd); err != nil {
+		if ctx.Err() != nil {
+			return nil, err
+		}
 		log.Error("GTTS: Failed to convert MP3 to PCM",
 			"error", err,
 			"stderr", ffmpegStderr.String())
@@ -311,15 +305,7 @@ func (e *GTTSEngine) SetLanguage(lang string) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 	
-	// Common language codes supported by gTTS
-	supportedLangs := map[string]bool{
-		"en": true, "es": true, "fr": true, "de": true,
-		"it": true, "pt": true, "ru": true, "ja": true,
-		"ko": true, "zh": true, "ar": true, "hi": true,
-		"nl": true, "pl": true, "tr": true, "sv": true,
-	}
-	
-	if !supportedLangs[lang] {
+	if _, ok := gttsLanguages[lang]; !ok {
 		return fmt.Errorf("unsupported language: %s", lang)
 	}
 	
@@ -329,6 +315,13 @@ func (e *GTTSEngine) SetLanguage(lang string) error {
 
 // GetName returns the engine name
 func (e *GTTSEngine) GetName() string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	
+	// Include non-default voices so cached audio is kept apart
+	if e.language != "en" || (e.tld != "" && e.tld != "com") {
+		return fmt.Sprintf("Google TTS (%s)", gttsVoiceID(e.language, e.tld))
+	}
 	return "Google TTS"
 }
 
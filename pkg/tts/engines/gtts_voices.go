@@ -0,0 +1,97 @@
+package engines
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// gttsLanguages are the languages gTTS can speak
+var gttsLanguages = map[string]string{
+	"af": "Afrikaans", "ar": "Arabic", "bg": "Bulgarian", "bn": "Bengali",
+	"bs": "Bosnian", "ca": "Catalan", "cs": "Czech", "da": "Danish",
+	"de": "German", "el": "Greek", "en": "English", "es": "Spanish",
+	"et": "Estonian", "fi": "Finnish", "fr": "French", "gu": "Gujarati",
+	"hi": "Hindi", "hr": "Croatian", "hu": "Hungarian", "id": "Indonesian",
+	"is": "Icelandic", "it": "Italian", "iw": "Hebrew", "ja": "Japanese",
+	"jw": "Javanese", "km": "Khmer", "kn": "Kannada", "ko": "Korean",
+	"la": "Latin", "lv": "Latvian", "ml": "Malayalam", "mr": "Marathi",
+	"ms": "Malay", "my": "Myanmar (Burmese)", "ne": "Nepali", "nl": "Dutch",
+	"no": "Norwegian", "pl": "Polish", "pt": "Portuguese", "ro": "Romanian",
+	"ru": "Russian", "si": "Sinhala", "sk": "Slovak", "sq": "Albanian",
+	"sr": "Serbian", "su": "Sundanese", "sv": "Swedish", "sw": "Swahili",
+	"ta": "Tamil", "te": "Telugu", "th": "Thai", "tl": "Filipino",
+	"tr": "Turkish", "uk": "Ukrainian", "ur": "Urdu", "vi": "Vietnamese",
+	"zh": "Chinese", "zh-CN": "Chinese (Simplified)", "zh-TW": "Chinese (Traditional)",
+}
+
+// gttsAccents maps languages to the Google domains that give regional accents
+var gttsAccents = map[string]map[string]string{
+	"en": {
+		"com.au": "Australia", "co.uk": "United Kingdom", "us": "United States",
+		"ca": "Canada", "co.in": "India", "ie": "Ireland", "co.za": "South Africa",
+		"com.ng": "Nigeria",
+	},
+	"fr": {"ca": "Canada", "fr": "France"},
+	"pt": {"com.br": "Brazil", "pt": "Portugal"},
+	"es": {"com.mx": "Mexico", "es": "Spain", "us": "United States"},
+}
+
+// gttsVoiceID joins a language and accent domain into a voice ID ("en:co.uk")
+func gttsVoiceID(lang, tld string) string {
+	if tld == "" || tld == "com" {
+		return lang
+	}
+	return lang + ":" + tld
+}
+
+// GTTSVoices lists every gTTS language, plus a voice for each regional accent
+func GTTSVoices() []tts.Voice {
+	var voices []tts.Voice
+	for lang, name := range gttsLanguages {
+		voices = append(voices, tts.Voice{Engine: "gtts", ID: lang, Name: name, Language: lang})
+		for tld, region := range gttsAccents[lang] {
+			voices = append(voices, tts.Voice{
+				Engine:   "gtts",
+				ID:       gttsVoiceID(lang, tld),
+				Name:     fmt.Sprintf("%s (%s)", name, region),
+				Language: lang,
+			})
+		}
+	}
+
+	sort.Slice(voices, func(i, j int) bool { return voices[i].ID < voices[j].ID })
+	return voices
+}
+
+// ListVoices returns the gTTS languages and accents
+func (e *GTTSEngine) ListVoices() ([]tts.Voice, error) {
+	return GTTSVoices(), nil
+}
+
+// SetTLD sets the Google domain used for regional accents (e.g. "co.uk")
+func (e *GTTSEngine) SetTLD(tld string) error {
+	tld = strings.TrimPrefix(strings.TrimSpace(tld), ".")
+	if tld == "" || strings.ContainsAny(tld, "/: ") {
+		return fmt.Errorf("invalid gTTS top-level domain: %q", tld)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.tld = tld
+	return nil
+}
+
+// SetVoice selects a voice ID from the catalog ("fr" or "en:co.uk")
+func (e *GTTSEngine) SetVoice(id string) error {
+	lang, tld, _ := strings.Cut(id, ":")
+	if err := e.SetLanguage(lang); err != nil {
+		return err
+	}
+	if tld == "" {
+		tld = "com"
+	}
+	return e.SetTLD(tld)
+}
@@ -0,0 +1,46 @@
+package engines
+
+import "testing"
+
+func TestGTTSVoices(t *testing.T) {
+	voices := GTTSVoices()
+	found := map[string]string{}
+	for _, v := range voices {
+		found[v.ID] = v.Name
+	}
+
+	if found["fr"] != "French" {
+		t.Errorf("Expected French voice, got %q", found["fr"])
+	}
+	if found["en:co.uk"] != "English (United Kingdom)" {
+		t.Errorf("Expected British English voice, got %q", found["en:co.uk"])
+	}
+	if len(found) != len(voices) {
+		t.Error("Expected unique voice IDs")
+	}
+}
+
+func TestGTTSEngineSetVoice(t *testing.T) {
+	engine := &GTTSEngine{language: "en", tld: "com"}
+
+	if err := engine.SetVoice("en:co.uk"); err != nil {
+		t.Fatal(err)
+	}
+	if engine.language != "en" || engine.tld != "co.uk" {
+		t.Errorf("Expected en/co.uk, got %s/%s", engine.language, engine.tld)
+	}
+	if engine.GetName() != "Google TTS (en:co.uk)" {
+		t.Errorf("Expected accent in engine name, got %q", engine.GetName())
+	}
+
+	if err := engine.SetVoice("de"); err != nil {
+		t.Fatal(err)
+	}
+	if engine.tld != "com" {
+		t.Errorf("Expected default domain, got %s", engine.tld)
+	}
+
+	if err := engine.SetVoice("xx"); err == nil {
+		t.Error("Expected error for unknown language")
+	}
+}
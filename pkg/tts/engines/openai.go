@@ -0,0 +1,333 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// openAIHealthTTL is how long a health check result is reused
+const openAIHealthTTL = 30 * time.Second
+
+// openAIHealthTimeout bounds the connection health check
+const openAIHealthTimeout = 5 * time.Second
+
+// openAIErrorLimit caps how much of an error response is reported
+const openAIErrorLimit = 512
+
+// OpenAI-compatible response formats
+var openAIFormats = map[string]bool{
+	"wav": true, "pcm": true, "mp3": true, "opus": true, "aac": true, "flac": true,
+}
+
+// speechRequest is the body of a /v1/audio/speech request
+type speechRequest struct {
+	Model          string  `json:"model"`
+	Input          string  `json:"input"`
+	Voice          string  `json:"voice"`
+	ResponseFormat string  `json:"response_format"`
+	Speed          float64 `json:"speed,omitempty"`
+}
+
+// OpenAIEngine implements the TTSEngine interface for servers exposing an
+// OpenAI-compatible /v1/audio/speech endpoint
+type OpenAIEngine struct {
+	config   tts.OpenAIConfig
+	baseURL  string
+	client   *http.Client
+	speed    float64
+	attempts int
+	// retryDelay is the backoff before the first retry; it doubles each time
+	retryDelay time.Duration
+
+	ffmpegBinary string
+	subprocess   *tts.SubprocessManager
+
+	mu          sync.Mutex
+	healthAt    time.Time
+	healthError error
+}
+
+// NewOpenAIEngine creates an engine for an OpenAI-compatible speech server
+func NewOpenAIEngine(config tts.OpenAIConfig) (*OpenAIEngine, error) {
+	base := strings.TrimRight(strings.TrimSpace(config.BaseURL), "/")
+	if base == "" {
+		return nil, fmt.Errorf("openai engine requires a base URL (engines.openai.base_url)")
+	}
+	u, err := url.Parse(base)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL: %q", config.BaseURL)
+	}
+	base = strings.TrimSuffix(base, "/v1")
+
+	config.ResponseFormat = strings.ToLower(config.ResponseFormat)
+	if config.ResponseFormat == "" {
+		config.ResponseFormat = "wav"
+	}
+	if !openAIFormats[config.ResponseFormat] {
+		return nil, fmt.Errorf("unsupported response format %q", config.ResponseFormat)
+	}
+	if config.SampleRate <= 0 {
+		config.SampleRate = 24000
+	}
+	if config.Retries < 0 {
+		config.Retries = 0
+	}
+
+	timeout := 30 * time.Second
+	if config.Timeout > 0 {
+		timeout = time.Duration(config.Timeout) * time.Second
+	}
+
+	engine := &OpenAIEngine{
+		config:     config,
+		baseURL:    base,
+		client:     &http.Client{Timeout: timeout},
+		speed:      DefaultSpeed,
+		attempts:   config.Retries + 1,
+		retryDelay: 250 * time.Millisecond,
+		subprocess: tts.NewSubprocessManager(timeout),
+	}
+	if path, err := findFFmpeg(); err == nil {
+		engine.ffmpegBinary = path
+	}
+
+	log.Debug("OpenAI-compatible engine initialized", "url", base, "model", config.Model, "voice", config.Voice)
+	return engine, nil
+}
+
+// Synthesize requests speech from the server and decodes it to PCM
+func (e *OpenAIEngine) Synthesize(text string, speed float64) ([]byte, error) {
+	return e.SynthesizeContext(context.Background(), text, speed)
+}
+
+// SynthesizeContext requests speech, abandoning the request and any retries
+// if ctx is cancelled
+func (e *OpenAIEngine) SynthesizeContext(ctx context.Context, text string, speed float64) ([]byte, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("empty text")
+	}
+	if err := e.validateConfig(); err != nil {
+		return nil, err
+	}
+	if speed <= 0 {
+		speed = e.speed
+	}
+
+	body, err := json.Marshal(speechRequest{
+		Model:          e.config.Model,
+		Input:          text,
+		Voice:          e.config.Voice,
+		ResponseFormat: e.config.ResponseFormat,
+		Speed:          speed,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < e.attempts; attempt++ {
+		if attempt > 0 {
+			delay := e.retryDelay << (attempt - 1)
+			log.Debug("OpenAI: retrying speech request", "attempt", attempt+1, "delay", delay, "error", lastErr)
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		audio, retry, err := e.post(ctx, body)
+		if err == nil {
+			e.recordHealth(nil)
+			return e.decode(ctx, audio)
+		}
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		lastErr = err
+		if !retry {
+			break
+		}
+	}
+
+	return nil, lastErr
+}
+
+// post sends one speech request; retry reports whether the failure is
+// transient (connection errors, rate limiting, server errors)
+func (e *OpenAIEngine) post(ctx context.Context, body []byte) (audio []byte, retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.baseURL+"/v1/audio/speech", bytes.NewReader(body))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	e.authorize(req)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			// Cancelled by the caller, which says nothing about the server
+			return nil, false, ctx.Err()
+		}
+		e.recordHealth(err)
+		return nil, true, fmt.Errorf("speech request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, openAIErrorLimit))
+		retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return nil, retry, fmt.Errorf("speech server returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+
+	audio, err = io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to read speech response: %w", err)
+	}
+	if len(audio) == 0 {
+		return nil, true, fmt.Errorf("speech server returned no audio")
+	}
+	return audio, false, nil
+}
+
+// decode converts a response body to the 22050Hz mono s16le contract
+func (e *OpenAIEngine) decode(ctx context.Context, audio []byte) ([]byte, error) {
+	switch e.config.ResponseFormat {
+	case "wav":
+		return tts.WAVToPCM(audio)
+	case "pcm":
+		source := tts.DefaultPCMFormat()
+		source.SampleRate = e.config.SampleRate
+		audio = audio[:len(audio)-len(audio)%tts.BytesPerSample]
+		return tts.ResamplePCM(audio, source, tts.DefaultPCMFormat())
+	default:
+		return decodeWithFFmpeg(ctx, e.subprocess, e.ffmpegBinary, audio, nil, 1.0, e.client.Timeout)
+	}
+}
+
+// authorize adds the bearer token when an API key is configured
+func (e *OpenAIEngine) authorize(req *http.Request) {
+	if e.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.config.APIKey)
+	}
+}
+
+// CheckHealth verifies the server is reachable and accepts the API key.
+// Any response other than an authentication failure counts as healthy, since
+// not every server implements /v1/models.
+func (e *OpenAIEngine) CheckHealth() error {
+	ctx, cancel := context.WithTimeout(context.Background(), openAIHealthTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.baseURL+"/v1/models", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create health check: %w", err)
+	}
+	e.authorize(req)
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		err = fmt.Errorf("speech server unreachable at %s: %w", e.baseURL, err)
+		e.recordHealth(err)
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		err = fmt.Errorf("speech server rejected the API key: %s", resp.Status)
+	}
+	e.recordHealth(err)
+	return err
+}
+
+// recordHealth caches the outcome of the latest contact with the server
+func (e *OpenAIEngine) recordHealth(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.healthAt = time.Now()
+	e.healthError = err
+}
+
+// health returns the cached health result, checking again once it is stale
+func (e *OpenAIEngine) health() error {
+	e.mu.Lock()
+	fresh := !e.healthAt.IsZero() && time.Since(e.healthAt) < openAIHealthTTL
+	err := e.healthError
+	e.mu.Unlock()
+
+	if fresh {
+		return err
+	}
+	return e.CheckHealth()
+}
+
+// validateConfig checks settings that do not need the server
+func (e *OpenAIEngine) validateConfig() error {
+	if e.config.Voice == "" {
+		return errors.New("no voice configured (engines.openai.voice)")
+	}
+	if e.config.ResponseFormat != "wav" && e.config.ResponseFormat != "pcm" && e.ffmpegBinary == "" {
+		return fmt.Errorf("ffmpeg is required to decode %s responses", e.config.ResponseFormat)
+	}
+	return nil
+}
+
+// SetSpeed sets the default speaking speed
+func (e *OpenAIEngine) SetSpeed(speed float64) error {
+	if speed < MinSpeed || speed > MaxSpeed {
+		return fmt.Errorf("speed must be between %.1f and %.1f", MinSpeed, MaxSpeed)
+	}
+	e.speed = speed
+	return nil
+}
+
+// GetName returns the engine name
+func (e *OpenAIEngine) GetName() string {
+	if e.config.Model != "" {
+		return fmt.Sprintf("OpenAI-compatible (%s, %s)", e.config.Model, e.config.Voice)
+	}
+	return fmt.Sprintf("OpenAI-compatible (%s)", e.config.Voice)
+}
+
+// Validate checks the configuration and that the server is reachable
+func (e *OpenAIEngine) Validate() error {
+	if err := e.validateConfig(); err != nil {
+		return err
+	}
+	return e.health()
+}
+
+// IsAvailable checks if the engine can be used
+func (e *OpenAIEngine) IsAvailable() bool {
+	return e.Validate() == nil
+}
+
+// GetInfo returns information about the engine configuration
+func (e *OpenAIEngine) GetInfo() map[string]string {
+	return map[string]string{
+		"engine":         "openai",
+		"url":            e.baseURL,
+		"model":          e.config.Model,
+		"voice":          e.config.Voice,
+		"responseFormat": e.config.ResponseFormat,
+		"format":         "PCM 16-bit mono",
+	}
+}
+
+// Cleanup performs cleanup for the OpenAI-compatible engine
+func (e *OpenAIEngine) Cleanup() error {
+	e.client.CloseIdleConnections()
+	return nil
+}
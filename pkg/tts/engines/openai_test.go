@@ -0,0 +1,185 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+func newTestOpenAIEngine(t *testing.T, handler http.HandlerFunc, config tts.OpenAIConfig) *OpenAIEngine {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config.BaseURL = server.URL + "/v1/"
+	if config.Voice == "" {
+		config.Voice = "alloy"
+	}
+	engine, err := NewOpenAIEngine(config)
+	if err != nil {
+		t.Fatalf("NewOpenAIEngine failed: %v", err)
+	}
+	engine.retryDelay = time.Millisecond
+	return engine
+}
+
+func TestOpenAIEngineSynthesize(t *testing.T) {
+	var wav bytes.Buffer
+	if err := tts.WriteWAV(&wav, make([]byte, 2205*2), tts.DefaultPCMFormat()); err != nil {
+		t.Fatal(err)
+	}
+
+	var got speechRequest
+	engine := newTestOpenAIEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/speech" || r.Method != http.MethodPost {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write(wav.Bytes())
+	}, tts.OpenAIConfig{Model: "kokoro", Voice: "af_bella", APIKey: "secret"})
+
+	pcm, err := engine.Synthesize("Hello there.", 1.5)
+	if err != nil {
+		t.Fatalf("Synthesize failed: %v", err)
+	}
+	if len(pcm) != 2205*2 {
+		t.Errorf("Expected %d bytes of PCM, got %d", 2205*2, len(pcm))
+	}
+
+	want := speechRequest{Model: "kokoro", Input: "Hello there.", Voice: "af_bella", ResponseFormat: "wav", Speed: 1.5}
+	if got != want {
+		t.Errorf("Expected request %+v, got %+v", want, got)
+	}
+}
+
+func TestOpenAIEngineRawPCM(t *testing.T) {
+	// One second of 24kHz PCM becomes one second at 22050Hz
+	engine := newTestOpenAIEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(make([]byte, 24000*2))
+	}, tts.OpenAIConfig{ResponseFormat: "pcm", SampleRate: 24000})
+
+	pcm, err := engine.Synthesize("Hello.", 1.0)
+	if err != nil {
+		t.Fatalf("Synthesize failed: %v", err)
+	}
+	if diff := len(pcm) - tts.SampleRate*2; diff < -4 || diff > 4 {
+		t.Errorf("Expected about %d bytes of PCM, got %d", tts.SampleRate*2, len(pcm))
+	}
+}
+
+func TestOpenAIEngineRetries(t *testing.T) {
+	t.Run("server errors are retried", func(t *testing.T) {
+		var calls int32
+		engine := newTestOpenAIEngine(t, func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write(make([]byte, 100))
+		}, tts.OpenAIConfig{ResponseFormat: "pcm", SampleRate: tts.SampleRate, Retries: 2})
+
+		if _, err := engine.Synthesize("Hello.", 1.0); err != nil {
+			t.Fatalf("Expected success after retries, got %v", err)
+		}
+		if n := atomic.LoadInt32(&calls); n != 3 {
+			t.Errorf("Expected 3 requests, got %d", n)
+		}
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls int32
+		engine := newTestOpenAIEngine(t, func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			http.Error(w, `{"detail":"unknown voice"}`, http.StatusBadRequest)
+		}, tts.OpenAIConfig{Retries: 2})
+
+		if _, err := engine.Synthesize("Hello.", 1.0); err == nil {
+			t.Fatal("Expected error for bad request")
+		}
+		if n := atomic.LoadInt32(&calls); n != 1 {
+			t.Errorf("Expected a single request, got %d", n)
+		}
+	})
+}
+
+func TestOpenAIEngineCancel(t *testing.T) {
+	var calls int32
+	engine := newTestOpenAIEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio/speech" {
+			return
+		}
+		atomic.AddInt32(&calls, 1)
+		// The server only notices the client going away once the body is read
+		io.Copy(io.Discard, r.Body)
+		<-r.Context().Done()
+	}, tts.OpenAIConfig{Retries: 2})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	if _, err := engine.SynthesizeContext(ctx, "Hello.", 1.0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the context error, got %v", err)
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Errorf("Expected cancellation to stop retries, got %d requests", n)
+	}
+	if err := engine.Validate(); err != nil {
+		t.Errorf("Expected cancellation not to mark the server unhealthy: %v", err)
+	}
+}
+
+func TestOpenAIEngineHealth(t *testing.T) {
+	var status int32 = http.StatusOK
+	engine := newTestOpenAIEngine(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(atomic.LoadInt32(&status)))
+	}, tts.OpenAIConfig{})
+
+	if !engine.IsAvailable() {
+		t.Fatalf("Expected engine to be available: %v", engine.Validate())
+	}
+
+	atomic.StoreInt32(&status, http.StatusUnauthorized)
+	if err := engine.CheckHealth(); err == nil {
+		t.Error("Expected health check to fail when the key is rejected")
+	}
+	if engine.IsAvailable() {
+		t.Error("Expected cached health failure to make the engine unavailable")
+	}
+
+	unreachable, err := NewOpenAIEngine(tts.OpenAIConfig{BaseURL: "http://127.0.0.1:1", Voice: "alloy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unreachable.Validate() == nil {
+		t.Error("Expected unreachable server to fail validation")
+	}
+}
+
+func TestOpenAIEngineConfig(t *testing.T) {
+	for _, config := range []tts.OpenAIConfig{
+		{},
+		{BaseURL: "localhost:8880"},
+		{BaseURL: "http://localhost:8880", ResponseFormat: "ogg"},
+	} {
+		if _, err := NewOpenAIEngine(config); err == nil {
+			t.Errorf("Expected error for config %+v", config)
+		}
+	}
+}
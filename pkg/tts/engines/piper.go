@@ -3,13 +3,18 @@ package engines
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
 )
 
 // Audio format constants for Piper
@@ -26,6 +31,9 @@ const (
 	MinSpeed = 0.5
 	// MaxSpeed is the maximum speaking speed
 	MaxSpeed = 2.0
+	// piperStreamMinLength is the text length at which streaming from a
+	// dedicated process beats waiting on the persistent worker
+	piperStreamMinLength = 200
 )
 
 // PiperError represents Piper-specific errors
@@ -60,13 +68,27 @@ type PiperEngine struct {
 	voiceName string
 	// timeout for synthesis operations
 	timeout time.Duration
+	// persistent keeps one piper process alive across sentences
+	persistent bool
+	// lifecycle supervises the persistent worker process
+	lifecycle *tts.SubprocessLifecycle
+	// voiceDirs are scanned for voice models (standard locations if empty)
+	voiceDirs []string
+
+	workerMu sync.Mutex
+	worker   *piperWorker
+	// workerAnswered is set once a worker has completed a request, which
+	// shows this piper build supports --json-input
+	workerAnswered bool
 }
 
 // NewPiperEngine creates a new Piper TTS engine instance
 func NewPiperEngine() (*PiperEngine, error) {
 	engine := &PiperEngine{
-		speed:   DefaultSpeed,
-		timeout: 30 * time.Second,
+		speed:      DefaultSpeed,
+		timeout:    30 * time.Second,
+		persistent: true,
+		lifecycle:  tts.NewSubprocessLifecycle(),
 	}
 
 	// Try to find the piper binary
@@ -129,46 +151,10 @@ func (e *PiperEngine) findBinary() error {
 	}
 }
 
-// findDefaultModel searches for ONNX voice models in standard locations
+// findDefaultModel picks the first voice model found in the voice directories
 func (e *PiperEngine) findDefaultModel() error {
-	// Common model locations
-	modelDirs := []string{
-		filepath.Join(os.Getenv("HOME"), ".local/share/piper-voices"),
-		"/usr/share/piper-voices",
-		"/usr/local/share/piper-voices",
-		filepath.Join(os.Getenv("HOME"), ".config/piper/voices"),
-		"/opt/piper/voices",
-	}
-
-	// Look for any .onnx file
-	for _, dir := range modelDirs {
-		if _, err := os.Stat(dir); err != nil {
-			continue
-		}
-
-		// Walk the directory looking for ONNX models
-		err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-			if err != nil {
-				return nil // Continue walking
-			}
-
-			if strings.HasSuffix(path, ".onnx") {
-				e.modelPath = path
-				// Look for accompanying config file
-				configPath := strings.TrimSuffix(path, ".onnx") + ".onnx.json"
-				if _, err := os.Stat(configPath); err == nil {
-					e.configPath = configPath
-				}
-				// Extract voice name from path
-				e.voiceName = filepath.Base(strings.TrimSuffix(path, ".onnx"))
-				return io.EOF // Stop walking, we found a model
-			}
-			return nil
-		})
-
-		if err == io.EOF {
-			return nil // Found a model
-		}
+	if voices := tts.ScanPiperVoices(e.searchDirs()...); len(voices) > 0 {
+		return e.SetModel(voices[0].Path)
 	}
 
 	return &PiperError{
@@ -179,6 +165,28 @@ and place it in ~/.local/share/piper-voices/`,
 	}
 }
 
+// searchDirs returns the directories scanned for voice models
+func (e *PiperEngine) searchDirs() []string {
+	if len(e.voiceDirs) > 0 {
+		return e.voiceDirs
+	}
+	return tts.DefaultPiperVoiceDirs()
+}
+
+// SetVoiceDirs sets the directories scanned for voice models
+func (e *PiperEngine) SetVoiceDirs(dirs []string) {
+	e.voiceDirs = dirs
+}
+
+// ListVoices returns the voice models found in the voice directories
+func (e *PiperEngine) ListVoices() ([]tts.Voice, error) {
+	dirs := e.searchDirs()
+	if e.modelPath != "" {
+		dirs = append([]string{filepath.Dir(e.modelPath)}, dirs...)
+	}
+	return tts.ScanPiperVoices(dirs...), nil
+}
+
 // SetModel sets the voice model to use
 func (e *PiperEngine) SetModel(modelPath string) error {
 	// Validate the model file exists
@@ -215,6 +223,13 @@ func (e *PiperEngine) SetModel(modelPath string) error {
 
 // Synthesize converts text to speech audio data
 func (e *PiperEngine) Synthesize(text string, speed float64) ([]byte, error) {
+	return e.SynthesizeContext(context.Background(), text, speed)
+}
+
+// SynthesizeContext converts text to speech audio data, giving up when ctx
+// is cancelled. The piper process is killed, whether one-shot or the
+// persistent worker, which the next request restarts.
+func (e *PiperEngine) SynthesizeContext(ctx context.Context, text string, speed float64) ([]byte, error) {
 	if text == "" {
 		return []byte{}, nil
 	}
@@ -231,7 +246,52 @@ func (e *PiperEngine) Synthesize(text string, speed float64) ([]byte, error) {
 		speed = e.speed
 	}
 
-	// Build command arguments
+	if e.isPersistent() {
+		audio, err := e.synthesizeWithWorker(ctx, text, speed)
+		if err == nil || !errors.Is(err, errWorkerExited) || e.hasWorkerAnswered() {
+			return audio, err
+		}
+		// A worker that exits before ever answering needs --json-input,
+		// which older piper builds lack; fall back to a process per sentence
+		log.Warn("Piper worker unavailable, falling back to one process per sentence", "error", err)
+		e.SetPersistent(false)
+	}
+
+	return e.synthesizeOnce(ctx, text, speed)
+}
+
+// SynthesizeStream returns raw PCM as piper produces it. Piper emits audio
+// line by line, so long passages start playing after the first line; short
+// text goes through the persistent worker, which avoids reloading the model.
+func (e *PiperEngine) SynthesizeStream(ctx context.Context, text string, speed float64) (io.ReadCloser, error) {
+	if speed <= 0 {
+		speed = e.speed
+	}
+	if text == "" || (e.isPersistent() && len(text) < piperStreamMinLength) {
+		audio, err := e.SynthesizeContext(ctx, text, speed)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(audio)), nil
+	}
+
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	stream, err := tts.NewSubprocessManager(e.timeout).StreamProcess(ctx, text, e.binaryPath, e.oneShotArgs(speed)...)
+	if err != nil {
+		return nil, &PiperError{
+			Type:    "process",
+			Message: "failed to start piper process",
+			Cause:   err,
+		}
+	}
+	return stream, nil
+}
+
+// oneShotArgs builds the arguments for a single piper process writing raw PCM
+func (e *PiperEngine) oneShotArgs(speed float64) []string {
 	args := []string{
 		"--model", e.modelPath,
 		"--output-raw",
@@ -249,9 +309,81 @@ func (e *PiperEngine) Synthesize(text string, speed float64) ([]byte, error) {
 		lengthScale := 1.0 / speed
 		args = append(args, "--length-scale", fmt.Sprintf("%.2f", lengthScale))
 	}
+	return args
+}
+
+// synthesizeWithWorker routes a request to the persistent worker, replacing
+// it when the model or speed no longer matches
+func (e *PiperEngine) synthesizeWithWorker(ctx context.Context, text string, speed float64) ([]byte, error) {
+	config := piperWorkerConfig{
+		binaryPath:  e.binaryPath,
+		modelPath:   e.modelPath,
+		configPath:  e.configPath,
+		lengthScale: 1.0 / speed,
+	}
+
+	for {
+		worker := e.currentWorker(config)
+		if worker == nil {
+			return e.synthesizeOnce(ctx, text, speed)
+		}
+		audio, err := worker.synthesize(ctx, text, e.timeout)
+		if errors.Is(err, errWorkerClosed) {
+			// Replaced while this request waited; ask its successor
+			continue
+		}
+		if err == nil {
+			e.workerMu.Lock()
+			e.workerAnswered = true
+			e.workerMu.Unlock()
+		}
+		return audio, err
+	}
+}
+
+// currentWorker returns the worker for config, replacing one started with
+// other settings, or nil once the engine is no longer persistent
+func (e *PiperEngine) currentWorker(config piperWorkerConfig) *piperWorker {
+	e.workerMu.Lock()
+	if !e.persistent {
+		e.workerMu.Unlock()
+		return nil
+	}
+	var old *piperWorker
+	if e.worker != nil && e.worker.config != config {
+		old, e.worker = e.worker, nil
+	}
+	if e.worker == nil {
+		if e.lifecycle == nil {
+			e.lifecycle = tts.NewSubprocessLifecycle()
+		}
+		e.worker = newPiperWorker(config, e.lifecycle)
+	}
+	worker := e.worker
+	e.workerMu.Unlock()
+
+	// The old worker is stopped outside workerMu, as its request in flight
+	// must not hold up other callers
+	if old != nil {
+		old.stop()
+	}
+	return worker
+}
 
-	// Create command with context for timeout
-	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+// hasWorkerAnswered reports whether a worker has ever completed a request
+func (e *PiperEngine) hasWorkerAnswered() bool {
+	e.workerMu.Lock()
+	defer e.workerMu.Unlock()
+	return e.workerAnswered
+}
+
+// synthesizeOnce runs a dedicated piper process for a single sentence
+func (e *PiperEngine) synthesizeOnce(ctx context.Context, text string, speed float64) ([]byte, error) {
+	args := e.oneShotArgs(speed)
+
+	// Create command with context for timeout and cancellation
+	parent := ctx
+	ctx, cancel := context.WithTimeout(parent, e.timeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, e.binaryPath, args...)
@@ -285,6 +417,10 @@ func (e *PiperEngine) Synthesize(text string, speed float64) ([]byte, error) {
 	// Read audio data from stdout
 	var audioData bytes.Buffer
 	if _, err := io.Copy(&audioData, stdout); err != nil {
+		if parent.Err() != nil {
+			_ = cmd.Wait()
+			return nil, parent.Err()
+		}
 		return nil, &PiperError{
 			Type:    "synthesis",
 			Message: "failed to read audio data",
@@ -294,6 +430,11 @@ func (e *PiperEngine) Synthesize(text string, speed float64) ([]byte, error) {
 
 	// Wait for process to complete
 	if err := cmd.Wait(); err != nil {
+		// Cancelled by the caller
+		if parent.Err() != nil {
+			return nil, parent.Err()
+		}
+
 		// Check if it was a timeout
 		if ctx.Err() == context.DeadlineExceeded {
 			return nil, &PiperError{
@@ -355,6 +496,39 @@ func (e *PiperEngine) SetSpeed(speed float64) error {
 	return nil
 }
 
+// SetPersistent enables or disables the long-lived piper worker process
+func (e *PiperEngine) SetPersistent(persistent bool) {
+	e.workerMu.Lock()
+
+	e.persistent = persistent
+	var old *piperWorker
+	if !persistent {
+		old, e.worker = e.worker, nil
+	}
+	e.workerMu.Unlock()
+
+	if old != nil {
+		old.stop()
+	}
+}
+
+func (e *PiperEngine) isPersistent() bool {
+	e.workerMu.Lock()
+	defer e.workerMu.Unlock()
+	return e.persistent
+}
+
+// Subprocesses returns the lifecycle tracking the worker process
+func (e *PiperEngine) Subprocesses() *tts.SubprocessLifecycle {
+	e.workerMu.Lock()
+	defer e.workerMu.Unlock()
+
+	if e.lifecycle == nil {
+		e.lifecycle = tts.NewSubprocessLifecycle()
+	}
+	return e.lifecycle
+}
+
 // Validate checks if the engine is properly configured
 func (e *PiperEngine) Validate() error {
 	// Check binary
@@ -435,14 +609,24 @@ func (e *PiperEngine) GetInfo() map[string]string {
 	if e.configPath != "" {
 		info["config"] = e.configPath
 	}
+	if e.isPersistent() {
+		info["worker"] = "persistent"
+	}
 
 	return info
 }
 
 // Cleanup performs cleanup for the Piper engine
 func (e *PiperEngine) Cleanup() error {
-	// Piper doesn't create temp files by default, 
-	// but we should ensure any running processes are terminated
-	// This is handled by the subprocess lifecycle manager
+	// Stop the persistent worker, detached first so a request in flight
+	// cannot hold up other callers
+	e.workerMu.Lock()
+	worker := e.worker
+	e.worker = nil
+	e.workerMu.Unlock()
+
+	if worker != nil {
+		worker.stop()
+	}
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,377 @@
+package engines
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// piperStopTimeout is how long a worker may take to exit after stdin closes
+const piperStopTimeout = 2 * time.Second
+
+// piperStderrLimit caps how much worker stderr is kept for error messages
+const piperStderrLimit = 4096
+
+// errWorkerExited reports that the worker process died mid-request
+var errWorkerExited = errors.New("piper worker exited")
+
+// errWorkerClosed reports that the worker was stopped, e.g. replaced after a
+// speed change, while a request waited for it
+var errWorkerClosed = errors.New("piper worker closed")
+
+// piperRequest is one line of Piper's --json-input protocol
+type piperRequest struct {
+	Text       string `json:"text"`
+	OutputFile string `json:"output_file"`
+}
+
+// piperWorkerConfig identifies the settings a worker process was started with
+type piperWorkerConfig struct {
+	binaryPath  string
+	modelPath   string
+	configPath  string
+	lengthScale float64
+}
+
+// args builds the command line for a long-lived Piper process. Each JSON
+// request names its own output file and Piper prints that path once the
+// utterance is written, which splits the output into per-utterance chunks.
+func (c piperWorkerConfig) args(outputDir string) []string {
+	args := []string{
+		"--model", c.modelPath,
+		"--json-input",
+		"--output-dir", outputDir,
+	}
+	if c.configPath != "" {
+		args = append(args, "--config", c.configPath)
+	}
+	if c.lengthScale != 1.0 {
+		args = append(args, "--length-scale", fmt.Sprintf("%.2f", c.lengthScale))
+	}
+	return args
+}
+
+// piperWorker keeps one Piper process (and its loaded model) alive across
+// sentences. Requests are serialized; a crashed process is restarted on the
+// next request.
+type piperWorker struct {
+	config    piperWorkerConfig
+	lifecycle *tts.SubprocessLifecycle
+
+	// stopped is closed by stop; the worker never restarts after
+	stopped  chan struct{}
+	stopOnce sync.Once
+
+	// mu serializes requests and guards the process state below. Changes
+	// to cmd, stdin, exited and outputDir also hold procMu, so stop can
+	// reach the process without waiting for a request to finish.
+	mu        sync.Mutex
+	procMu    sync.Mutex
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	lines     chan string
+	exited    chan struct{}
+	quit      chan struct{}
+	stderr    *tailBuffer
+	outputDir string
+	seq       int
+	restarts  int
+}
+
+// newPiperWorker creates a worker; the process starts on the first request
+func newPiperWorker(config piperWorkerConfig, lifecycle *tts.SubprocessLifecycle) *piperWorker {
+	return &piperWorker{
+		config:    config,
+		lifecycle: lifecycle,
+		stopped:   make(chan struct{}),
+	}
+}
+
+// synthesize sends text to the worker and returns 22050Hz mono PCM. If the
+// process has died it is restarted and the request retried once.
+func (w *piperWorker) synthesize(ctx context.Context, text string, timeout time.Duration) ([]byte, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// Requests queue up behind the mutex; skip those cancelled while waiting
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if w.isStopped() {
+		return nil, errWorkerClosed
+	}
+
+	for attempt := 0; ; attempt++ {
+		if w.cmd == nil {
+			if err := w.start(); err != nil {
+				return nil, err
+			}
+		}
+
+		pcm, err := w.request(ctx, text, timeout)
+		if !errors.Is(err, errWorkerExited) || attempt > 0 {
+			return pcm, err
+		}
+
+		w.restarts++
+		log.Warn("Piper worker exited, restarting", "restarts", w.restarts, "error", err)
+	}
+}
+
+// start launches the Piper process and registers it for supervision
+func (w *piperWorker) start() error {
+	outputDir, err := os.MkdirTemp("", "glow-piper-")
+	if err != nil {
+		return &PiperError{Type: "process", Message: "failed to create output directory", Cause: err}
+	}
+
+	cmd := exec.Command(w.config.binaryPath, w.config.args(outputDir)...)
+	stderr := &tailBuffer{limit: piperStderrLimit}
+	cmd.Stderr = stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		os.RemoveAll(outputDir)
+		return &PiperError{Type: "process", Message: "failed to create stdin pipe", Cause: err}
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		os.RemoveAll(outputDir)
+		return &PiperError{Type: "process", Message: "failed to create stdout pipe", Cause: err}
+	}
+
+	if err := cmd.Start(); err != nil {
+		os.RemoveAll(outputDir)
+		return &PiperError{Type: "process", Message: "failed to start piper worker", Cause: err}
+	}
+
+	lines := make(chan string, 1)
+	exited := make(chan struct{})
+	quit := make(chan struct{})
+	procName := fmt.Sprintf("piper-%d", cmd.Process.Pid)
+	w.lifecycle.Register(procName, cmd.Process)
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-quit:
+				// Worker was discarded; keep draining until the process exits
+			case <-w.stopped:
+			}
+		}
+		// Wait must only be called once stdout has been drained
+		_ = cmd.Wait()
+		w.lifecycle.Unregister(procName)
+		close(exited)
+	}()
+
+	w.procMu.Lock()
+	w.cmd = cmd
+	w.stdin = stdin
+	w.lines = lines
+	w.exited = exited
+	w.quit = quit
+	w.stderr = stderr
+	w.outputDir = outputDir
+	w.procMu.Unlock()
+
+	// stop may have run while the process was starting
+	if w.isStopped() {
+		w.kill()
+		return errWorkerClosed
+	}
+
+	log.Debug("Piper worker started", "pid", cmd.Process.Pid, "model", w.config.modelPath)
+	return nil
+}
+
+// request performs one round trip with the running process
+func (w *piperWorker) request(ctx context.Context, text string, timeout time.Duration) ([]byte, error) {
+	w.seq++
+	outputFile := filepath.Join(w.outputDir, fmt.Sprintf("utterance-%d.wav", w.seq))
+	defer os.Remove(outputFile)
+
+	line, err := json.Marshal(piperRequest{Text: text, OutputFile: outputFile})
+	if err != nil {
+		return nil, &PiperError{Type: "synthesis", Message: "failed to encode request", Cause: err}
+	}
+	if _, err := w.stdin.Write(append(line, '\n')); err != nil {
+		return nil, w.crashed(err)
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case path := <-w.lines:
+			// Piper echoes the path of each finished utterance; anything
+			// else on stdout is ignored
+			path = strings.TrimSpace(path)
+			if path != outputFile {
+				if filepath.Dir(path) == w.outputDir {
+					// Not this request's utterance
+					os.Remove(path)
+				}
+				continue
+			}
+			return w.readUtterance(outputFile)
+
+		case <-w.exited:
+			if w.isStopped() {
+				w.kill()
+				return nil, errWorkerClosed
+			}
+			return nil, w.crashed(nil)
+
+		case <-w.stopped:
+			w.kill()
+			return nil, errWorkerClosed
+
+		case <-ctx.Done():
+			// Piper would keep synthesizing the skipped sentence and delay
+			// the next one; the next request starts a fresh process
+			w.kill()
+			return nil, ctx.Err()
+
+		case <-timer.C:
+			w.kill()
+			return nil, &PiperError{
+				Type:    "timeout",
+				Message: fmt.Sprintf("synthesis timed out after %v", timeout),
+			}
+		}
+	}
+}
+
+// readUtterance loads a finished utterance and converts it to the PCM contract
+func (w *piperWorker) readUtterance(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &PiperError{Type: "synthesis", Message: "failed to read utterance", Cause: err}
+	}
+
+	pcm, err := tts.WAVToPCM(data)
+	if err != nil {
+		return nil, &PiperError{Type: "synthesis", Message: "invalid utterance audio", Cause: err}
+	}
+	if len(pcm) == 0 {
+		return nil, &PiperError{Type: "synthesis", Message: "no audio data generated"}
+	}
+	return pcm, nil
+}
+
+// crashed tears down a dead process and describes why it died
+func (w *piperWorker) crashed(cause error) error {
+	msg := strings.TrimSpace(w.stderr.String())
+	w.kill()
+
+	err := errWorkerExited
+	if cause != nil {
+		err = fmt.Errorf("%w: %v", errWorkerExited, cause)
+	}
+	if msg != "" {
+		err = fmt.Errorf("%w: %s", err, msg)
+	}
+	return &PiperError{Type: "process", Message: "piper worker crashed", Cause: err}
+}
+
+// kill terminates the process immediately and resets the worker state
+func (w *piperWorker) kill() {
+	if w.cmd == nil {
+		return
+	}
+	_ = w.cmd.Process.Kill()
+	w.reset()
+}
+
+// stop kills the process and makes the worker refuse further requests. It
+// does not wait for a request in flight, which returns errWorkerClosed.
+func (w *piperWorker) stop() {
+	w.procMu.Lock()
+	defer w.procMu.Unlock()
+
+	w.stopOnce.Do(func() { close(w.stopped) })
+	if w.cmd == nil {
+		return
+	}
+
+	_ = w.stdin.Close()
+	_ = w.cmd.Process.Kill()
+	exited, outputDir := w.exited, w.outputDir
+	go func() {
+		<-exited
+		os.RemoveAll(outputDir)
+	}()
+}
+
+// isStopped reports whether stop has been called
+func (w *piperWorker) isStopped() bool {
+	select {
+	case <-w.stopped:
+		return true
+	default:
+		return false
+	}
+}
+
+// reset forgets the current process and removes its output directory
+func (w *piperWorker) reset() {
+	close(w.quit)
+
+	// Wait for the reaper goroutine so the process is unregistered
+	select {
+	case <-w.exited:
+	case <-time.After(piperStopTimeout):
+	}
+
+	_ = w.stdin.Close()
+	os.RemoveAll(w.outputDir)
+
+	w.procMu.Lock()
+	w.cmd = nil
+	w.stdin = nil
+	w.lines = nil
+	w.exited = nil
+	w.quit = nil
+	w.outputDir = ""
+	w.procMu.Unlock()
+}
+
+// tailBuffer is an io.Writer that keeps only the last limit bytes written
+type tailBuffer struct {
+	mu    sync.Mutex
+	buf   []byte
+	limit int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.limit {
+		b.buf = b.buf[len(b.buf)-b.limit:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return string(b.buf)
+}
@@ -0,0 +1,327 @@
+package engines
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// fakePiperWorker creates a piper stand-in that speaks the --json-input
+// protocol. Every start is appended to the returned log file; when exitAfter
+// is positive the process exits after that many requests.
+func fakePiperWorker(t *testing.T, exitAfter int) (*PiperEngine, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake piper requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	var wav bytes.Buffer
+	if err := tts.WriteWAV(&wav, make([]byte, 2205*2), tts.DefaultPCMFormat()); err != nil {
+		t.Fatal(err)
+	}
+	wavFile := filepath.Join(dir, "utterance.wav")
+	if err := os.WriteFile(wavFile, wav.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	startsFile := filepath.Join(dir, "starts")
+	script := `#!/bin/sh
+echo "$@" >> ` + startsFile + `
+n=0
+while IFS= read -r line; do
+	out=$(printf '%s' "$line" | sed 's/.*"output_file":"\([^"]*\)".*/\1/')
+	cp ` + wavFile + ` "$out"
+	echo "$out"
+	n=$((n+1))
+	if [ ` + strconv.Itoa(exitAfter) + ` -gt 0 ] && [ $n -ge ` + strconv.Itoa(exitAfter) + ` ]; then
+		exit 1
+	fi
+done
+`
+	binary := filepath.Join(dir, "piper")
+	if err := os.WriteFile(binary, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	modelPath := filepath.Join(dir, "voice.onnx")
+	if err := os.WriteFile(modelPath, []byte("mock"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := &PiperEngine{
+		binaryPath: binary,
+		modelPath:  modelPath,
+		speed:      DefaultSpeed,
+		timeout:    5 * time.Second,
+		persistent: true,
+	}
+	t.Cleanup(func() { engine.Cleanup() })
+	return engine, startsFile
+}
+
+// scriptedPiper creates a piper stand-in running script, in which {dir} is
+// replaced by a directory holding utterance.wav, a valid utterance
+func scriptedPiper(t *testing.T, script string) *PiperEngine {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake piper requires a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	var wav bytes.Buffer
+	if err := tts.WriteWAV(&wav, make([]byte, 2205*2), tts.DefaultPCMFormat()); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "utterance.wav"), wav.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+	binary := filepath.Join(dir, "piper")
+	if err := os.WriteFile(binary, []byte("#!/bin/sh\n"+strings.ReplaceAll(script, "{dir}", dir)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	modelPath := filepath.Join(dir, "voice.onnx")
+	if err := os.WriteFile(modelPath, []byte("mock"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := &PiperEngine{
+		binaryPath: binary,
+		modelPath:  modelPath,
+		speed:      DefaultSpeed,
+		timeout:    5 * time.Second,
+		persistent: true,
+	}
+	t.Cleanup(func() { engine.Cleanup() })
+	return engine
+}
+
+func countStarts(t *testing.T, startsFile string) int {
+	t.Helper()
+	data, err := os.ReadFile(startsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.Count(string(data), "\n")
+}
+
+func TestPiperWorkerReusesProcess(t *testing.T) {
+	engine, startsFile := fakePiperWorker(t, 0)
+
+	for i := 0; i < 3; i++ {
+		audio, err := engine.Synthesize("Hello there.", 1.0)
+		if err != nil {
+			t.Fatalf("Synthesize %d failed: %v", i, err)
+		}
+		if len(audio) != 2205*2 {
+			t.Errorf("Expected %d bytes of audio, got %d", 2205*2, len(audio))
+		}
+	}
+
+	if starts := countStarts(t, startsFile); starts != 1 {
+		t.Errorf("Expected one piper process, got %d", starts)
+	}
+
+	// A different speed needs a new length scale
+	if _, err := engine.Synthesize("Faster now.", 2.0); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(startsFile)
+	if !strings.Contains(string(data), "--length-scale 0.50") {
+		t.Errorf("Expected restart with new length scale, got %q", data)
+	}
+}
+
+func TestPiperWorkerRestartsAfterCrash(t *testing.T) {
+	engine, startsFile := fakePiperWorker(t, 1)
+
+	for i := 0; i < 3; i++ {
+		if _, err := engine.Synthesize("Say something.", 1.0); err != nil {
+			t.Fatalf("Synthesize %d failed: %v", i, err)
+		}
+	}
+
+	if starts := countStarts(t, startsFile); starts < 2 {
+		t.Errorf("Expected the worker to be restarted, got %d starts", starts)
+	}
+	if !engine.isPersistent() {
+		t.Error("Expected engine to stay in persistent mode after a restart")
+	}
+}
+
+func TestPiperWorkerSerializesRequests(t *testing.T) {
+	engine, startsFile := fakePiperWorker(t, 0)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 8)
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := engine.Synthesize("Concurrent sentence.", 1.0); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("Concurrent synthesis failed: %v", err)
+	}
+	if starts := countStarts(t, startsFile); starts != 1 {
+		t.Errorf("Expected one piper process, got %d", starts)
+	}
+}
+
+func TestPiperWorkerFallsBackWithoutJSONInput(t *testing.T) {
+	engine := scriptedPiper(t, `case "$*" in *--json-input*) echo "unknown option" >&2; exit 1;; esac
+cat > /dev/null
+head -c 4410 /dev/zero
+`)
+
+	audio, err := engine.Synthesize("Hello there.", 1.0)
+	if err != nil {
+		t.Fatalf("Expected a one-shot fallback, got %v", err)
+	}
+	if len(audio) != 4410 {
+		t.Errorf("Expected 4410 bytes of audio, got %d", len(audio))
+	}
+	if engine.isPersistent() {
+		t.Error("Expected a piper without --json-input to disable the worker")
+	}
+}
+
+func TestPiperWorkerCrashAfterRestartIsAnError(t *testing.T) {
+	// Answers one request, then every later start dies immediately
+	engine := scriptedPiper(t, `[ -e {dir}/answered ] && exit 1
+touch {dir}/answered
+IFS= read -r line
+out=$(printf '%s' "$line" | sed 's/.*"output_file":"\([^"]*\)".*/\1/')
+cp {dir}/utterance.wav "$out"
+echo "$out"
+exit 1
+`)
+
+	if _, err := engine.Synthesize("First.", 1.0); err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+	if _, err := engine.Synthesize("Second.", 1.0); !errors.Is(err, errWorkerExited) {
+		t.Errorf("Expected the crash to be reported, got %v", err)
+	}
+	if !engine.isPersistent() {
+		t.Error("Expected a worker that once answered to stay persistent")
+	}
+}
+
+func TestPiperWorkerStoppedIsNotRestarted(t *testing.T) {
+	engine, startsFile := fakePiperWorker(t, 0)
+
+	if _, err := engine.Synthesize("Hello.", 1.0); err != nil {
+		t.Fatal(err)
+	}
+	engine.workerMu.Lock()
+	old := engine.worker
+	engine.workerMu.Unlock()
+
+	// A speed change replaces the worker; a request still holding the old
+	// one must not bring its process back
+	if _, err := engine.Synthesize("Faster.", 2.0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := old.synthesize(context.Background(), "Stale.", time.Second); !errors.Is(err, errWorkerClosed) {
+		t.Errorf("Expected the replaced worker to refuse requests, got %v", err)
+	}
+	if starts := countStarts(t, startsFile); starts != 2 {
+		t.Errorf("Expected two piper processes, got %d", starts)
+	}
+}
+
+func TestPiperWorkerStopDoesNotWaitForRequest(t *testing.T) {
+	// Reads requests but never answers them
+	engine := scriptedPiper(t, `while IFS= read -r line; do :; done
+exec sleep 30
+`)
+	engine.timeout = 30 * time.Second
+
+	worker := engine.currentWorker(piperWorkerConfig{
+		binaryPath:  engine.binaryPath,
+		modelPath:   engine.modelPath,
+		lengthScale: 1.0,
+	})
+	result := make(chan error, 1)
+	go func() {
+		_, err := worker.synthesize(context.Background(), "Never answered.", engine.timeout)
+		result <- err
+	}()
+	time.Sleep(200 * time.Millisecond)
+
+	// Replacing the worker and other engine calls return while the
+	// request is in flight
+	stopped := make(chan struct{})
+	go func() {
+		engine.currentWorker(piperWorkerConfig{
+			binaryPath:  engine.binaryPath,
+			modelPath:   engine.modelPath,
+			lengthScale: 0.5,
+		})
+		engine.hasWorkerAnswered()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected replacing the worker not to wait for its request")
+	}
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, errWorkerClosed) {
+			t.Errorf("Expected the request to end with the worker, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the request in flight to end when its worker stopped")
+	}
+}
+
+func TestPiperWorkerCancelKillsUtterance(t *testing.T) {
+	// The first process never answers; later ones answer at once
+	engine := scriptedPiper(t, `if [ ! -e {dir}/started ]; then
+	touch {dir}/started
+	exec sleep 30
+fi
+while IFS= read -r line; do
+	out=$(printf '%s' "$line" | sed 's/.*"output_file":"\([^"]*\)".*/\1/')
+	cp {dir}/utterance.wav "$out"
+	echo "$out"
+done
+`)
+	engine.timeout = 30 * time.Second
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := engine.SynthesizeContext(ctx, "Skipped.", 1.0); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the request to be cancelled, got %v", err)
+	}
+
+	// The skipped sentence is not finished first
+	start := time.Now()
+	if _, err := engine.Synthesize("Jumped to.", 1.0); err != nil {
+		t.Fatalf("Next request failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Expected the next request not to wait for the skipped one, took %v", elapsed)
+	}
+	if !engine.isPersistent() {
+		t.Error("Expected the worker to stay persistent after a cancel")
+	}
+}
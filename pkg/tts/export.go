@@ -0,0 +1,253 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// ExportConfig holds settings for rendering a whole document to audio
+type ExportConfig struct {
+	// Speed is the synthesis speed passed to the engine
+	Speed float64
+	// SentencePause is the silence inserted between sentences
+	SentencePause time.Duration
+	// Parser configures sentence extraction
+	Parser *ParserConfig
+	// Lexicon rewrites terms before synthesis (optional)
+	Lexicon *Lexicon
+	// LanguageEngines speak sentences tagged with other languages,
+	// keyed by language code (optional)
+	LanguageEngines map[string]TTSEngine
+	// Cache holds synthesized sentences across exports (optional)
+	Cache *TTSCacheManager
+	// OnProgress is called after each sentence is synthesized or skipped
+	// (optional)
+	OnProgress func(done, total int)
+}
+
+// DefaultExportConfig returns the default export configuration
+func DefaultExportConfig() *ExportConfig {
+	return &ExportConfig{
+		Speed:         1.0,
+		SentencePause: 300 * time.Millisecond,
+		Parser:        DefaultParserConfig(),
+	}
+}
+
+// ExportSegment describes where a sentence landed in the exported audio
+type ExportSegment struct {
+	Sentence ParsedSentence
+	Offset   time.Duration
+	Duration time.Duration
+	// FirstLine and LastLine are the 1-based source lines of the
+	// sentence, or 0 when it could not be located
+	FirstLine int
+	LastLine  int
+	// Document is the file the sentence came from when several
+	// documents are joined, as in an audiobook
+	Document string
+}
+
+// ExportResult holds the synthesized audio for a document
+type ExportResult struct {
+	Audio    []byte
+	Format   PCMFormat
+	Segments []ExportSegment
+}
+
+// Duration returns the total length of the exported audio. A result that
+// keeps only the segments ends where its last segment does.
+func (r *ExportResult) Duration() time.Duration {
+	if len(r.Audio) == 0 && len(r.Segments) > 0 {
+		last := r.Segments[len(r.Segments)-1]
+		return last.Offset + last.Duration
+	}
+	return pcmDuration(len(r.Audio), r.Format)
+}
+
+// WriteWAV writes the exported audio as a WAV file
+func (r *ExportResult) WriteWAV(w io.Writer) error {
+	return WriteWAV(w, r.Audio, r.Format)
+}
+
+// Exporter renders markdown documents to a single PCM stream
+type Exporter struct {
+	engine TTSEngine
+	parser *SentenceParser
+	config *ExportConfig
+	format PCMFormat
+}
+
+// NewExporter creates a new exporter for the given engine
+func NewExporter(engine TTSEngine, config *ExportConfig) (*Exporter, error) {
+	if engine == nil {
+		return nil, errors.New("no TTS engine configured")
+	}
+	if config == nil {
+		config = DefaultExportConfig()
+	}
+	if config.Speed <= 0 {
+		config.Speed = 1.0
+	}
+
+	parser, err := NewSentenceParser(config.Parser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parser: %w", err)
+	}
+
+	return &Exporter{
+		engine: engine,
+		parser: parser,
+		config: config,
+		format: DefaultPCMFormat(),
+	}, nil
+}
+
+// Export synthesizes every sentence of the markdown document in order
+func (e *Exporter) Export(markdown string) (*ExportResult, error) {
+	sentences, err := e.Parse(markdown)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := e.ExportSentences(sentences)
+	if err != nil {
+		return nil, err
+	}
+	setSourceLines(result.Segments, markdown, sentences)
+	return result, nil
+}
+
+// setSourceLines fills in the markdown lines each segment's sentence came from
+func setSourceLines(segments []ExportSegment, markdown string, sentences []ParsedSentence) {
+	lines := SourceLines(markdown, sentences)
+	for i := range segments {
+		if position := segments[i].Sentence.Position; position < len(lines) {
+			segments[i].FirstLine, segments[i].LastLine = lines[position][0], lines[position][1]
+		}
+	}
+}
+
+// Parse splits the markdown document into the sentences Export reads
+func (e *Exporter) Parse(markdown string) ([]ParsedSentence, error) {
+	sentences, err := e.parser.Parse(markdown)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse document: %w", err)
+	}
+	if len(sentences) == 0 {
+		return nil, errors.New("document contains no speakable text")
+	}
+	return sentences, nil
+}
+
+// ExportSentences synthesizes already parsed sentences in order
+func (e *Exporter) ExportSentences(sentences []ParsedSentence) (*ExportResult, error) {
+	result := &ExportResult{
+		Format:   e.format,
+		Segments: make([]ExportSegment, 0, len(sentences)),
+	}
+	err := e.Stream(context.Background(), sentences, func(segment ExportSegment, audio []byte) error {
+		result.Segments = append(result.Segments, segment)
+		result.Audio = append(result.Audio, audio...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Stream synthesizes sentences in order and hands each one to emit as soon
+// as it is ready. audio starts with the pause before the sentence, so the
+// concatenated audio is what ExportSentences returns and segment offsets
+// match it.
+func (e *Exporter) Stream(ctx context.Context, sentences []ParsedSentence, emit func(segment ExportSegment, audio []byte) error) error {
+	pause := GenerateSilence(e.config.SentencePause.Seconds(), e.format)
+	written := 0
+	progress := func(done int) {
+		if e.config.OnProgress != nil {
+			e.config.OnProgress(done, len(sentences))
+		}
+	}
+
+	for i, sentence := range sentences {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if strings.TrimSpace(sentence.SpokenText()) == "" {
+			progress(i + 1)
+			continue
+		}
+
+		audio, err := e.synthesize(ctx, sentence)
+		if err != nil {
+			return fmt.Errorf("failed to synthesize sentence %d: %w", i+1, err)
+		}
+		if err := ValidatePCMData(audio, e.format); err != nil {
+			log.Warn("Skipping sentence with invalid audio", "sentence", i+1, "error", err)
+			progress(i + 1)
+			continue
+		}
+
+		segment := ExportSegment{
+			Sentence: sentence,
+			Offset:   pcmDuration(written, e.format),
+			Duration: pcmDuration(len(audio), e.format),
+		}
+		if written > 0 {
+			segment.Offset = pcmDuration(written+len(pause), e.format)
+			audio = append(pause[:len(pause):len(pause)], audio...)
+		}
+		written += len(audio)
+		if err := emit(segment, audio); err != nil {
+			return err
+		}
+
+		progress(i + 1)
+	}
+
+	if written == 0 {
+		return errors.New("no audio was produced")
+	}
+	return nil
+}
+
+// synthesize speaks one sentence, using the cache when one is configured
+func (e *Exporter) synthesize(ctx context.Context, sentence ParsedSentence) ([]byte, error) {
+	engine := e.engine
+	if routed := LanguageEngine(e.config.LanguageEngines, sentence.Language); routed != nil {
+		engine = routed
+	}
+	speech := sentence.Speech().Map(e.config.Lexicon.Apply)
+	if e.config.Cache == nil {
+		return SynthesizeSpeech(ctx, engine, speech, e.config.Speed)
+	}
+
+	key := GenerateCacheKey(speechCacheText(speech), engine.GetName(), e.config.Speed)
+	if cached, err := e.config.Cache.Get(key); err == nil && cached != nil {
+		return cached.Audio, nil
+	}
+	audio, err := SynthesizeSpeech(ctx, engine, speech, e.config.Speed)
+	if err != nil {
+		return nil, err
+	}
+	_ = e.config.Cache.Put(key, &AudioData{
+		Audio:    audio,
+		Text:     speechCacheText(speech),
+		Voice:    engine.GetName(),
+		Speed:    e.config.Speed,
+		CacheKey: key,
+	})
+	return audio, nil
+}
+
+// pcmDuration converts a PCM byte length to a time.Duration
+func pcmDuration(dataLen int, format PCMFormat) time.Duration {
+	return time.Duration(CalculatePCMDuration(dataLen, format) * float64(time.Second))
+}
@@ -0,0 +1,116 @@
+package tts
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExporter(t *testing.T) {
+	format := DefaultPCMFormat()
+	sentenceAudio := make([]byte, 2205*format.BytesPerSample()) // 100ms
+
+	var spoken []string
+	engine := &mockQueueEngine{
+		name:      "mock",
+		available: true,
+		synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+			spoken = append(spoken, text)
+			return sentenceAudio, nil
+		},
+	}
+
+	config := DefaultExportConfig()
+	config.SentencePause = 50 * time.Millisecond
+	var progress []int
+	config.OnProgress = func(done, total int) {
+		progress = append(progress, done)
+	}
+
+	exporter, err := NewExporter(engine, config)
+	if err != nil {
+		t.Fatalf("NewExporter failed: %v", err)
+	}
+
+	result, err := exporter.Export("# Title\n\nFirst sentence here. Second sentence here.")
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+
+	if len(spoken) != len(result.Segments) {
+		t.Errorf("Expected %d segments, got %d", len(spoken), len(result.Segments))
+	}
+	if len(progress) != len(spoken) {
+		t.Errorf("Expected %d progress callbacks, got %d", len(spoken), len(progress))
+	}
+
+	// The title is padded with silence for engines without SSML
+	pause := len(GenerateSilence(0.05, format))
+	titlePauses := len(GenerateSilence(headingPause(1).Seconds(), format)) + len(GenerateSilence((headingPause(1)/2).Seconds(), format))
+	expectedLen := len(spoken)*len(sentenceAudio) + (len(spoken)-1)*pause + titlePauses
+	if len(result.Audio) != expectedLen {
+		t.Errorf("Expected %d bytes of audio, got %d", expectedLen, len(result.Audio))
+	}
+
+	for i := 1; i < len(result.Segments); i++ {
+		prev := result.Segments[i-1]
+		if result.Segments[i].Offset < prev.Offset+prev.Duration {
+			t.Errorf("Segment %d overlaps the previous one", i)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := result.WriteWAV(&buf); err != nil {
+		t.Fatalf("WriteWAV failed: %v", err)
+	}
+	if buf.Len() != WAVHeaderSize+len(result.Audio) {
+		t.Errorf("Unexpected WAV size %d", buf.Len())
+	}
+
+	t.Run("synthesis error", func(t *testing.T) {
+		failing := &mockQueueEngine{
+			synthesizeFunc: func(string, float64) ([]byte, error) {
+				return nil, errors.New("boom")
+			},
+		}
+		exporter, _ := NewExporter(failing, nil)
+		if _, err := exporter.Export("Something to say."); err == nil {
+			t.Error("Expected synthesis error to propagate")
+		}
+	})
+
+	t.Run("skipped last sentence", func(t *testing.T) {
+		skipping := &mockQueueEngine{
+			synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+				if strings.Contains(text, "Broken") {
+					return []byte{0}, nil
+				}
+				return sentenceAudio, nil
+			},
+		}
+		var progress [][2]int
+		config := DefaultExportConfig()
+		config.OnProgress = func(done, total int) {
+			progress = append(progress, [2]int{done, total})
+		}
+		exporter, _ := NewExporter(skipping, config)
+		result, err := exporter.Export("Fine here. Broken one.")
+		if err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		if len(result.Segments) != 1 {
+			t.Errorf("Expected the broken sentence to be skipped, got %d segments", len(result.Segments))
+		}
+		if len(progress) != 2 || progress[1] != [2]int{2, 2} {
+			t.Errorf("Expected progress to reach the skipped last sentence, got %v", progress)
+		}
+	})
+
+	t.Run("empty document", func(t *testing.T) {
+		if _, err := exporter.Export("   "); err == nil {
+			t.Error("Expected error for empty document")
+		}
+	})
+}
@@ -0,0 +1,235 @@
+package tts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dgnsrekt/glow-tts/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatter is the document metadata read from YAML front matter
+type FrontMatter struct {
+	Title   string
+	Authors []string
+	// Language is the document language from "lang" or "language"
+	Language string
+	// TTS holds the document's overrides from the "tts" key
+	TTS DocumentSettings
+}
+
+// DocumentSettings are per-document TTS overrides. Zero values keep the
+// user's settings.
+type DocumentSettings struct {
+	// Voice for the active engine (a Piper voice ID or model path, a gTTS
+	// "lang:tld" ID, an espeak-ng voice, ...)
+	Voice string `yaml:"voice"`
+	// Speed is the playback speed
+	Speed float64 `yaml:"speed"`
+	// Language of the text, used for normalization and by engines that
+	// select voices by language
+	Language string `yaml:"language"`
+	// SkipCode leaves out every code block
+	SkipCode bool `yaml:"skip_code"`
+}
+
+// IsZero reports whether the settings override nothing
+func (d DocumentSettings) IsZero() bool {
+	return d == DocumentSettings{}
+}
+
+// SelectsVoice reports whether the settings change the voice of the named
+// engine, which then has to be created from ForDocument's configuration
+func (d DocumentSettings) SelectsVoice(engine string) bool {
+	switch strings.ToLower(engine) {
+	case "gtts", "espeak":
+		return d.Voice != "" || d.Language != ""
+	}
+	return d.Voice != ""
+}
+
+// authorNames accepts a single name or a list of names
+type authorNames []string
+
+// UnmarshalYAML implements yaml.Unmarshaler
+func (a *authorNames) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		*a = authorNames{value.Value}
+		return nil
+	}
+	var names []string
+	if err := value.Decode(&names); err != nil {
+		return err
+	}
+	*a = names
+	return nil
+}
+
+// ParseFrontMatter splits YAML front matter from a markdown document. The
+// body is returned even when the front matter cannot be parsed.
+func ParseFrontMatter(markdown string) (FrontMatter, string, error) {
+	header, body := utils.SplitFrontmatter([]byte(markdown))
+	if header == nil {
+		return FrontMatter{}, markdown, nil
+	}
+
+	var raw struct {
+		Title    string           `yaml:"title"`
+		Author   authorNames      `yaml:"author"`
+		Authors  authorNames      `yaml:"authors"`
+		Lang     string           `yaml:"lang"`
+		Language string           `yaml:"language"`
+		TTS      DocumentSettings `yaml:"tts"`
+	}
+	if err := yaml.Unmarshal(header, &raw); err != nil {
+		return FrontMatter{}, string(body), fmt.Errorf("invalid front matter: %w", err)
+	}
+
+	fm := FrontMatter{Title: strings.TrimSpace(raw.Title), TTS: raw.TTS}
+	for _, lang := range []string{raw.TTS.Language, raw.Lang, raw.Language} {
+		if lang = strings.TrimSpace(lang); lang != "" {
+			fm.Language = lang
+			break
+		}
+	}
+	for _, name := range append(raw.Author, raw.Authors...) {
+		if name = strings.TrimSpace(name); name != "" {
+			fm.Authors = append(fm.Authors, name)
+		}
+	}
+	return fm, string(body), nil
+}
+
+// Announcement is the spoken introduction for the document, such as
+// "Release Notes, by Ada Lovelace." It is empty without a title or author.
+func (fm FrontMatter) Announcement() string {
+	var by string
+	switch n := len(fm.Authors); {
+	case n == 1:
+		by = fm.Authors[0]
+	case n > 1:
+		by = strings.Join(fm.Authors[:n-1], ", ") + " and " + fm.Authors[n-1]
+	}
+
+	switch {
+	case fm.Title != "" && by != "":
+		return terminate(fm.Title + ", by " + by)
+	case fm.Title != "":
+		return terminate(fm.Title)
+	case by != "":
+		return terminate("By " + by)
+	}
+	return ""
+}
+
+// forDocument returns a parser with the document's language and
+// overrides applied, or p itself when there are none
+func (p *SentenceParser) forDocument(fm FrontMatter) *SentenceParser {
+	p = p.forLanguage(fm.Language)
+	if !fm.TTS.SkipCode {
+		return p
+	}
+
+	config := *p.config
+	config.IncludeCodeBlocks = false
+	config.CodeBlocks = CodeBlockSkip
+	config.CodeLanguages = nil
+
+	parser := *p
+	parser.config = &config
+	return &parser
+}
+
+// forLanguage returns a parser for text known to be in language, or p
+// itself when language is empty
+func (p *SentenceParser) forLanguage(language string) *SentenceParser {
+	if language == "" {
+		return p
+	}
+	parser := *p
+	parser.language = language
+	return &parser
+}
+
+// ForDocument returns a copy of the configuration with a document's voice,
+// speed and language applied to the named engine. The receiver is not
+// modified.
+func (c *TTSConfig) ForDocument(engine string, doc DocumentSettings) *TTSConfig {
+	config := *c
+	if doc.Speed > 0 {
+		config.Playback.DefaultSpeed = doc.Speed
+	}
+
+	switch strings.ToLower(engine) {
+	case "piper":
+		if doc.Voice != "" {
+			config.Engines.Piper.Voice = doc.Voice
+			if model := findPiperModel(doc.Voice, PiperVoiceDirs(c)); model != "" {
+				config.Engines.Piper.ModelPath = model
+			}
+		}
+	case "gtts":
+		if doc.Voice != "" {
+			lang, tld, _ := strings.Cut(doc.Voice, ":")
+			config.Engines.GTTS.Language = lang
+			if tld != "" {
+				config.Engines.GTTS.TLD = tld
+			}
+		} else if doc.Language != "" {
+			config.Engines.GTTS.Language = doc.Language
+		}
+	case "espeak":
+		if doc.Voice != "" {
+			config.Engines.ESpeak.Voice = doc.Voice
+		} else if doc.Language != "" {
+			config.Engines.ESpeak.Voice = doc.Language
+		}
+	case "command":
+		if doc.Voice != "" {
+			config.Engines.Command.Voice = doc.Voice
+		}
+	case "openai":
+		if doc.Voice != "" {
+			config.Engines.OpenAI.Voice = doc.Voice
+		}
+	}
+	return &config
+}
+
+// VoiceSetting returns the named engine's configured voice in the form a
+// document's front matter selects it, so ForDocument can restore it later
+func (c *TTSConfig) VoiceSetting(engine string) string {
+	switch strings.ToLower(engine) {
+	case "piper":
+		if c.Engines.Piper.ModelPath != "" {
+			return c.Engines.Piper.ModelPath
+		}
+		return c.Engines.Piper.Voice
+	case "gtts":
+		if c.Engines.GTTS.TLD != "" {
+			return c.Engines.GTTS.Language + ":" + c.Engines.GTTS.TLD
+		}
+		return c.Engines.GTTS.Language
+	case "espeak":
+		return c.Engines.ESpeak.Voice
+	case "command":
+		return c.Engines.Command.Voice
+	case "openai":
+		return c.Engines.OpenAI.Voice
+	}
+	return ""
+}
+
+// findPiperModel resolves a Piper voice ID such as "en_US-amy-medium" or a
+// model path to a model file, or "" when none is found
+func findPiperModel(voice string, dirs []string) string {
+	if strings.HasSuffix(voice, ".onnx") {
+		return utils.ExpandPath(voice)
+	}
+	for _, v := range ScanPiperVoices(dirs...) {
+		if v.ID == voice {
+			return v.Path
+		}
+	}
+	return ""
+}
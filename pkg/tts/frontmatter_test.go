@@ -0,0 +1,157 @@
+package tts
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const frontMatterMarkdown = `---
+title: Release Notes
+author:
+  - Ada Lovelace
+  - Grace Hopper
+tts:
+  voice: en_GB-alba-medium
+  speed: 1.25
+  language: en-GB
+  skip_code: true
+---
+
+The build takes 5ms.
+
+` + "```go\nfmt.Println(\"hi\")\n```\n"
+
+func TestParseFrontMatter(t *testing.T) {
+	fm, body, err := ParseFrontMatter(frontMatterMarkdown)
+	if err != nil {
+		t.Fatalf("ParseFrontMatter failed: %v", err)
+	}
+
+	want := FrontMatter{
+		Title:    "Release Notes",
+		Authors:  []string{"Ada Lovelace", "Grace Hopper"},
+		Language: "en-GB",
+		TTS: DocumentSettings{
+			Voice:    "en_GB-alba-medium",
+			Speed:    1.25,
+			Language: "en-GB",
+			SkipCode: true,
+		},
+	}
+	if !reflect.DeepEqual(fm, want) {
+		t.Errorf("Expected %+v, got %+v", want, fm)
+	}
+	if body[:10] != "The build " {
+		t.Errorf("Expected body without front matter, got %q", body)
+	}
+
+	fm, body, err = ParseFrontMatter("# No metadata\n")
+	if err != nil || body != "# No metadata\n" || fm.Title != "" || !fm.TTS.IsZero() {
+		t.Errorf("Expected document without front matter unchanged, got %+v %q %v", fm, body, err)
+	}
+
+	_, body, err = ParseFrontMatter("---\ntitle: [broken\n---\nBody text.\n")
+	if err == nil {
+		t.Error("Expected error for invalid front matter")
+	}
+	if body != "Body text.\n" {
+		t.Errorf("Expected body despite invalid front matter, got %q", body)
+	}
+}
+
+func TestFrontMatterAnnouncement(t *testing.T) {
+	tests := []struct {
+		fm   FrontMatter
+		want string
+	}{
+		{FrontMatter{Title: "Guide", Authors: []string{"Ada"}}, "Guide, by Ada."},
+		{FrontMatter{Title: "Guide?"}, "Guide?"},
+		{FrontMatter{Authors: []string{"Ada", "Grace", "Linus"}}, "By Ada, Grace and Linus."},
+		{FrontMatter{}, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.fm.Announcement(); got != tt.want {
+			t.Errorf("Announcement() for %+v = %q, want %q", tt.fm, got, tt.want)
+		}
+	}
+}
+
+func TestParserReadsFrontMatter(t *testing.T) {
+	config := DefaultParserConfig()
+	config.CodeBlocks = CodeBlockRead
+	text, spoken := parseSpoken(t, config, frontMatterMarkdown)
+
+	// The title is spoken only, front matter is never read and the
+	// document's skip_code wins over the configured strategy
+	wantText := []string{"", "The build takes 5ms"}
+	wantSpoken := []string{"Release Notes, by Ada Lovelace and Grace Hopper.", "The build takes 5 milliseconds"}
+	if !reflect.DeepEqual(text, wantText) {
+		t.Errorf("Expected text %q, got %q", wantText, text)
+	}
+	if !reflect.DeepEqual(spoken, wantSpoken) {
+		t.Errorf("Expected spoken %q, got %q", wantSpoken, spoken)
+	}
+	if config.CodeBlocks != CodeBlockRead {
+		t.Error("Document settings must not change the parser configuration")
+	}
+
+	config.AnnounceFrontMatter = false
+	_, spoken = parseSpoken(t, config, frontMatterMarkdown)
+	if len(spoken) != 1 || spoken[0] != "The build takes 5 milliseconds" {
+		t.Errorf("Expected no announcement, got %q", spoken)
+	}
+
+	// Languages without a normalizer are read as written
+	_, spoken = parseSpoken(t, DefaultParserConfig(), "---\ntts:\n  language: de\n---\nDer Build dauert 5ms.\n")
+	if len(spoken) != 1 || spoken[0] != "Der Build dauert 5ms" {
+		t.Errorf("Expected text without English normalization, got %q", spoken)
+	}
+}
+
+func TestConfigForDocument(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "en_GB-alba-medium.onnx"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := DefaultTTSConfig()
+	cfg.Engines.Piper.VoiceDirs = []string{dir}
+	doc := DocumentSettings{Voice: "en_GB-alba-medium", Speed: 1.5}
+
+	piper := cfg.ForDocument("piper", doc)
+	if want := filepath.Join(dir, "en_GB-alba-medium.onnx"); piper.Engines.Piper.ModelPath != want {
+		t.Errorf("Expected model %s, got %s", want, piper.Engines.Piper.ModelPath)
+	}
+	if piper.Playback.DefaultSpeed != 1.5 {
+		t.Errorf("Expected speed 1.5, got %v", piper.Playback.DefaultSpeed)
+	}
+	if cfg.Engines.Piper.ModelPath != "" || cfg.Playback.DefaultSpeed != 1.0 {
+		t.Error("ForDocument must not modify the global configuration")
+	}
+
+	gtts := cfg.ForDocument("gtts", DocumentSettings{Voice: "en:co.uk"})
+	if gtts.Engines.GTTS.Language != "en" || gtts.Engines.GTTS.TLD != "co.uk" {
+		t.Errorf("Expected gTTS voice en:co.uk, got %s:%s", gtts.Engines.GTTS.Language, gtts.Engines.GTTS.TLD)
+	}
+	espeak := cfg.ForDocument("espeak", DocumentSettings{Language: "fr"})
+	if espeak.Engines.ESpeak.Voice != "fr" {
+		t.Errorf("Expected espeak voice fr, got %s", espeak.Engines.ESpeak.Voice)
+	}
+
+	// VoiceSetting names the voice so ForDocument selects it again
+	for engine, config := range map[string]*TTSConfig{"piper": piper, "gtts": gtts, "espeak": espeak} {
+		voice := config.VoiceSetting(engine)
+		if again := cfg.ForDocument(engine, DocumentSettings{Voice: voice}); again.VoiceSetting(engine) != voice {
+			t.Errorf("Expected %s voice %q to round-trip, got %q", engine, voice, again.VoiceSetting(engine))
+		}
+	}
+
+	if (DocumentSettings{Language: "fr"}).SelectsVoice("piper") {
+		t.Error("Language alone should not select a Piper voice")
+	}
+	if !(DocumentSettings{Language: "fr"}).SelectsVoice("espeak") {
+		t.Error("Language should select an espeak voice")
+	}
+}
@@ -0,0 +1,283 @@
+package tts
+
+import (
+	"errors"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// gaplessStallTimeout ends gapless playback when the next segment has had
+// no audio for this long, e.g. because its synthesis failed
+const gaplessStallTimeout = 30 * time.Second
+
+// GaplessConfig sets how sentences are joined when a document is played as
+// one continuous stream
+type GaplessConfig struct {
+	// CrossfadeMs is how long adjacent sentences overlap
+	CrossfadeMs int
+	// SentencePause is the silence between sentences
+	SentencePause time.Duration
+	// HeadingPause is the silence before and after a heading
+	HeadingPause time.Duration
+	// BlockPause is the silence before and after lists, tables and code
+	BlockPause time.Duration
+}
+
+// DefaultGaplessConfig returns the default gapless playback configuration
+func DefaultGaplessConfig() *GaplessConfig {
+	return &GaplessConfig{
+		CrossfadeMs:   DefaultCrossfadeMs,
+		SentencePause: 200 * time.Millisecond,
+		HeadingPause:  600 * time.Millisecond,
+		BlockPause:    400 * time.Millisecond,
+	}
+}
+
+// PauseBetween returns the silence between a sentence of type prev and one
+// of type next
+func (c *GaplessConfig) PauseBetween(prev, next SentenceType) time.Duration {
+	switch {
+	case prev == SentenceTypeHeader || next == SentenceTypeHeader:
+		return c.HeadingPause
+	case prev != next && (isBlockSentence(prev) || isBlockSentence(next)):
+		return c.BlockPause
+	}
+	return c.SentencePause
+}
+
+// isBlockSentence reports whether sentences of type t come from a block
+// that is set apart from the surrounding text
+func isBlockSentence(t SentenceType) bool {
+	switch t {
+	case SentenceTypeListItem, SentenceTypeTable, SentenceTypeCodeBlock:
+		return true
+	}
+	return false
+}
+
+// GaplessReader plays the segments of a queue as one PCM stream, starting
+// with the current segment. Adjacent segments are crossfaded and separated
+// by the pauses of its configuration. The player reads ahead of what it
+// plays, so the queue is advanced as the player reports each segment heard
+// through Played, not as it is read. Like the streaming reader it never
+// blocks: it reports no data while the next segment is still being
+// synthesized.
+type GaplessReader struct {
+	queue  *TTSAudioQueue
+	config GaplessConfig
+	format PCMFormat
+
+	mu       sync.Mutex
+	segment  string           // ID of the segment being read, "" before the first
+	kind     SentenceType     // type of that segment
+	buf      []byte           // audio ready to be read
+	stream   *PCMStreamReader // a segment still arriving from a streaming engine
+	tail     []byte           // end of the segment, held back to crossfade into the next
+	position int64
+	starts   []gaplessStart // segments read but not yet heard, in order
+	playing  string         // ID of the segment being heard
+	start    int64          // position at which its audio begins
+	played   int64          // bytes the player has played
+	waiting  time.Time      // when the reader started waiting for the next segment
+	done     bool
+}
+
+// gaplessStart is the position at which a segment's audio begins
+type gaplessStart struct {
+	id    string
+	start int64
+}
+
+// NewGaplessReader creates a reader that plays queue from its current
+// segment. A nil config uses the defaults with the queue's crossfade.
+func NewGaplessReader(queue *TTSAudioQueue, config *GaplessConfig) *GaplessReader {
+	if config == nil {
+		config = DefaultGaplessConfig()
+		queue.mu.RLock()
+		config.CrossfadeMs = queue.config.CrossfadeDurationMs
+		queue.mu.RUnlock()
+	}
+	return &GaplessReader{
+		queue:  queue,
+		config: *config,
+		format: DefaultPCMFormat(),
+	}
+}
+
+// Read implements io.Reader. It returns io.EOF after the last segment of
+// the queue, or once the queue has moved to a segment the reader did not
+// play.
+func (r *GaplessReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	n := 0
+	for n < len(p) {
+		if len(r.buf) > 0 {
+			c := copy(p[n:], r.buf)
+			r.buf = r.buf[c:]
+			n += c
+			r.position += int64(c)
+			continue
+		}
+		if r.stream != nil {
+			c, err := r.stream.Read(p[n:])
+			n += c
+			r.position += int64(c)
+			if err != nil {
+				if err != io.EOF {
+					log.Warn("Gapless: streamed segment failed", "error", err)
+				}
+				r.stream = nil
+				continue
+			}
+			if c == 0 {
+				break
+			}
+			continue
+		}
+		if !r.load() {
+			break
+		}
+	}
+
+	if n == 0 && r.done {
+		return 0, io.EOF
+	}
+	return n, nil
+}
+
+// load prepares the audio of the next segment, advancing the queue to it.
+// It reports false when there is nothing more to read yet.
+func (r *GaplessReader) load() bool {
+	if r.done {
+		return false
+	}
+
+	// Navigation moves the queue away from the segment being heard
+	if r.playing != "" && !r.queue.isCurrent(r.playing) {
+		r.buf, r.tail, r.done = nil, nil, true
+		return false
+	}
+
+	segment, end := r.queue.following(r.segment)
+	if end {
+		r.buf, r.tail, r.done = r.tail, nil, true
+		return len(r.buf) > 0
+	}
+
+	var audio []byte
+	var stream *PCMStream
+	if segment != nil {
+		r.queue.mu.RLock()
+		audio, stream = segment.ProcessedAudio, segment.Stream
+		if len(audio) == 0 {
+			audio = segment.Audio
+		}
+		r.queue.mu.RUnlock()
+	}
+	if len(audio) == 0 && (stream == nil || !stream.Ready()) {
+		if r.waiting.IsZero() {
+			r.waiting = time.Now()
+		} else if time.Since(r.waiting) > gaplessStallTimeout {
+			log.Warn("Gapless: next segment never became ready, stopping")
+			r.buf, r.tail, r.done = r.tail, nil, true
+			return len(r.buf) > 0
+		}
+		return false
+	}
+	r.waiting = time.Time{}
+
+	var gap []byte
+	if r.segment != "" {
+		gap = GenerateSilence(r.config.PauseBetween(r.kind, segment.Type).Seconds(), r.format)
+	}
+	r.segment, r.kind = segment.ID, segment.Type
+
+	crossfade := r.config.CrossfadeMs
+	if len(audio) == 0 {
+		// Still being synthesized: fade out into the pause and play the
+		// stream as it arrives
+		r.buf = CrossfadeAudio(r.tail, gap, crossfade, r.format.SampleRate)
+		r.tail = nil
+		r.starts = append(r.starts, gaplessStart{segment.ID, r.position + int64(len(r.buf))})
+		r.stream = stream.newPlaybackReader()
+		r.advance()
+		return true
+	}
+
+	joined := CrossfadeAudio(CrossfadeAudio(r.tail, gap, crossfade, r.format.SampleRate), audio, crossfade, r.format.SampleRate)
+	r.starts = append(r.starts, gaplessStart{segment.ID, r.position + int64(len(joined)-len(audio))})
+	hold := crossfade * r.format.SampleRate / 1000 * r.format.BytesPerSample()
+	if hold > 0 && len(joined) > hold {
+		r.buf, r.tail = joined[:len(joined)-hold], joined[len(joined)-hold:]
+	} else {
+		r.buf, r.tail = joined, nil
+	}
+	r.advance()
+	return true
+}
+
+// Played tells the reader how many of its bytes the player has played
+func (r *GaplessReader) Played(position int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch {
+	case position < r.played:
+		position = r.played
+	case position > r.position:
+		position = r.position
+	}
+	r.played = position
+	r.advance()
+}
+
+// advance makes each segment the player has reached the current one
+func (r *GaplessReader) advance() {
+	for len(r.starts) > 0 && r.starts[0].start <= r.played {
+		// The first segment is already current; later ones become current
+		// as they are heard, unless navigation got there first
+		if r.playing != "" && !r.queue.advanceFrom(r.playing) {
+			r.buf, r.tail, r.stream, r.starts, r.done = nil, nil, nil, nil, true
+			return
+		}
+		r.playing, r.start = r.starts[0].id, r.starts[0].start
+		r.starts = r.starts[1:]
+	}
+}
+
+// Seek reports the read position. The stream cannot be rewound, so other
+// positions are an error.
+func (r *GaplessReader) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if (whence == io.SeekCurrent && offset == 0) || (whence == io.SeekStart && offset == r.position) {
+		return r.position, nil
+	}
+	return r.position, errors.New("gapless playback cannot seek")
+}
+
+// SegmentPosition returns the segment being played and how far into its
+// audio the player is; the offset is negative during the pause before it
+func (r *GaplessReader) SegmentPosition() (string, time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	offset := r.played - r.start
+	if offset < 0 {
+		return r.playing, -pcmDuration(int(-offset), r.format)
+	}
+	return r.playing, pcmDuration(int(offset), r.format)
+}
+
+// GetPosition returns the number of bytes read
+func (r *GaplessReader) GetPosition() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.position
+}
@@ -0,0 +1,233 @@
+package tts
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+)
+
+// newGaplessTestQueue queues a heading, a paragraph and a list item whose
+// audio is 100ms of a constant tone, and waits until all are synthesized
+func newGaplessTestQueue(t *testing.T) (*TTSAudioQueue, int) {
+	t.Helper()
+
+	format := DefaultPCMFormat()
+	tone := make([]byte, 2205*format.BytesPerSample())
+	for i := 0; i < len(tone); i += 2 {
+		binary.LittleEndian.PutUint16(tone[i:], uint16(16000))
+	}
+	engine := &mockQueueEngine{
+		available: true,
+		synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+			return append([]byte(nil), tone...), nil
+		},
+	}
+	parser := &mockQueueParser{
+		parseFunc: func(text string) ([]Sentence, error) {
+			return []Sentence{
+				{Text: "Install", Position: 0, Type: SentenceTypeHeader},
+				{Text: "Run the installer.", Position: 1, Type: SentenceTypeParagraph},
+				{Text: "Linux", Position: 2, Type: SentenceTypeListItem},
+			}, nil
+		},
+	}
+
+	queue, err := NewAudioQueue(createTestQueueConfig(engine, parser))
+	if err != nil {
+		t.Fatalf("Failed to create queue: %v", err)
+	}
+	t.Cleanup(queue.Stop)
+
+	if err := queue.AddText("document"); err != nil {
+		t.Fatalf("Failed to add text: %v", err)
+	}
+	queue.Preload()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		queue.mu.RLock()
+		ready := len(queue.order) == 3 && len(queue.textQueue) == 0
+		for _, id := range queue.order {
+			ready = ready && len(queue.segments[id].ProcessedAudio) > 0
+		}
+		queue.mu.RUnlock()
+		if ready {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for synthesis")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return queue, len(tone)
+}
+
+// readAll reads r until EOF, retrying while it has no data
+func readAll(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+
+	var out []byte
+	buf := make([]byte, 4096)
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		n, err := r.Read(buf)
+		out = append(out, buf[:n]...)
+		if err == io.EOF {
+			return out
+		}
+		if err != nil {
+			t.Fatalf("Read failed: %v", err)
+		}
+		if n == 0 {
+			time.Sleep(time.Millisecond)
+		}
+	}
+	t.Fatal("Timed out reading gapless audio")
+	return nil
+}
+
+func TestGaplessReader(t *testing.T) {
+	format := DefaultPCMFormat()
+	config := &GaplessConfig{
+		SentencePause: 100 * time.Millisecond,
+		HeadingPause:  300 * time.Millisecond,
+		BlockPause:    200 * time.Millisecond,
+	}
+	silence := func(d time.Duration) int { return len(GenerateSilence(d.Seconds(), format)) }
+
+	t.Run("structural pauses", func(t *testing.T) {
+		queue, segment := newGaplessTestQueue(t)
+
+		reader := NewGaplessReader(queue, config)
+		audio := readAll(t, reader)
+
+		// Heading, pause after the heading, paragraph, pause before the list, item
+		want := 3*segment + silence(config.HeadingPause) + silence(config.BlockPause)
+		if len(audio) != want {
+			t.Errorf("Expected %d bytes, got %d", want, len(audio))
+		}
+		reader.Played(int64(len(audio)))
+		if got := queue.CurrentPosition(); got != 2 {
+			t.Errorf("Expected the queue to follow playback to the last segment, got %d", got)
+		}
+	})
+
+	t.Run("advances as segments are heard", func(t *testing.T) {
+		queue, segment := newGaplessTestQueue(t)
+
+		reader := NewGaplessReader(queue, config)
+		readAll(t, reader)
+		if got := queue.CurrentPosition(); got != 0 {
+			t.Errorf("Expected reading ahead not to advance the queue, got %d", got)
+		}
+
+		// The paragraph follows the 100ms heading and its pause
+		paragraph := int64(segment + silence(config.HeadingPause))
+		reader.Played(paragraph - int64(silence(100*time.Millisecond)))
+		if _, offset := reader.SegmentPosition(); queue.CurrentPosition() != 0 || offset != 300*time.Millisecond {
+			t.Errorf("Expected 300ms into the heading, got %v", offset)
+		}
+		reader.Played(paragraph + int64(silence(100*time.Millisecond)))
+		if got := queue.CurrentPosition(); got != 1 {
+			t.Errorf("Expected the queue at the paragraph once it is heard, got %d", got)
+		}
+		if _, offset := reader.SegmentPosition(); offset != 100*time.Millisecond {
+			t.Errorf("Expected 100ms into the paragraph, got %v", offset)
+		}
+		reader.Played(0)
+		if _, offset := reader.SegmentPosition(); offset != 100*time.Millisecond {
+			t.Errorf("Expected the played position never to go back, got %v", offset)
+		}
+	})
+
+	t.Run("crossfade", func(t *testing.T) {
+		queue, segment := newGaplessTestQueue(t)
+
+		faded := *config
+		faded.CrossfadeMs = 10
+		reader := NewGaplessReader(queue, &faded)
+		audio := readAll(t, reader)
+
+		// Each of the four joins between audio and silence overlaps
+		overlap := faded.CrossfadeMs * format.SampleRate / 1000 * format.BytesPerSample()
+		want := 3*segment + silence(config.HeadingPause) + silence(config.BlockPause) - 4*overlap
+		if len(audio) != want {
+			t.Errorf("Expected %d bytes, got %d", want, len(audio))
+		}
+		if reader.GetPosition() != int64(len(audio)) {
+			t.Errorf("Expected position %d, got %d", len(audio), reader.GetPosition())
+		}
+	})
+
+	t.Run("stops when navigated away", func(t *testing.T) {
+		queue, segment := newGaplessTestQueue(t)
+
+		reader := NewGaplessReader(queue, config)
+		buf := make([]byte, segment)
+		if n, err := io.ReadFull(reader, buf); err != nil || n != segment {
+			t.Fatalf("Expected the first segment, got %d bytes: %v", n, err)
+		}
+		reader.Played(int64(segment))
+		if id, offset := reader.SegmentPosition(); id == "" || offset != 100*time.Millisecond {
+			t.Errorf("Expected to be 100ms into the first segment, got %q at %v", id, offset)
+		}
+		if _, err := queue.Next(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := reader.Read(buf); err != io.EOF {
+			t.Errorf("Expected EOF once the queue moved on, got %v", err)
+		}
+		if got := queue.CurrentPosition(); got != 1 {
+			t.Errorf("Expected the reader not to advance the queue, got %d", got)
+		}
+	})
+}
+
+func TestGaplessPauseBetween(t *testing.T) {
+	config := DefaultGaplessConfig()
+
+	tests := []struct {
+		prev, next SentenceType
+		want       time.Duration
+	}{
+		{SentenceTypeParagraph, SentenceTypeParagraph, config.SentencePause},
+		{SentenceTypeHeader, SentenceTypeParagraph, config.HeadingPause},
+		{SentenceTypeParagraph, SentenceTypeListItem, config.BlockPause},
+		{SentenceTypeListItem, SentenceTypeListItem, config.SentencePause},
+		{SentenceTypeCodeBlock, SentenceTypeParagraph, config.BlockPause},
+	}
+	for _, tt := range tests {
+		if got := config.PauseBetween(tt.prev, tt.next); got != tt.want {
+			t.Errorf("PauseBetween(%v, %v) = %v, want %v", tt.prev, tt.next, got, tt.want)
+		}
+	}
+}
+
+func TestParserListItems(t *testing.T) {
+	parser, err := NewSentenceParser(nil)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	sentences, err := parser.ParseSentences("Supported systems:\n\n- Linux\n- macOS\n  and BSD\n\n---\n\nThat is all.")
+	if err != nil {
+		t.Fatalf("ParseSentences failed: %v", err)
+	}
+
+	var texts []string
+	for _, s := range sentences {
+		texts = append(texts, s.Text)
+	}
+	if len(sentences) != 4 {
+		t.Fatalf("Expected each list item on its own, got %q", texts)
+	}
+	if sentences[1].Type != SentenceTypeListItem || sentences[2].Type != SentenceTypeListItem {
+		t.Errorf("Expected list item sentences, got %+v", sentences[1:3])
+	}
+	if sentences[2].Text != "macOS and BSD" {
+		t.Errorf("Expected the continuation line in its item, got %q", sentences[2].Text)
+	}
+	if sentences[3].Type == SentenceTypeListItem {
+		t.Error("Expected the text after the rule not to be a list item")
+	}
+}
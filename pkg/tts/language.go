@@ -0,0 +1,264 @@
+package tts
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Common function words per language. They make up a large share of any
+// running text, so counting them identifies the language of a sentence
+// without a statistical model.
+var languageStopwords = map[string][]string{
+	"en": {"the", "and", "is", "are", "of", "to", "in", "that", "it", "with", "for", "this", "was", "you", "not", "be", "on", "have", "from", "or", "which", "we", "they", "will", "can", "an", "by", "at"},
+	"de": {"der", "die", "das", "und", "ist", "nicht", "ein", "eine", "mit", "sich", "auf", "für", "dem", "den", "des", "von", "zu", "auch", "wir", "ich", "sie", "es", "im", "wird", "sind", "oder", "aber", "wenn", "kann", "noch", "nach", "bei"},
+	"es": {"el", "la", "los", "las", "y", "es", "de", "que", "en", "un", "una", "por", "con", "para", "no", "se", "del", "al", "lo", "como", "más", "pero", "su", "sus", "está", "son", "muy", "también", "hay", "puede"},
+	"fr": {"le", "la", "les", "et", "est", "de", "des", "un", "une", "que", "qui", "dans", "pour", "pas", "sur", "avec", "ce", "il", "elle", "nous", "vous", "sont", "du", "au", "aux", "mais", "ou", "être", "plus", "peut"},
+	"it": {"il", "lo", "la", "gli", "le", "e", "è", "di", "che", "un", "una", "per", "con", "non", "del", "della", "sono", "nel", "nella", "anche", "come", "ma", "più", "questo", "essere", "può"},
+	"pt": {"o", "os", "as", "e", "é", "de", "que", "em", "um", "uma", "para", "com", "não", "do", "da", "dos", "das", "no", "na", "se", "por", "mais", "mas", "como", "são", "também", "pode"},
+	"nl": {"de", "het", "een", "en", "is", "van", "dat", "niet", "met", "voor", "op", "zijn", "te", "ook", "maar", "als", "er", "bij", "wordt", "kan", "nog", "naar", "dit", "wij", "ze"},
+}
+
+// Letters that only occur in some of the languages, worth extra weight
+var languageLetters = map[string]string{
+	"de": "äöüß",
+	"es": "ñ¿¡",
+	"fr": "çœèêà",
+	"pt": "ãõç",
+}
+
+var stopwordSets = func() map[string]map[string]bool {
+	sets := make(map[string]map[string]bool, len(languageStopwords))
+	for lang, words := range languageStopwords {
+		set := make(map[string]bool, len(words))
+		for _, w := range words {
+			set[w] = true
+		}
+		sets[lang] = set
+	}
+	return sets
+}()
+
+// DetectableLanguages lists the languages DetectLanguage can recognize
+func DetectableLanguages() []string {
+	langs := make([]string, 0, len(languageStopwords))
+	for lang := range languageStopwords {
+		langs = append(langs, lang)
+	}
+	sort.Strings(langs)
+	return langs
+}
+
+// DetectLanguage returns the language code of text, choosing among
+// candidates (all detectable languages when empty). It returns "" when
+// the text is too short or too ambiguous to tell.
+func DetectLanguage(text string, candidates ...string) string {
+	if len(candidates) == 0 {
+		candidates = DetectableLanguages()
+	}
+
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	if len(words) < 2 {
+		return ""
+	}
+
+	best, bestScore, runnerUp := "", 0, 0
+	for _, candidate := range candidates {
+		lang := baseLanguage(candidate)
+		set, ok := stopwordSets[lang]
+		if !ok {
+			continue
+		}
+		score := 0
+		for _, w := range words {
+			if set[w] {
+				score += 2
+			}
+		}
+		if letters := languageLetters[lang]; letters != "" && strings.ContainsAny(strings.ToLower(text), letters) {
+			score++
+		}
+		switch {
+		case score > bestScore:
+			best, bestScore, runnerUp = lang, score, bestScore
+		case score > runnerUp:
+			runnerUp = score
+		}
+	}
+
+	// Require a couple of function words and a clear winner
+	if bestScore < 3 || bestScore == runnerUp {
+		return ""
+	}
+	return best
+}
+
+// baseLanguage returns the language part of a locale ("pt-BR" -> "pt")
+func baseLanguage(locale string) string {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	base, _, _ := strings.Cut(locale, "-")
+	return base
+}
+
+// LanguageEngine looks up the engine for language in engines, trying the
+// full locale before its base language. It returns nil when none matches.
+func LanguageEngine(engines map[string]TTSEngine, language string) TTSEngine {
+	if language == "" || len(engines) == 0 {
+		return nil
+	}
+	if engine, ok := engines[strings.ToLower(language)]; ok {
+		return engine
+	}
+	return engines[baseLanguage(language)]
+}
+
+// languageSpan is a stretch of markdown with an explicit language, or ""
+// where the language is unknown
+type languageSpan struct {
+	text     string
+	language string
+}
+
+var langElementPattern = regexp.MustCompile(`<([A-Za-z][A-Za-z0-9]*)\b[^>]*?\slang\s*=\s*["']?([A-Za-z]{2,3}(?:[-_][A-Za-z0-9]+)*)["']?[^>]*>`)
+
+// codeSpanPattern matches inline code, whose markup is an example and not
+// a language hint
+var codeSpanPattern = regexp.MustCompile("(?s)``.+?``|`[^`]+`")
+
+// splitLanguageSpans cuts markdown at HTML elements carrying a lang
+// attribute, such as <span lang="de">...</span> or <div lang="es">, so
+// their contents can be read in that language. The tags are dropped.
+func splitLanguageSpans(markdown string) []languageSpan {
+	return new(languageSplitter).split(markdown)
+}
+
+// languageSplitter splits the blocks of a document into language spans.
+// An element left open at the end of a block carries its language into
+// the following blocks, so a <div lang> can wrap several paragraphs.
+type languageSplitter struct {
+	// tag and language describe the open element, if any
+	tag      string
+	language string
+}
+
+// split cuts text at elements carrying a lang attribute, ignoring tags
+// inside code spans
+func (s *languageSplitter) split(text string) []languageSpan {
+	var spans []languageSpan
+	for {
+		if s.tag != "" {
+			end, closeLen := findClosingTag(text, s.tag)
+			spans = append(spans, languageSpan{text: text[:end], language: s.language})
+			if closeLen == 0 {
+				return spans
+			}
+			text = text[end+closeLen:]
+			s.tag, s.language = "", ""
+			continue
+		}
+
+		loc := findLangElement(text)
+		if loc == nil {
+			break
+		}
+		if before := text[:loc[0]]; strings.TrimSpace(before) != "" {
+			spans = append(spans, languageSpan{text: before})
+		}
+		s.tag, s.language = text[loc[2]:loc[3]], text[loc[4]:loc[5]]
+		text = text[loc[1]:]
+	}
+	if strings.TrimSpace(text) != "" || len(spans) == 0 {
+		spans = append(spans, languageSpan{text: text})
+	}
+	return spans
+}
+
+// findLangElement returns the submatch indexes of the first element with a
+// lang attribute outside code spans, or nil
+func findLangElement(html string) []int {
+	code := codeSpanPattern.FindAllStringIndex(html, -1)
+	for _, loc := range langElementPattern.FindAllStringSubmatchIndex(html, -1) {
+		if !inRanges(code, loc[0]) {
+			return loc
+		}
+	}
+	return nil
+}
+
+// findClosingTag returns the offset and length of the tag closing an
+// element whose contents start html, allowing nested elements of the same
+// name. Tags in code spans are ignored. An unclosed element runs to the end.
+func findClosingTag(html, tag string) (int, int) {
+	open := regexp.MustCompile(`(?i)<` + regexp.QuoteMeta(tag) + `\b[^>]*>|</` + regexp.QuoteMeta(tag) + `\s*>`)
+	code := codeSpanPattern.FindAllStringIndex(html, -1)
+	depth := 0
+	for _, m := range open.FindAllStringIndex(html, -1) {
+		if inRanges(code, m[0]) {
+			continue
+		}
+		if !strings.HasPrefix(html[m[0]:], "</") {
+			depth++
+			continue
+		}
+		if depth == 0 {
+			return m[0], m[1] - m[0]
+		}
+		depth--
+	}
+	return len(html), 0
+}
+
+// inRanges reports whether offset falls inside one of ranges
+func inRanges(ranges [][]int, offset int) bool {
+	for _, r := range ranges {
+		if offset >= r[0] && offset < r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// tagLanguages sets the language of sentences parsed from one span. An
+// explicit language applies to all of them; otherwise each sentence is
+// detected, and sentences too short to tell keep the language of the
+// passage they belong to. Headings belong to the passage they introduce.
+func (p *SentenceParser) tagLanguages(sentences []ParsedSentence, language string) {
+	if language == "" {
+		language = p.language
+	}
+	if language != "" || !p.config.DetectLanguage {
+		for i := range sentences {
+			sentences[i].Language = language
+		}
+		return
+	}
+
+	detected := make([]string, len(sentences))
+	for i, sentence := range sentences {
+		text := sentence.Text
+		if text == "" {
+			text = sentence.Spoken
+		}
+		detected[i] = DetectLanguage(text, p.config.Languages...)
+	}
+
+	current := ""
+	for i := range sentences {
+		lang := detected[i]
+		if lang == "" && sentences[i].Type == SentenceTypeHeader {
+			for _, next := range detected[i+1:] {
+				if next != "" {
+					lang = next
+					break
+				}
+			}
+		}
+		if lang != "" {
+			current = lang
+		}
+		sentences[i].Language = current
+	}
+}
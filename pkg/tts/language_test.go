@@ -0,0 +1,150 @@
+package tts
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		text       string
+		candidates []string
+		want       string
+	}{
+		{"The server is started with the default settings.", nil, "en"},
+		{"Der Server wird mit den Standardeinstellungen gestartet und ist sofort bereit.", nil, "de"},
+		{"El servidor se inicia con la configuración por defecto.", nil, "es"},
+		{"Le serveur est lancé avec les réglages par défaut.", nil, "fr"},
+		{"Installation", nil, ""},
+		{"Kubernetes Helm Terraform", nil, ""},
+		// Candidates restrict the choice
+		{"Der Server ist bereit und wird gestartet.", []string{"en", "es"}, ""},
+		{"El servidor se inicia con la configuración.", []string{"en", "es-MX"}, "es"},
+	}
+	for _, tt := range tests {
+		if got := DetectLanguage(tt.text, tt.candidates...); got != tt.want {
+			t.Errorf("DetectLanguage(%q, %v) = %q, want %q", tt.text, tt.candidates, got, tt.want)
+		}
+	}
+}
+
+func TestSplitLanguageSpans(t *testing.T) {
+	markdown := "Intro text.\n\n<div lang=\"de\">\nDeutscher Text mit <div>innerem</div> Block.\n</div>\n\nSay <span lang='es-MX'>hola</span> now."
+	want := []languageSpan{
+		{text: "Intro text.\n\n"},
+		{text: "\nDeutscher Text mit <div>innerem</div> Block.\n", language: "de"},
+		{text: "\n\nSay "},
+		{text: "hola", language: "es-MX"},
+		{text: " now."},
+	}
+	if got := splitLanguageSpans(markdown); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+
+	if got := splitLanguageSpans("No hints here."); len(got) != 1 || got[0].language != "" {
+		t.Errorf("Expected a single unmarked span, got %+v", got)
+	}
+}
+
+func TestLanguageHintsInCode(t *testing.T) {
+	markdown := "# Intro\n\nSet the language like this:\n\n```html\n<p lang=\"fr\">Bonjour</p>\n```\n\n" +
+		"This paragraph is after the code block.\n\nAnother normal paragraph here.\n\n" +
+		"Write `<span lang=\"de\">Hallo</span>` for German."
+	languages, spoken := parseLanguages(t, DefaultParserConfig(), markdown)
+
+	joined := strings.Join(spoken, " | ")
+	for _, want := range []string{"This paragraph is after the code block", "Another normal paragraph here", "for German"} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("Expected %q to be read, got %q", want, spoken)
+		}
+	}
+	for i, language := range languages {
+		if language != "" {
+			t.Errorf("Expected markup in code to be ignored, sentence %q tagged %q", spoken[i], language)
+		}
+	}
+}
+
+func TestLanguageHintAcrossBlocks(t *testing.T) {
+	markdown := "Before.\n\n<div lang=\"es\">\n\nPrimer párrafo.\n\n- Un punto\n\nSegundo párrafo.\n\n</div>\n\nAfter."
+	languages, spoken := parseLanguages(t, DefaultParserConfig(), markdown)
+	if want := []string{"", "es", "es", "es", ""}; !reflect.DeepEqual(languages, want) {
+		t.Errorf("Expected languages %q, got %q (%q)", want, languages, spoken)
+	}
+}
+
+const mixedLanguageMarkdown = `# Setup
+
+The installer needs 2GB of disk space and it runs on Linux.
+
+## Einleitung
+
+Der Installer braucht 2GB Speicher und er läuft auf Linux.
+
+Kurz.
+
+<p lang="es">Gracias.</p>
+`
+
+func parseLanguages(t *testing.T, config *ParserConfig, markdown string) ([]string, []string) {
+	t.Helper()
+	parser, err := NewSentenceParser(config)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	sentences, err := parser.Parse(markdown)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	var languages, spoken []string
+	for _, s := range sentences {
+		languages = append(languages, s.Language)
+		spoken = append(spoken, s.SpokenText())
+	}
+	return languages, spoken
+}
+
+func TestParserTagsLanguages(t *testing.T) {
+	config := DefaultParserConfig()
+	config.DetectLanguage = true
+	languages, spoken := parseLanguages(t, config, mixedLanguageMarkdown)
+
+	// Short sentences keep the language of their passage, headings take
+	// the language of the passage they introduce and hints win
+	wantLanguages := []string{"en", "en", "de", "de", "de", "es"}
+	if !reflect.DeepEqual(languages, wantLanguages) {
+		t.Errorf("Expected languages %q, got %q (%q)", wantLanguages, languages, spoken)
+	}
+	// English rules only apply to English sentences
+	if len(spoken) > 3 && (!strings.Contains(spoken[1], "2 gigabytes") || !strings.Contains(spoken[3], "2GB")) {
+		t.Errorf("Expected English normalization only for English text, got %q", spoken)
+	}
+
+	// Without detection only hints are tagged
+	languages, _ = parseLanguages(t, DefaultParserConfig(), mixedLanguageMarkdown)
+	if want := []string{"", "", "", "", "", "es"}; !reflect.DeepEqual(languages, want) {
+		t.Errorf("Expected languages %q, got %q", want, languages)
+	}
+
+	// Front matter sets the document language
+	languages, _ = parseLanguages(t, DefaultParserConfig(), "---\nlang: de\n---\nDer Text ist kurz.\n")
+	if want := []string{"de"}; !reflect.DeepEqual(languages, want) {
+		t.Errorf("Expected languages %q, got %q", want, languages)
+	}
+}
+
+func TestLanguageEngine(t *testing.T) {
+	german := &mockQueueEngine{name: "german"}
+	engines := map[string]TTSEngine{"de": german, "pt-br": &mockQueueEngine{name: "brazilian"}}
+
+	if LanguageEngine(engines, "de-AT") != german {
+		t.Error("Expected locale to fall back to its base language")
+	}
+	if got := LanguageEngine(engines, "pt-BR"); got == nil || got.GetName() != "brazilian" {
+		t.Errorf("Expected exact locale match, got %v", got)
+	}
+	if LanguageEngine(engines, "fr") != nil || LanguageEngine(engines, "") != nil {
+		t.Error("Expected no engine for unmapped languages")
+	}
+}
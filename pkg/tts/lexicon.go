@@ -0,0 +1,209 @@
+package tts
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"unicode"
+	"unicode/utf8"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectLexiconFile is the lexicon looked for next to a document
+const ProjectLexiconFile = ".glow-lexicon.yml"
+
+// LexiconRule rewrites a term into the form the engine should say
+type LexiconRule struct {
+	// Match is the literal term, or a regular expression when Regex is set
+	Match string `yaml:"match"`
+	// Say is the spoken replacement; regex rules may use $1 for groups
+	Say string `yaml:"say"`
+	// Regex treats Match as a Go regular expression
+	Regex bool `yaml:"regex"`
+	// CaseSensitive disables case-insensitive matching
+	CaseSensitive bool `yaml:"case_sensitive"`
+}
+
+// lexiconFile is the on-disk format: a terms shorthand for literal,
+// case-insensitive rules plus a list of rules with options
+type lexiconFile struct {
+	Terms map[string]string `yaml:"terms"`
+	Rules []LexiconRule     `yaml:"rules"`
+}
+
+// lexiconEntry is a compiled rule
+type lexiconEntry struct {
+	pattern *regexp.Regexp
+	say     string
+	literal bool
+}
+
+// Lexicon applies pronunciation rules to text before synthesis. A nil
+// Lexicon leaves text unchanged.
+type Lexicon struct {
+	entries []lexiconEntry
+}
+
+// NewLexicon compiles rules, which are applied in order
+func NewLexicon(rules []LexiconRule) (*Lexicon, error) {
+	l := &Lexicon{}
+	for i, rule := range rules {
+		if rule.Match == "" {
+			return nil, fmt.Errorf("lexicon rule %d has no match", i+1)
+		}
+
+		expr := rule.Match
+		if !rule.Regex {
+			expr = literalPattern(rule.Match)
+		}
+		if !rule.CaseSensitive {
+			expr = "(?i)" + expr
+		}
+
+		pattern, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("lexicon rule %q: %w", rule.Match, err)
+		}
+		l.entries = append(l.entries, lexiconEntry{
+			pattern: pattern,
+			say:     rule.Say,
+			literal: !rule.Regex,
+		})
+	}
+	return l, nil
+}
+
+// literalPattern matches term as a whole word. Word boundaries are only
+// required on sides that start or end with a letter or digit, so terms
+// like "C++" and ".NET" still match.
+func literalPattern(term string) string {
+	expr := regexp.QuoteMeta(term)
+	if first, _ := utf8.DecodeRuneInString(term); isWordRune(first) {
+		expr = `\b` + expr
+	}
+	if last, _ := utf8.DecodeLastRuneInString(term); isWordRune(last) {
+		expr += `\b`
+	}
+	return expr
+}
+
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// LoadLexicon reads a lexicon file. A missing file yields an empty lexicon.
+func LoadLexicon(path string) (*Lexicon, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Lexicon{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lexicon: %w", err)
+	}
+
+	var file lexiconFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse lexicon %s: %w", path, err)
+	}
+
+	// Longer terms first so "PostgreSQL" wins over "SQL"
+	terms := make([]string, 0, len(file.Terms))
+	for term := range file.Terms {
+		terms = append(terms, term)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if len(terms[i]) != len(terms[j]) {
+			return len(terms[i]) > len(terms[j])
+		}
+		return terms[i] < terms[j]
+	})
+
+	rules := make([]LexiconRule, 0, len(terms)+len(file.Rules))
+	for _, term := range terms {
+		rules = append(rules, LexiconRule{Match: term, Say: file.Terms[term]})
+	}
+	rules = append(rules, file.Rules...)
+
+	lexicon, err := NewLexicon(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lexicon %s: %w", path, err)
+	}
+	return lexicon, nil
+}
+
+// DefaultLexiconPath returns the user lexicon (~/.config/glow/tts/lexicon.yml)
+func DefaultLexiconPath() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".config", "glow", "tts", "lexicon.yml")
+}
+
+// LoadLexicons loads the user lexicon and the project lexicon next to
+// documentPath (if any). Project rules run first, so they override user
+// rules for the same term.
+func LoadLexicons(userPath, documentPath string) (*Lexicon, error) {
+	if userPath == "" {
+		userPath = DefaultLexiconPath()
+	}
+	user, err := LoadLexicon(userPath)
+	if err != nil {
+		return nil, err
+	}
+	if documentPath == "" {
+		return user, nil
+	}
+
+	project, err := LoadLexicon(filepath.Join(filepath.Dir(documentPath), ProjectLexiconFile))
+	if err != nil {
+		return nil, err
+	}
+	return project.Merge(user), nil
+}
+
+// Merge returns a lexicon applying l's rules, then other's. Rules in other
+// with the same pattern as one in l are dropped so they cannot rewrite l's
+// replacement a second time.
+func (l *Lexicon) Merge(other *Lexicon) *Lexicon {
+	merged := &Lexicon{}
+	seen := make(map[string]bool)
+	for _, lex := range []*Lexicon{l, other} {
+		if lex == nil {
+			continue
+		}
+		for _, entry := range lex.entries {
+			key := entry.pattern.String()
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged.entries = append(merged.entries, entry)
+		}
+	}
+	return merged
+}
+
+// Len returns the number of rules
+func (l *Lexicon) Len() int {
+	if l == nil {
+		return 0
+	}
+	return len(l.entries)
+}
+
+// Apply rewrites text with every rule in order
+func (l *Lexicon) Apply(text string) string {
+	if l == nil {
+		return text
+	}
+	for _, entry := range l.entries {
+		if entry.literal {
+			text = entry.pattern.ReplaceAllLiteralString(text, entry.say)
+		} else {
+			text = entry.pattern.ReplaceAllString(text, entry.say)
+		}
+	}
+	return text
+}
@@ -0,0 +1,106 @@
+package tts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLexiconApply(t *testing.T) {
+	lexicon, err := NewLexicon([]LexiconRule{
+		{Match: "nginx", Say: "engine x"},
+		{Match: "C++", Say: "C plus plus"},
+		{Match: "GiB", Say: "gibibytes", CaseSensitive: true},
+		{Match: `v(\d+)`, Say: "version $1", Regex: true},
+	})
+	if err != nil {
+		t.Fatalf("NewLexicon failed: %v", err)
+	}
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"Restart Nginx now", "Restart engine x now"},
+		{"nginxconf stays", "nginxconf stays"},
+		{"Written in C++.", "Written in C plus plus."},
+		{"4 GiB, not 4 gib", "4 gibibytes, not 4 gib"},
+		{"Upgrade to v2", "Upgrade to version 2"},
+	}
+	for _, tt := range tests {
+		if got := lexicon.Apply(tt.input); got != tt.want {
+			t.Errorf("Apply(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+
+	var nilLexicon *Lexicon
+	if got := nilLexicon.Apply("kubectl"); got != "kubectl" {
+		t.Errorf("Expected nil lexicon to leave text unchanged, got %q", got)
+	}
+}
+
+func TestLexiconInvalidRule(t *testing.T) {
+	if _, err := NewLexicon([]LexiconRule{{Match: "(", Regex: true}}); err == nil {
+		t.Error("Expected error for invalid regular expression")
+	}
+	if _, err := NewLexicon([]LexiconRule{{Say: "nothing"}}); err == nil {
+		t.Error("Expected error for rule without match")
+	}
+}
+
+func TestLoadLexicons(t *testing.T) {
+	dir := t.TempDir()
+	userPath := filepath.Join(dir, "lexicon.yml")
+	writeFile := func(path, content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile(userPath, `terms:
+  SQL: sequel
+  PostgreSQL: postgres Q L
+  gRPC: gee R P C
+`)
+	docDir := filepath.Join(dir, "docs")
+	if err := os.Mkdir(docDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(filepath.Join(docDir, ProjectLexiconFile), `terms:
+  gRPC: grpc
+rules:
+  - match: 'RFC (\d+)'
+    say: 'R F C $1'
+    regex: true
+`)
+
+	user, err := LoadLexicons(userPath, "")
+	if err != nil {
+		t.Fatalf("LoadLexicons failed: %v", err)
+	}
+	if got := user.Apply("PostgreSQL and SQL over gRPC"); got != "postgres Q L and sequel over gee R P C" {
+		t.Errorf("Unexpected user lexicon output: %q", got)
+	}
+
+	merged, err := LoadLexicons(userPath, filepath.Join(docDir, "README.md"))
+	if err != nil {
+		t.Fatalf("LoadLexicons failed: %v", err)
+	}
+	if got := merged.Apply("gRPC per RFC 9113"); got != "grpc per R F C 9113" {
+		t.Errorf("Expected project lexicon to take precedence, got %q", got)
+	}
+
+	missing, err := LoadLexicons(filepath.Join(dir, "missing.yml"), filepath.Join(dir, "none", "doc.md"))
+	if err != nil {
+		t.Fatalf("Expected missing lexicons to be ignored, got %v", err)
+	}
+	if missing.Len() != 0 {
+		t.Errorf("Expected empty lexicon, got %d rules", missing.Len())
+	}
+
+	writeFile(userPath, "terms: [")
+	if _, err := LoadLexicons(userPath, ""); err == nil {
+		t.Error("Expected error for malformed lexicon")
+	}
+}
@@ -178,7 +178,10 @@ func (el *EngineLifecycle) Shutdown(ctx context.Context) error {
 
 // ForceStop performs immediate engine termination
 func (el *EngineLifecycle) ForceStop() error {
-	// Most engines don't need force stop, but we could kill subprocesses here
+	// Engines with long-lived workers expose them for termination
+	if supervised, ok := el.engine.(interface{ Subprocesses() *SubprocessLifecycle }); ok {
+		return supervised.Subprocesses().ForceStop()
+	}
 	return nil
 }
 
@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/text"
 )
@@ -24,6 +27,13 @@ func NewMarkdownProcessor(config *ParserConfig) *MarkdownProcessor {
 	}
 
 	md := goldmark.New(
+		goldmark.WithExtensions(
+			extension.Table,
+			extension.Strikethrough,
+			extension.TaskList,
+			extension.DefinitionList,
+			extension.Footnote,
+		),
 		goldmark.WithParserOptions(
 			parser.WithAutoHeadingID(),
 		),
@@ -41,6 +51,13 @@ func (mp *MarkdownProcessor) ProcessMarkdown(source string) ([]MarkdownElement,
 	doc := mp.parser.Parser().Parse(reader)
 	
 	elements := []MarkdownElement{}
+	footnotes := mp.collectFootnotes(doc, source)
+	placement := mp.config.Footnotes
+	if placement == "" {
+		placement = FootnotesParagraph
+	}
+	var pending []MarkdownElement
+	read := make(map[int]bool)
 	
 	// Walk the AST and extract elements
 	err := ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
@@ -50,7 +67,29 @@ func (mp *MarkdownProcessor) ProcessMarkdown(source string) ([]MarkdownElement,
 		
 		element := mp.processNode(n, source)
 		if element != nil {
+			if element.Type == ElementHeading {
+				elements = append(elements, pending...)
+				pending = nil
+			}
 			elements = append(elements, *element)
+
+			// Queue the footnotes this element cites
+			for _, index := range footnoteLinks(n) {
+				if fn, ok := footnotes[index]; ok && !read[index] && placement != FootnotesSkip {
+					read[index] = true
+					pending = append(pending, fn)
+				}
+			}
+			if placement == FootnotesParagraph {
+				elements = append(elements, pending...)
+				pending = nil
+			}
+		}
+
+		// Tables, definition lists and footnotes are read as a whole
+		switch n.Kind() {
+		case east.KindTable, east.KindDefinitionList, east.KindFootnoteList:
+			return ast.WalkSkipChildren, nil
 		}
 		
 		return ast.WalkContinue, nil
@@ -60,7 +99,36 @@ func (mp *MarkdownProcessor) ProcessMarkdown(source string) ([]MarkdownElement,
 		return nil, fmt.Errorf("failed to walk markdown AST: %w", err)
 	}
 	
-	return elements, nil
+	return append(elements, pending...), nil
+}
+
+// collectFootnotes returns the document's footnotes by index
+func (mp *MarkdownProcessor) collectFootnotes(doc ast.Node, source string) map[int]MarkdownElement {
+	footnotes := make(map[int]MarkdownElement)
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if fn, ok := n.(*east.Footnote); ok && entering {
+			footnotes[fn.Index] = MarkdownElement{
+				Type:    ElementFootnote,
+				Index:   fn.Index,
+				Content: mp.extractText(fn, source),
+			}
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+	return footnotes
+}
+
+// footnoteLinks returns the footnote indexes cited below node
+func footnoteLinks(node ast.Node) []int {
+	var indexes []int
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if link, ok := n.(*east.FootnoteLink); ok && entering {
+			indexes = append(indexes, link.Index)
+		}
+		return ast.WalkContinue, nil
+	})
+	return indexes
 }
 
 // MarkdownElement represents a parsed markdown element
@@ -72,6 +140,10 @@ type MarkdownElement struct {
 	URL         string // For links
 	Alt         string // For images
 	IsOrdered   bool // For lists
+	Rows        [][]string // For tables, header row first
+	Index       int // For footnotes
+	IsTask      bool // For task list items
+	IsChecked   bool // For task list items
 	Children    []MarkdownElement
 }
 
@@ -93,6 +165,10 @@ const (
 	ElementLineBreak
 	ElementHorizontalRule
 	ElementTable
+	ElementFootnote
+	ElementDefinitionList
+	ElementDefinitionTerm
+	ElementDefinition
 )
 
 // processNode converts an AST node to a MarkdownElement
@@ -112,7 +188,7 @@ func (mp *MarkdownProcessor) processNode(node ast.Node, source string) *Markdown
 		}
 		
 	case *ast.CodeBlock:
-		if !mp.config.IncludeCodeBlocks {
+		if mp.config.codeStrategy("") == CodeBlockSkip {
 			return nil
 		}
 		return &MarkdownElement{
@@ -121,13 +197,13 @@ func (mp *MarkdownProcessor) processNode(node ast.Node, source string) *Markdown
 		}
 		
 	case *ast.FencedCodeBlock:
-		if !mp.config.IncludeCodeBlocks {
-			return nil
-		}
 		lang := ""
 		if n.Info != nil && n.Info.Segment.Len() > 0 {
 			lang = string(n.Info.Segment.Value([]byte(source)))
 		}
+		if mp.config.codeStrategy(lang) == CodeBlockSkip {
+			return nil
+		}
 		return &MarkdownElement{
 			Type:     ElementCodeBlock,
 			Language: lang,
@@ -163,10 +239,33 @@ func (mp *MarkdownProcessor) processNode(node ast.Node, source string) *Markdown
 		}
 		
 	case *ast.ListItem:
-		return &MarkdownElement{
+		element := &MarkdownElement{
 			Type:    ElementListItem,
 			Content: mp.extractText(n, source),
 		}
+		if box := taskCheckBox(n); box != nil {
+			element.IsTask = true
+			element.IsChecked = box.IsChecked
+		}
+		return element
+		
+	case *east.DefinitionList:
+		element := &MarkdownElement{Type: ElementDefinitionList}
+		for child := n.FirstChild(); child != nil; child = child.NextSibling() {
+			switch child.Kind() {
+			case east.KindDefinitionTerm:
+				element.Children = append(element.Children, MarkdownElement{
+					Type:    ElementDefinitionTerm,
+					Content: mp.extractText(child, source),
+				})
+			case east.KindDefinitionDescription:
+				element.Children = append(element.Children, MarkdownElement{
+					Type:    ElementDefinition,
+					Content: mp.extractText(child, source),
+				})
+			}
+		}
+		return element
 		
 	case *ast.Blockquote:
 		return &MarkdownElement{
@@ -175,25 +274,28 @@ func (mp *MarkdownProcessor) processNode(node ast.Node, source string) *Markdown
 		}
 		
 	case *ast.Emphasis:
-		content := mp.extractText(n, source)
-		if mp.config.PreserveEmphasis {
-			content = fmt.Sprintf("emphasized: %s", content)
+		elemType := ElementEmphasis
+		if n.Level > 1 {
+			elemType = ElementStrong
 		}
 		return &MarkdownElement{
-			Type:    ElementEmphasis,
-			Content: content,
+			Type:    elemType,
+			Content: mp.extractText(n, source),
 		}
 		
 	case *ast.CodeSpan:
-		content := mp.extractText(n, source)
-		if mp.config.IncludeCodeBlocks {
-			content = fmt.Sprintf("code: %s", content)
-		} else {
+		if !mp.config.IncludeCodeBlocks {
 			return nil
 		}
 		return &MarkdownElement{
 			Type:    ElementInlineCode,
-			Content: content,
+			Content: mp.extractText(n, source),
+		}
+		
+	case *east.Table:
+		return &MarkdownElement{
+			Type: ElementTable,
+			Rows: mp.extractTableRows(n, source),
 		}
 		
 	case *ast.ThematicBreak:
@@ -218,6 +320,10 @@ func (mp *MarkdownProcessor) extractText(node ast.Node, source string) string {
 			if mp.config.IncludeCodeBlocks {
 				text.WriteString(mp.extractText(c, source))
 			}
+		case *east.Strikethrough:
+			if mp.config.Strikethrough == StrikethroughAnnounce {
+				text.WriteString("deleted: " + mp.extractText(c, source))
+			}
 		default:
 			// Recursively extract text from child nodes
 			text.WriteString(mp.extractText(c, source))
@@ -253,25 +359,82 @@ func (mp *MarkdownProcessor) ConvertToSpeech(elements []MarkdownElement) []strin
 	return speeches
 }
 
-// elementToSpeech converts a single element to speech
+// elementToSpeech converts a single element to the text engines without
+// SSML support read
 func (mp *MarkdownProcessor) elementToSpeech(elem MarkdownElement) string {
+	return mp.ElementSpeech(elem).Text()
+}
+
+// ElementSpeech converts a single element to speech with the pauses,
+// emphasis, prosody and say-as hints its markup implies, normalized by the
+// configured normalizer
+func (mp *MarkdownProcessor) ElementSpeech(elem MarkdownElement) Speech {
+	speech := mp.markupSpeech(elem)
+	if mp.config.Normalizer == nil {
+		return speech
+	}
+	for i, node := range speech.Nodes {
+		// Say-as text is read as the hint says, not as words
+		if node.Kind != SpeechSayAs && node.Text != "" {
+			speech.Nodes[i].Text = mp.config.Normalizer.Normalize(node.Text)
+		}
+	}
+	return speech
+}
+
+// markupSpeech converts a single element to speech as written
+func (mp *MarkdownProcessor) markupSpeech(elem MarkdownElement) Speech {
+	var speech Speech
+	switch elem.Type {
+	case ElementHeading:
+		return headingSpeech(elem.Content, elem.Level, "")
+		
+	case ElementEmphasis, ElementStrong:
+		if !mp.config.PreserveEmphasis {
+			speech.Say(elem.Content)
+		} else if elem.Type == ElementStrong {
+			speech.Emphasize(elem.Content, EmphasisStrong)
+		} else {
+			speech.Emphasize(elem.Content, EmphasisModerate)
+		}
+		
+	case ElementInlineCode:
+		// Short snippets like -v or ls are spelled out
+		if len([]rune(elem.Content)) <= 3 && !strings.ContainsAny(elem.Content, " \t") {
+			speech.Say("code:")
+			speech.SayAs(elem.Content, "characters")
+		} else {
+			speech.Say("code: " + elem.Content)
+		}
+		
+	case ElementHorizontalRule:
+		speech.Pause(time.Second)
+		
+	default:
+		speech.Say(mp.elementText(elem))
+	}
+	return speech
+}
+
+// elementText is the text read for elements without speech markup
+func (mp *MarkdownProcessor) elementText(elem MarkdownElement) string {
 	switch elem.Type {
 	case ElementParagraph:
 		return elem.Content
 		
-	case ElementHeading:
-		// Add pause indicators for headers
-		pause := strings.Repeat(".", elem.Level)
-		return fmt.Sprintf("%s %s %s", pause, elem.Content, pause)
-		
 	case ElementCodeBlock:
-		if mp.config.IncludeCodeBlocks {
+		switch strategy := mp.config.codeStrategy(elem.Language); strategy {
+		case CodeBlockSkip:
+			return ""
+		case CodeBlockRead:
 			if elem.Language != "" {
 				return fmt.Sprintf("Code block in %s: %s", elem.Language, elem.Content)
 			}
 			return fmt.Sprintf("Code block: %s", elem.Content)
+		default:
+			_, spoken := codeLines(strategy, elem.Language, elem.Content)
+			return strings.Join(spoken, ". ")
 		}
-		return ""
 		
 	case ElementLink:
 		return elem.Content
@@ -280,19 +443,34 @@ func (mp *MarkdownProcessor) elementToSpeech(elem MarkdownElement) string {
 		return elem.Content
 		
 	case ElementListItem:
+		if elem.IsTask {
+			if elem.IsChecked {
+				return fmt.Sprintf("Done: %s", elem.Content)
+			}
+			return fmt.Sprintf("Not done: %s", elem.Content)
+		}
 		return fmt.Sprintf("Item: %s", elem.Content)
 		
-	case ElementBlockquote:
-		return fmt.Sprintf("Quote: %s", elem.Content)
+	case ElementFootnote:
+		return fmt.Sprintf("Footnote %d: %s", elem.Index, elem.Content)
 		
-	case ElementEmphasis, ElementStrong:
-		return elem.Content
+	case ElementDefinitionList:
+		var parts []string
+		for _, child := range elem.Children {
+			if child.Type == ElementDefinitionTerm {
+				parts = append(parts, child.Content+":")
+			} else {
+				parts = append(parts, terminate(child.Content))
+			}
+		}
+		return strings.Join(parts, " ")
 		
-	case ElementInlineCode:
-		return elem.Content
+	case ElementBlockquote:
+		return fmt.Sprintf("Quote: %s", elem.Content)
 		
-	case ElementHorizontalRule:
-		return "... ... ..." // Pause for horizontal rule
+	case ElementTable:
+		_, spoken := tableLines(elem.Rows, mp.config.TableSummaryRows)
+		return strings.Join(spoken, ". ")
 		
 	default:
 		return elem.Content
@@ -307,9 +485,6 @@ func (mp *MarkdownProcessor) ExtractSpeakableSentences(markdown string) ([]Parse
 		return nil, err
 	}
 	
-	// Convert elements to speech
-	speeches := mp.ConvertToSpeech(elements)
-	
 	// Create sentence parser for splitting
 	parser, err := NewSentenceParser(mp.config)
 	if err != nil {
@@ -319,18 +494,35 @@ func (mp *MarkdownProcessor) ExtractSpeakableSentences(markdown string) ([]Parse
 	var allSentences []ParsedSentence
 	position := 0
 	
-	// Process each speech element
-	for _, speech := range speeches {
-		if speech == "" {
-			continue
+	// Process each element
+	for index, elem := range elements {
+		var sentences []ParsedSentence
+		switch {
+		case elem.Type == ElementTable:
+			sentences = parser.tableSentences(elem.Rows)
+		case elem.Type == ElementCodeBlock && mp.config.codeStrategy(elem.Language) != CodeBlockRead:
+			sentences = parser.codeSentences(elem.Language, elem.Content, markdown)
+		default:
+			// The parser normalizes each sentence into Spoken, so Text
+			// stays as written
+			speech := mp.markupSpeech(elem).Text()
+			if speech == "" {
+				continue
+			}
+			// Split into sentences
+			sentences = parser.extractSentences(speech, markdown)
+			if elem.Type == ElementHeading {
+				for i := range sentences {
+					sentences[i].Type = SentenceTypeHeader
+					sentences[i].Level = elem.Level
+				}
+			}
 		}
 		
-		// Split into sentences
-		sentences := parser.extractSentences(speech, markdown)
-		
 		// Update positions
 		for i := range sentences {
 			sentences[i].Position = position
+			sentences[i].Block = index
 			position++
 		}
 		
@@ -381,4 +573,13 @@ func CleanTextForSpeech(text string) string {
 	text = regexp.MustCompile(`\s+`).ReplaceAllString(text, " ")
 	
 	return strings.TrimSpace(text)
-}
\ No newline at end of file
+}
+// taskCheckBox returns the checkbox of a task list item, if any
+func taskCheckBox(item ast.Node) *east.TaskCheckBox {
+	block := item.FirstChild()
+	if block == nil {
+		return nil
+	}
+	box, _ := block.FirstChild().(*east.TaskCheckBox)
+	return box
+}
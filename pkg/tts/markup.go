@@ -0,0 +1,264 @@
+package tts
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FootnotePlacement selects where footnotes are read
+type FootnotePlacement string
+
+const (
+	// FootnotesParagraph reads a footnote after the paragraph citing it
+	FootnotesParagraph FootnotePlacement = "paragraph"
+	// FootnotesSection reads footnotes before the next heading
+	FootnotesSection FootnotePlacement = "section"
+	// FootnotesSkip leaves footnotes out
+	FootnotesSkip FootnotePlacement = "skip"
+)
+
+// ParseFootnotePlacement validates a footnote placement name
+func ParseFootnotePlacement(name string) (FootnotePlacement, error) {
+	switch p := FootnotePlacement(strings.ToLower(strings.TrimSpace(name))); p {
+	case FootnotesParagraph, FootnotesSection, FootnotesSkip:
+		return p, nil
+	}
+	return "", fmt.Errorf("unknown footnote placement %q (want paragraph, section or skip)", name)
+}
+
+// StrikethroughMode selects how struck text is read
+type StrikethroughMode string
+
+const (
+	// StrikethroughSkip leaves struck text out
+	StrikethroughSkip StrikethroughMode = "skip"
+	// StrikethroughAnnounce reads struck text after "deleted"
+	StrikethroughAnnounce StrikethroughMode = "announce"
+)
+
+// ParseStrikethroughMode validates a strikethrough mode name
+func ParseStrikethroughMode(name string) (StrikethroughMode, error) {
+	switch m := StrikethroughMode(strings.ToLower(strings.TrimSpace(name))); m {
+	case StrikethroughSkip, StrikethroughAnnounce:
+		return m, nil
+	}
+	return "", fmt.Errorf("unknown strikethrough mode %q (want skip or announce)", name)
+}
+
+// Private use runes mark text that is only spoken (such as "Not done:")
+// or only displayed (such as skipped struck text). They survive markdown
+// stripping and sentence splitting and are resolved by separateMarkup.
+const (
+	spokenOpen   = '\uE000'
+	spokenClose  = '\uE001'
+	displayOpen  = '\uE002'
+	displayClose = '\uE003'
+	markupRunes  = "\uE000\uE001\uE002\uE003"
+)
+
+func spokenOnly(text string) string {
+	return string(spokenOpen) + text + string(spokenClose)
+}
+
+func displayOnly(text string) string {
+	return string(displayOpen) + text + string(displayClose)
+}
+
+// separateMarkup splits marked text into what is displayed and what is
+// spoken. Marks may span sentences, so the open state is carried over.
+func separateMarkup(text string, inSpoken, inDisplay bool) (display, spoken string, spokenOpenAfter, displayOpenAfter bool) {
+	var d, s strings.Builder
+	for _, r := range text {
+		switch r {
+		case spokenOpen:
+			inSpoken = true
+		case spokenClose:
+			inSpoken = false
+		case displayOpen:
+			inDisplay = true
+		case displayClose:
+			inDisplay = false
+		default:
+			if !inSpoken {
+				d.WriteRune(r)
+			}
+			if !inDisplay {
+				s.WriteRune(r)
+			}
+		}
+	}
+	return collapseSpaces(d.String()), collapseSpaces(s.String()), inSpoken, inDisplay
+}
+
+var (
+	footnoteDefPattern   = regexp.MustCompile(`^\[\^([^\]\s]+)\]:[ \t]*(.*)$`)
+	footnoteRefPattern   = regexp.MustCompile(`\[\^([^\]\s]+)\]`)
+	headingLinePattern   = regexp.MustCompile(`^ {0,3}#{1,6}(\s|$)`)
+	taskItemPattern      = regexp.MustCompile(`(?m)^[ \t]*(?:[-*+]|\d+[.)])[ \t]+\[([ xX])\][ \t]+(.*?)[ \t]*$`)
+	strikethroughPattern = regexp.MustCompile(`~~([^~\n]+)~~`)
+	definitionPattern    = regexp.MustCompile(`^:[ \t]+(.*)$`)
+)
+
+// placeFootnotes moves footnote definitions to where they are read and
+// removes the references. Fenced code is left alone.
+func placeFootnotes(markdown string, placement FootnotePlacement) string {
+	if !strings.Contains(markdown, "[^") {
+		return markdown
+	}
+	if placement == "" {
+		placement = FootnotesParagraph
+	}
+	lines := strings.Split(markdown, "\n")
+
+	// Collect definitions, including indented continuation lines
+	defs := make(map[string]string)
+	var body []string
+	fence := ""
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if fence = trackFence(line, fence); fence != "" {
+			body = append(body, line)
+			continue
+		}
+		if m := footnoteDefPattern.FindStringSubmatch(line); m != nil {
+			text := strings.TrimSpace(m[2])
+			for i+1 < len(lines) && (strings.HasPrefix(lines[i+1], "    ") || strings.HasPrefix(lines[i+1], "\t")) {
+				i++
+				text += " " + strings.TrimSpace(lines[i])
+			}
+			defs[m[1]] = text
+			continue
+		}
+		body = append(body, line)
+	}
+
+	var out, pending []string
+	read := make(map[string]bool)
+	flush := func() {
+		if len(pending) > 0 {
+			out = append(out, "")
+			out = append(out, pending...)
+			out = append(out, "")
+			pending = nil
+		}
+	}
+
+	fence = ""
+	for _, line := range body {
+		wasInFence := fence != ""
+		if fence = trackFence(line, fence); fence != "" || wasInFence {
+			out = append(out, line)
+			continue
+		}
+
+		switch {
+		case placement == FootnotesSection && headingLinePattern.MatchString(line):
+			flush()
+		case placement == FootnotesParagraph && strings.TrimSpace(line) == "":
+			flush()
+		}
+
+		for _, m := range footnoteRefPattern.FindAllStringSubmatch(line, -1) {
+			if text, ok := defs[m[1]]; ok && !read[m[1]] && placement != FootnotesSkip {
+				read[m[1]] = true
+				pending = append(pending, footnoteSentence(m[1], text))
+			}
+		}
+		out = append(out, footnoteRefPattern.ReplaceAllString(line, ""))
+	}
+	flush()
+
+	return strings.Join(out, "\n")
+}
+
+// footnoteSentence is a spoken-only footnote. Closing punctuation stays
+// outside the mark so the sentence splitter still sees it.
+func footnoteSentence(id, text string) string {
+	punct := "."
+	if n := len(text); n > 0 && strings.ContainsAny(text[n-1:], ".!?") {
+		punct, text = text[n-1:], text[:n-1]
+	}
+	return spokenOnly("Footnote "+id+": "+text) + punct
+}
+
+// trackFence returns the fence that is open after line, or "" outside
+// fenced code
+func trackFence(line, fence string) string {
+	if fence != "" {
+		if isClosingFence(line, fence) {
+			return ""
+		}
+		return fence
+	}
+	if m := fencePattern.FindStringSubmatch(line); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// speakMarkup rewrites task list items, strikethrough and definition
+// lists in a block of markdown text into their spoken form
+func (p *SentenceParser) speakMarkup(text string) string {
+	text = taskItemPattern.ReplaceAllStringFunc(text, func(item string) string {
+		m := taskItemPattern.FindStringSubmatch(item)
+		status := "Not done: "
+		if m[1] != " " {
+			status = "Done: "
+		}
+		return spokenOnly(status) + terminate(m[2])
+	})
+
+	text = strikethroughPattern.ReplaceAllStringFunc(text, func(struck string) string {
+		inner := strikethroughPattern.FindStringSubmatch(struck)[1]
+		if p.config.Strikethrough == StrikethroughAnnounce {
+			return spokenOnly("deleted: ") + inner
+		}
+		return displayOnly(inner)
+	})
+
+	return expandDefinitionLists(text)
+}
+
+// expandDefinitionLists reads "Term\n: Definition" as "Term: Definition."
+func expandDefinitionLists(text string) string {
+	if !strings.Contains(text, "\n:") {
+		return text
+	}
+
+	lines := strings.Split(text, "\n")
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		term := strings.TrimSpace(lines[i])
+		if term == "" || i+1 >= len(lines) || !definitionPattern.MatchString(lines[i+1]) {
+			out = append(out, lines[i])
+			continue
+		}
+
+		first := true
+		for i+1 < len(lines) {
+			m := definitionPattern.FindStringSubmatch(lines[i+1])
+			if m == nil {
+				break
+			}
+			i++
+			if first {
+				out = append(out, term+": "+terminate(m[1]))
+				first = false
+			} else {
+				out = append(out, terminate(m[1]))
+			}
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// terminate ends text with a full stop unless it already has closing
+// punctuation, so list-like lines are read as separate sentences
+func terminate(text string) string {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.ContainsAny(text[len(text)-1:], ".!?:") {
+		return text
+	}
+	return text + "."
+}
@@ -0,0 +1,132 @@
+package tts
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const adrMarkdown = `# Decision
+
+We chose Postgres[^db] for storage.
+It scales well.
+
+Later paragraph here.
+
+## Checklist
+
+- [x] Write the ADR
+- [ ] Get it reviewed
+
+Use ~~MySQL~~ Postgres.
+
+Latency
+: Time to first byte
+
+[^db]: See the benchmark.`
+
+func parseSpoken(t *testing.T, config *ParserConfig, markdown string) ([]string, []string) {
+	t.Helper()
+	parser, err := NewSentenceParser(config)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	sentences, err := parser.ParseSentences(markdown)
+	if err != nil {
+		t.Fatalf("ParseSentences failed: %v", err)
+	}
+	var text, spoken []string
+	for _, s := range sentences {
+		// Compare words only
+		text = append(text, strings.Join(strings.Fields(s.Text), " "))
+		spoken = append(spoken, strings.Join(strings.Fields(s.SpokenText()), " "))
+	}
+	return text, spoken
+}
+
+func TestParserSpeaksMarkup(t *testing.T) {
+	text, spoken := parseSpoken(t, DefaultParserConfig(), adrMarkdown)
+
+	wantSpoken := []string{
+		"Decision",
+		"We chose Postgres for storage",
+		"It scales well",
+		"Footnote db: See the benchmark",
+		"Later paragraph here",
+		"Checklist",
+		"Done: Write the ADR",
+		"Not done: Get it reviewed",
+		"Use Postgres",
+		"Latency: Time to first byte",
+	}
+	if !reflect.DeepEqual(spoken, wantSpoken) {
+		t.Errorf("Spoken:\n got %q\nwant %q", spoken, wantSpoken)
+	}
+
+	// Spoken-only additions are not part of the displayed text
+	if text[3] != "" || text[6] != "Write the ADR" || text[8] != "Use MySQL Postgres" {
+		t.Errorf("Unexpected display text: %q", text)
+	}
+}
+
+func TestParserMarkupOptions(t *testing.T) {
+	config := DefaultParserConfig()
+	config.Footnotes = FootnotesSection
+	config.Strikethrough = StrikethroughAnnounce
+	_, spoken := parseSpoken(t, config, adrMarkdown)
+
+	want := []string{
+		"Decision",
+		"We chose Postgres for storage",
+		"It scales well",
+		"Later paragraph here",
+		"Footnote db: See the benchmark",
+		"Checklist",
+		"Done: Write the ADR",
+		"Not done: Get it reviewed",
+		"Use deleted: MySQL Postgres",
+		"Latency: Time to first byte",
+	}
+	if !reflect.DeepEqual(spoken, want) {
+		t.Errorf("Spoken:\n got %q\nwant %q", spoken, want)
+	}
+
+	config.Footnotes = FootnotesSkip
+	_, spoken = parseSpoken(t, config, adrMarkdown)
+	for _, s := range spoken {
+		if s == "Footnote db: See the benchmark" {
+			t.Error("Expected footnotes to be skipped")
+		}
+	}
+}
+
+func TestMarkdownProcessorMarkup(t *testing.T) {
+	processor := NewMarkdownProcessor(DefaultParserConfig())
+	elements, err := processor.ProcessMarkdown(adrMarkdown)
+	if err != nil {
+		t.Fatalf("ProcessMarkdown failed: %v", err)
+	}
+
+	var speech []string
+	for _, elem := range elements {
+		switch elem.Type {
+		case ElementListItem, ElementFootnote, ElementDefinitionList:
+			speech = append(speech, processor.elementToSpeech(elem))
+		case ElementParagraph:
+			if strings.HasPrefix(elem.Content, "Use") && !strings.Contains(elem.Content, "MySQL") {
+				speech = append(speech, "struck text skipped")
+			}
+		}
+	}
+
+	want := []string{
+		"Footnote 1: See the benchmark.",
+		"Done: Write the ADR",
+		"Not done: Get it reviewed",
+		"struck text skipped",
+		"Latency: Time to first byte.",
+	}
+	if !reflect.DeepEqual(speech, want) {
+		t.Errorf("Speech:\n got %q\nwant %q", speech, want)
+	}
+}
@@ -0,0 +1,327 @@
+package tts
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TextNormalizer rewrites written forms such as units, dates and symbols
+// into words a speech engine reads naturally
+type TextNormalizer interface {
+	Normalize(text string) string
+}
+
+// NormalizationRule replaces every match of Pattern with the result of
+// Replace, which receives the match followed by its submatches
+type NormalizationRule struct {
+	Pattern *regexp.Regexp
+	Replace func(groups []string) string
+}
+
+// RuleNormalizer applies a list of rules in order
+type RuleNormalizer struct {
+	rules []NormalizationRule
+}
+
+// NewRuleNormalizer creates a normalizer from rules, which run in order
+func NewRuleNormalizer(rules []NormalizationRule) *RuleNormalizer {
+	return &RuleNormalizer{rules: rules}
+}
+
+// Normalize implements TextNormalizer
+func (n *RuleNormalizer) Normalize(text string) string {
+	for _, rule := range n.rules {
+		text = replaceSubmatches(rule.Pattern, text, rule.Replace)
+	}
+	return collapseSpaces(text)
+}
+
+// replaceSubmatches is ReplaceAllStringFunc with access to submatches
+func replaceSubmatches(re *regexp.Regexp, text string, replace func([]string) string) string {
+	matches := re.FindAllStringSubmatchIndex(text, -1)
+	if matches == nil {
+		return text
+	}
+
+	var b strings.Builder
+	last := 0
+	for _, m := range matches {
+		groups := make([]string, len(m)/2)
+		for i := range groups {
+			if m[2*i] >= 0 {
+				groups[i] = text[m[2*i]:m[2*i+1]]
+			}
+		}
+		b.WriteString(text[last:m[0]])
+		b.WriteString(replace(groups))
+		last = m[1]
+	}
+	b.WriteString(text[last:])
+	return b.String()
+}
+
+var spaceRun = regexp.MustCompile(`[ \t]{2,}`)
+
+func collapseSpaces(text string) string {
+	return strings.TrimSpace(spaceRun.ReplaceAllString(text, " "))
+}
+
+var (
+	normalizersMu sync.RWMutex
+	normalizers   = map[string]func() TextNormalizer{
+		"en": func() TextNormalizer { return NewRuleNormalizer(EnglishNormalizationRules()) },
+	}
+	// Normalizers for sentences whose language differs from the
+	// configured one, built once per locale
+	sharedNormalizers = make(map[string]TextNormalizer)
+)
+
+// RegisterNormalizer installs the normalizer used for a locale such as
+// "de" or "pt-BR", replacing any existing one
+func RegisterNormalizer(locale string, factory func() TextNormalizer) {
+	normalizersMu.Lock()
+	defer normalizersMu.Unlock()
+	normalizers[strings.ToLower(locale)] = factory
+	sharedNormalizers = make(map[string]TextNormalizer)
+}
+
+// NewTextNormalizer returns the normalizer for locale, falling back to its
+// base language ("en-GB" uses "en"). An empty locale means English. It
+// returns nil when nothing is registered, which leaves text unchanged.
+func NewTextNormalizer(locale string) TextNormalizer {
+	locale = strings.ToLower(strings.ReplaceAll(locale, "_", "-"))
+	if locale == "" {
+		locale = "en"
+	}
+
+	normalizersMu.RLock()
+	defer normalizersMu.RUnlock()
+	if factory, ok := normalizers[locale]; ok {
+		return factory()
+	}
+	if base, _, found := strings.Cut(locale, "-"); found {
+		if factory, ok := normalizers[base]; ok {
+			return factory()
+		}
+	}
+	return nil
+}
+
+// sharedNormalizer is NewTextNormalizer with the result reused across
+// calls. Normalizers hold no state between calls, so sharing is safe.
+func sharedNormalizer(locale string) TextNormalizer {
+	normalizersMu.RLock()
+	normalizer, ok := sharedNormalizers[locale]
+	normalizersMu.RUnlock()
+	if ok {
+		return normalizer
+	}
+
+	normalizer = NewTextNormalizer(locale)
+	normalizersMu.Lock()
+	sharedNormalizers[locale] = normalizer
+	normalizersMu.Unlock()
+	return normalizer
+}
+
+// English unit names, singular and plural
+var englishUnits = map[string][2]string{
+	"KB":  {"kilobyte", "kilobytes"},
+	"MB":  {"megabyte", "megabytes"},
+	"GB":  {"gigabyte", "gigabytes"},
+	"TB":  {"terabyte", "terabytes"},
+	"PB":  {"petabyte", "petabytes"},
+	"KiB": {"kibibyte", "kibibytes"},
+	"MiB": {"mebibyte", "mebibytes"},
+	"GiB": {"gibibyte", "gibibytes"},
+	"TiB": {"tebibyte", "tebibytes"},
+	"ns":  {"nanosecond", "nanoseconds"},
+	"µs":  {"microsecond", "microseconds"},
+	"ms":  {"millisecond", "milliseconds"},
+	"Hz":  {"hertz", "hertz"},
+	"kHz": {"kilohertz", "kilohertz"},
+	"MHz": {"megahertz", "megahertz"},
+	"GHz": {"gigahertz", "gigahertz"},
+	"mm":  {"millimeter", "millimeters"},
+	"cm":  {"centimeter", "centimeters"},
+	"km":  {"kilometer", "kilometers"},
+	"mg":  {"milligram", "milligrams"},
+	"kg":  {"kilogram", "kilograms"},
+	"fps": {"frame per second", "frames per second"},
+	"rpm": {"revolution per minute", "revolutions per minute"},
+}
+
+// English currency names: singular, plural, fractional unit
+var englishCurrencies = map[string][3]string{
+	"$": {"dollar", "dollars", "cents"},
+	"€": {"euro", "euros", "cents"},
+	"£": {"pound", "pounds", "pence"},
+}
+
+var englishMagnitudes = map[string]string{
+	"k": "thousand",
+	"K": "thousand",
+	"m": "million",
+	"M": "million",
+	"b": "billion",
+	"B": "billion",
+}
+
+var englishOperators = []struct{ symbol, words string }{
+	{"->", "to"},
+	{"=>", "to"},
+	{"<-", "from"},
+	{">=", "greater than or equal to"},
+	{"<=", "less than or equal to"},
+	{"!=", "not equal to"},
+	{"==", "equals"},
+	{"→", "to"},
+	{"←", "from"},
+	{"≥", "greater than or equal to"},
+	{"≤", "less than or equal to"},
+	{"≠", "not equal to"},
+}
+
+// EnglishNormalizationRules returns the default English rule set. Rules
+// are exposed so other locales can reuse or extend them.
+func EnglishNormalizationRules() []NormalizationRule {
+	unitNames := make([]string, 0, len(englishUnits))
+	for unit := range englishUnits {
+		unitNames = append(unitNames, regexp.QuoteMeta(unit))
+	}
+	sort.Strings(unitNames)
+	operators := make([]string, 0, len(englishOperators))
+	operatorWords := make(map[string]string, len(englishOperators))
+	for _, op := range englishOperators {
+		operators = append(operators, regexp.QuoteMeta(op.symbol))
+		operatorWords[op.symbol] = op.words
+	}
+
+	return []NormalizationRule{
+		{
+			// ISO dates: 2024-10-16 -> October 16th, 2024
+			Pattern: regexp.MustCompile(`\b(\d{4})-(\d{2})-(\d{2})\b`),
+			Replace: func(g []string) string {
+				month, _ := strconv.Atoi(g[2])
+				day, _ := strconv.Atoi(g[3])
+				if month < 1 || month > 12 || day < 1 || day > 31 {
+					return g[0]
+				}
+				return time.Month(month).String() + " " + englishOrdinal(day) + ", " + g[1]
+			},
+		},
+		{
+			// Versions: v1.2.3 -> version 1 point 2 point 3
+			Pattern: regexp.MustCompile(`\bv(\d+(?:\.\d+)*)\b`),
+			Replace: func(g []string) string {
+				return "version " + strings.ReplaceAll(g[1], ".", " point ")
+			},
+		},
+		{
+			// Money: $40k -> 40 thousand dollars, $3.50 -> 3 dollars and 50 cents
+			Pattern: regexp.MustCompile(`([$€£])(\d[\d,]*)(?:\.(\d+))?([kKmMbB])?\b`),
+			Replace: func(g []string) string {
+				names := englishCurrencies[g[1]]
+				whole, fraction, magnitude := g[2], g[3], g[4]
+				if magnitude != "" {
+					if fraction != "" {
+						whole += "." + fraction
+					}
+					return whole + " " + englishMagnitudes[magnitude] + " " + names[1]
+				}
+				name := names[1]
+				if whole == "1" {
+					name = names[0]
+				}
+				switch {
+				case fraction == "" || strings.Trim(fraction, "0") == "":
+					return whole + " " + name
+				case len(fraction) == 2:
+					return whole + " " + name + " and " + strings.TrimPrefix(fraction, "0") + " " + names[2]
+				default:
+					return whole + "." + fraction + " " + names[1]
+				}
+			},
+		},
+		{
+			// Units: 3.5GB -> 3.5 gigabytes
+			Pattern: regexp.MustCompile(`\b(\d+(?:\.\d+)?) ?(` + strings.Join(unitNames, "|") + `)\b`),
+			Replace: func(g []string) string {
+				names := englishUnits[g[2]]
+				if g[1] == "1" {
+					return g[1] + " " + names[0]
+				}
+				return g[1] + " " + names[1]
+			},
+		},
+		{
+			// Powers: 10^6 -> 10 to the power of 6, x^2 -> x squared
+			Pattern: regexp.MustCompile(`\b(\w+)\^(\d+)\b`),
+			Replace: func(g []string) string {
+				switch g[2] {
+				case "2":
+					return g[1] + " squared"
+				case "3":
+					return g[1] + " cubed"
+				}
+				return g[1] + " to the power of " + g[2]
+			},
+		},
+		{
+			// Percentages: 25% -> 25 percent
+			Pattern: regexp.MustCompile(`(\d)%`),
+			Replace: func(g []string) string { return g[1] + " percent" },
+		},
+		{
+			// Home paths: ~/.config/glow -> home slash dot config slash glow
+			Pattern: regexp.MustCompile(`(^|[\s(])~/([^\s)]*)`),
+			Replace: func(g []string) string {
+				spoken := []string{"home"}
+				for _, part := range strings.Split(g[2], "/") {
+					if part == "" {
+						continue
+					}
+					if strings.HasPrefix(part, ".") {
+						part = "dot " + part[1:]
+					}
+					spoken = append(spoken, part)
+				}
+				return g[1] + strings.Join(spoken, " slash ")
+			},
+		},
+		{
+			// Approximations: ~5 minutes -> about 5 minutes
+			Pattern: regexp.MustCompile(`(^|[\s(])~(\d)`),
+			Replace: func(g []string) string { return g[1] + "about " + g[2] },
+		},
+		{
+			// Issue and pull request references: #123 -> number 123
+			Pattern: regexp.MustCompile(`(^|[\s(])#(\d+)\b`),
+			Replace: func(g []string) string { return g[1] + "number " + g[2] },
+		},
+		{
+			// Arrows and comparisons: a -> b, x >= 5
+			Pattern: regexp.MustCompile(strings.Join(operators, "|")),
+			Replace: func(g []string) string { return " " + operatorWords[g[0]] + " " },
+		},
+	}
+}
+
+// englishOrdinal returns 1st, 2nd, 3rd, 4th and so on
+func englishOrdinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
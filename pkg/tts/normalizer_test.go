@@ -0,0 +1,119 @@
+package tts
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnglishNormalizer(t *testing.T) {
+	n := NewTextNormalizer("en")
+
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"The image is 3.5GB", "The image is 3.5 gigabytes"},
+		{"Timeout after 1 ms", "Timeout after 1 millisecond"},
+		{"Released on 2024-10-16", "Released on October 16th, 2024"},
+		{"Invalid 2024-13-40 stays", "Invalid 2024-13-40 stays"},
+		{"Upgrade to v1.2.3 today", "Upgrade to version 1 point 2 point 3 today"},
+		{"Costs $40k a year", "Costs 40 thousand dollars a year"},
+		{"Only $1 or $3.50", "Only 1 dollar or 3 dollars and 50 cents"},
+		{"About 10^6 rows", "About 10 to the power of 6 rows"},
+		{"Area is x^2", "Area is x squared"},
+		{"input -> output", "input to output"},
+		{"when n >= 5", "when n greater than or equal to 5"},
+		{"Edit ~/.config/glow/glow.yml", "Edit home slash dot config slash glow slash glow.yml"},
+		{"Takes ~5 minutes", "Takes about 5 minutes"},
+		{"Fixed in #123", "Fixed in number 123"},
+		{"Coverage is 85%", "Coverage is 85 percent"},
+		{"Plain text is unchanged", "Plain text is unchanged"},
+	}
+	for _, tt := range tests {
+		if got := n.Normalize(tt.input); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizerLocales(t *testing.T) {
+	if NewTextNormalizer("en-GB") == nil {
+		t.Error("Expected en-GB to fall back to English")
+	}
+	if NewTextNormalizer("xx") != nil {
+		t.Error("Expected no normalizer for an unregistered locale")
+	}
+
+	RegisterNormalizer("xx", func() TextNormalizer {
+		return NewRuleNormalizer(nil)
+	})
+	if NewTextNormalizer("xx_YY") == nil {
+		t.Error("Expected registered locale to be used for xx_YY")
+	}
+}
+
+func TestParserNormalizesSpokenText(t *testing.T) {
+	parser, err := NewSentenceParser(DefaultParserConfig())
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	sentences, err := parser.ParseSentences("Download the 3.5GB image. Then read the guide.")
+	if err != nil {
+		t.Fatalf("ParseSentences failed: %v", err)
+	}
+	if len(sentences) != 2 {
+		t.Fatalf("Expected 2 sentences, got %d", len(sentences))
+	}
+
+	// Text stays as written so it can be found in the document
+	if sentences[0].Text != "Download the 3.5GB image" {
+		t.Errorf("Unexpected text: %q", sentences[0].Text)
+	}
+	if got := sentences[0].SpokenText(); got != "Download the 3.5 gigabytes image" {
+		t.Errorf("Unexpected spoken text: %q", got)
+	}
+	if sentences[1].Spoken != "" || sentences[1].SpokenText() != sentences[1].Text {
+		t.Errorf("Expected unchanged sentence to have no spoken form, got %q", sentences[1].Spoken)
+	}
+
+	processor := NewMarkdownProcessor(DefaultParserConfig())
+	parsed, err := processor.ExtractSpeakableSentences("Costs $40k per year")
+	if err != nil {
+		t.Fatalf("ExtractSpeakableSentences failed: %v", err)
+	}
+	if len(parsed) != 1 || !strings.Contains(parsed[0].SpokenText(), "40 thousand dollars") {
+		t.Errorf("Expected markdown processor to normalize, got %+v", parsed)
+	}
+}
+
+func TestMarkdownProcessorNormalizesSpeech(t *testing.T) {
+	processor := NewMarkdownProcessor(DefaultParserConfig())
+	elements, err := processor.ProcessMarkdown("# Released 2024-10-16\n\nThe image is 3.5GB.\n\n- input -> output\n\nRun `->` first.")
+	if err != nil {
+		t.Fatalf("ProcessMarkdown failed: %v", err)
+	}
+
+	want := []string{
+		"Released October 16th, 2024",
+		"The image is 3.5 gigabytes.",
+		"Item: input to output",
+	}
+	speech := strings.Join(processor.ConvertToSpeech(elements), "\n")
+	for _, phrase := range want {
+		if !strings.Contains(speech, phrase) {
+			t.Errorf("Expected %q in speech, got:\n%s", phrase, speech)
+		}
+	}
+
+	// Say-as text is spelled out as written
+	code := processor.ElementSpeech(MarkdownElement{Type: ElementInlineCode, Content: "->"})
+	if len(code.Nodes) != 2 || code.Nodes[1].Text != "->" {
+		t.Errorf("Expected inline code left as written, got %+v", code.Nodes)
+	}
+
+	processor = NewMarkdownProcessor(&ParserConfig{})
+	if got := processor.ConvertToSpeech(elements[1:2]); len(got) != 1 || got[0] != "The image is 3.5GB." {
+		t.Errorf("Expected no normalization without a normalizer, got %q", got)
+	}
+}
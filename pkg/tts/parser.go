@@ -12,12 +12,24 @@ import (
 type ParsedSentence struct {
 	// Text is the clean, speakable text for TTS synthesis
 	Text string
+	// Spoken is what is synthesized when it differs from Text, e.g. after
+	// normalization. Text is empty for sentences that are only spoken.
+	Spoken string
 	// Position is the index position in the document (0-based)
 	Position int
 	// Original contains the original markdown text
 	Original string
 	// Type indicates the markdown element type (paragraph, header, etc.)
 	Type SentenceType
+	// Level is the heading level (1-6) of header sentences
+	Level int
+	// Block is the index of the markdown block the sentence was read
+	// from, so sentences of one heading or paragraph can be grouped. It is
+	// -1 for sentences that are not in the body, like the front matter.
+	Block int
+	// Language is the sentence's language code ("de", "es-MX") from a
+	// hint or detection, or "" for the configured language
+	Language string
 }
 
 // SentenceType represents the type of markdown element
@@ -31,8 +43,30 @@ const (
 	SentenceTypeCodeBlock
 	SentenceTypeLink
 	SentenceTypeEmphasis
+	SentenceTypeTable
 )
 
+// String returns the name of the sentence type used in transcripts
+func (t SentenceType) String() string {
+	switch t {
+	case SentenceTypeHeader:
+		return "heading"
+	case SentenceTypeListItem:
+		return "list_item"
+	case SentenceTypeBlockquote:
+		return "blockquote"
+	case SentenceTypeCodeBlock:
+		return "code"
+	case SentenceTypeLink:
+		return "link"
+	case SentenceTypeEmphasis:
+		return "emphasis"
+	case SentenceTypeTable:
+		return "table"
+	}
+	return "paragraph"
+}
+
 // ParserConfig contains configuration for the sentence parser
 type ParserConfig struct {
 	// IncludeCodeBlocks determines if code blocks should be included
@@ -45,16 +79,49 @@ type ParserConfig struct {
 	MinSentenceLength int
 	// MaxSentenceLength is the maximum length before splitting
 	MaxSentenceLength int
+	// Normalizer verbalizes numbers, units and symbols (nil disables it)
+	Normalizer TextNormalizer
+	// CodeBlocks selects how code blocks are spoken. Empty means
+	// CodeBlockRead when IncludeCodeBlocks is set and CodeBlockSkip otherwise.
+	CodeBlocks CodeBlockStrategy
+	// CodeLanguages overrides CodeBlocks for fenced languages such as "bash"
+	CodeLanguages map[string]CodeBlockStrategy
+	// Footnotes selects where footnotes are read (empty means after the
+	// citing paragraph)
+	Footnotes FootnotePlacement
+	// Strikethrough selects how struck text is read (empty means skipped)
+	Strikethrough StrikethroughMode
+	// TableSummaryRows summarizes tables with more data rows than this
+	// instead of reading every row (0 reads every row)
+	TableSummaryRows int
+	// AnnounceFrontMatter reads the front matter title and author before
+	// the document. Front matter is never read verbatim.
+	AnnounceFrontMatter bool
+	// Language is the language Normalizer is written for. Sentences in
+	// other languages use the normalizer registered for theirs.
+	Language string
+	// DetectLanguage tags each sentence with its detected language.
+	// Explicit lang hints are honoured either way.
+	DetectLanguage bool
+	// Languages limits detection to these languages (empty means all
+	// detectable languages)
+	Languages []string
 }
 
 // DefaultParserConfig returns a default configuration for the parser
 func DefaultParserConfig() *ParserConfig {
 	return &ParserConfig{
-		IncludeCodeBlocks: false,
-		ExpandLinks:       true,
-		PreserveEmphasis:  true,
-		MinSentenceLength: 3,
-		MaxSentenceLength: 500,
+		IncludeCodeBlocks:   false,
+		ExpandLinks:         true,
+		PreserveEmphasis:    true,
+		MinSentenceLength:   3,
+		MaxSentenceLength:   500,
+		Normalizer:          NewTextNormalizer("en"),
+		TableSummaryRows:    20,
+		Footnotes:           FootnotesParagraph,
+		Strikethrough:       StrikethroughSkip,
+		AnnounceFrontMatter: true,
+		Language:             "en",
 	}
 }
 
@@ -62,6 +129,8 @@ func DefaultParserConfig() *ParserConfig {
 type SentenceParser struct {
 	config   *ParserConfig
 	renderer *glamour.TermRenderer
+	// language is set while parsing text with a known language
+	language string
 	// Regex patterns for sentence detection
 	sentenceEndPattern   *regexp.Regexp
 	abbreviationPattern  *regexp.Regexp
@@ -96,8 +165,27 @@ func (p *SentenceParser) Parse(markdown string) ([]ParsedSentence, error) {
 		return []ParsedSentence{}, nil
 	}
 
-	var cleanText string
-	
+	// Front matter is metadata: its tts settings apply to this document
+	// only and the title and author are announced before the body.
+	// Unparseable front matter is dropped like it is on screen.
+	frontMatter, body, _ := ParseFrontMatter(markdown)
+	p = p.forDocument(frontMatter)
+	var intro []ParsedSentence
+	if announcement := frontMatter.Announcement(); announcement != "" && p.config.AnnounceFrontMatter {
+		intro = append(intro, ParsedSentence{
+			Spoken:   p.spokenForm("", announcement),
+			Original: announcement,
+			Type:     SentenceTypeHeader,
+			Level:    1,
+			Language: p.language,
+			Block:    -1,
+		})
+	}
+	markdown = body
+	if strings.TrimSpace(markdown) == "" {
+		return intro, nil
+	}
+
 	// If we have a renderer, use it. Otherwise, use simple markdown stripping
 	if p.renderer != nil {
 		// Use glamour renderer
@@ -105,18 +193,101 @@ func (p *SentenceParser) Parse(markdown string) ([]ParsedSentence, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to render markdown: %w", err)
 		}
-		cleanText = p.cleanRenderedText(rendered)
-	} else {
-		// Simple markdown stripping without glamour
-		cleanText = p.stripMarkdownSimple(markdown)
+		sentences := p.extractSentences(p.cleanRenderedText(rendered), markdown)
+		p.tagLanguages(sentences, "")
+		return append(intro, sentences...), nil
+	}
+
+	// Simple markdown stripping without glamour. Tables and code blocks
+	// are spoken rather than stripped, so they are handled separately.
+	// Passages marked with a lang attribute are parsed in their language;
+	// code is never searched for lang attributes, since markup there is an
+	// example rather than a hint.
+	type languageRun struct {
+		language  string
+		sentences []ParsedSentence
+	}
+	var runs []languageRun
+	add := func(block int, language string, parsed []ParsedSentence) {
+		for i := range parsed {
+			parsed[i].Block = block
+		}
+		if n := len(runs); n > 0 && runs[n-1].language == language {
+			runs[n-1].sentences = append(runs[n-1].sentences, parsed...)
+			return
+		}
+		runs = append(runs, languageRun{language: language, sentences: parsed})
+	}
+
+	splitter := &languageSplitter{}
+	for index, block := range splitMarkdownBlocks(placeFootnotes(markdown, p.config.Footnotes)) {
+		switch block.kind {
+		case blockCode:
+			sp := p.forLanguage(splitter.language)
+			add(index, splitter.language, sp.codeSentences(block.language, block.text, markdown))
+		case blockTable:
+			sp := p.forLanguage(splitter.language)
+			add(index, splitter.language, sp.parseTable(block.text))
+		case blockList:
+			for _, item := range block.items {
+				for _, span := range splitter.split(item) {
+					sp := p.forLanguage(span.language)
+					add(index, span.language, sp.listSentences(markdownBlock{kind: blockList, items: []string{span.text}}, markdown))
+				}
+			}
+		default:
+			for _, span := range splitter.split(block.text) {
+				sp := p.forLanguage(span.language)
+				part := block
+				part.text = span.text
+				if block.kind == blockHeading {
+					add(index, span.language, sp.headingSentences(part, markdown))
+				} else {
+					add(index, span.language, sp.extractSentences(sp.stripMarkdownSimple(sp.speakMarkup(part.text)), markdown))
+				}
+			}
+		}
 	}
 
-	// Extract sentences from the clean text
-	sentences := p.extractSentences(cleanText, markdown)
+	// Languages are tagged per passage, so detection can look across blocks
+	sentences := intro
+	for _, run := range runs {
+		p.forLanguage(run.language).tagLanguages(run.sentences, run.language)
+		sentences = append(sentences, run.sentences...)
+	}
+	for i := range sentences {
+		sentences[i].Position = i
+	}
 
 	return sentences, nil
 }
 
+// headingSentences parses a heading line into header sentences, so the
+// heading is read on its own with heading prosody
+func (p *SentenceParser) headingSentences(block markdownBlock, originalMarkdown string) []ParsedSentence {
+	sentences := p.extractSentences(p.stripMarkdownSimple(p.speakMarkup(block.text)), originalMarkdown)
+	for i := range sentences {
+		sentences[i].Type = SentenceTypeHeader
+		sentences[i].Level = block.level
+	}
+	return sentences
+}
+
+// listSentences parses each list item on its own, so items are not run
+// together and playback can pause around the list
+func (p *SentenceParser) listSentences(block markdownBlock, originalMarkdown string) []ParsedSentence {
+	var sentences []ParsedSentence
+	for _, item := range block.items {
+		text := strings.Join(strings.Fields(p.stripMarkdownSimple(p.speakMarkup(item))), " ")
+		itemSentences := p.extractSentences(text, originalMarkdown)
+		for i := range itemSentences {
+			itemSentences[i].Type = SentenceTypeListItem
+		}
+		sentences = append(sentences, itemSentences...)
+	}
+	return sentences
+}
+
 // stripMarkdownSimple removes basic markdown formatting without using glamour
 func (p *SentenceParser) stripMarkdownSimple(markdown string) string {
 	text := markdown
@@ -195,16 +366,48 @@ func (p *SentenceParser) extractSentences(text, originalMarkdown string) []Parse
 	// Split by sentence boundaries
 	parts := p.sentenceEndPattern.Split(protectedText, -1)
 	
+	inSpoken, inDisplay := false, false
 	for _, part := range parts {
 		part = strings.TrimSpace(part)
+
+		// Marked text gives the sentence a spoken form that differs from
+		// what is displayed
+		spoken := ""
+		if inSpoken || inDisplay || strings.ContainsAny(part, markupRunes) {
+			part, spoken, inSpoken, inDisplay = separateMarkup(part, inSpoken, inDisplay)
+			if spoken == "" {
+				// Nothing left to say, e.g. a sentence that is all struck text
+				continue
+			}
+			if spoken == part {
+				spoken = ""
+			}
+		}
 		
 		// Skip empty or too short sentences
-		if len(part) < p.config.MinSentenceLength {
+		if len(part) < p.config.MinSentenceLength && len(spoken) < p.config.MinSentenceLength {
 			continue
 		}
 
 		// Restore protected patterns
 		part = p.restoreSpecialPatterns(part)
+		spoken = p.restoreSpecialPatterns(spoken)
+
+		if spoken != "" {
+			original := ""
+			if part != "" {
+				original = p.findOriginalText(part, originalMarkdown)
+			}
+			sentences = append(sentences, ParsedSentence{
+				Text:     part,
+				Spoken:   p.spokenForm(part, spoken),
+				Position: position,
+				Original: original,
+				Type:     SentenceTypeParagraph,
+			})
+			position++
+			continue
+		}
 
 		// Handle sentences that are too long
 		if len(part) > p.config.MaxSentenceLength {
@@ -212,6 +415,7 @@ func (p *SentenceParser) extractSentences(text, originalMarkdown string) []Parse
 			for _, sub := range subSentences {
 				sentences = append(sentences, ParsedSentence{
 					Text:     sub,
+					Spoken:   p.normalize(sub),
 					Position: position,
 					Original: p.findOriginalText(sub, originalMarkdown),
 					Type:     SentenceTypeParagraph,
@@ -221,6 +425,7 @@ func (p *SentenceParser) extractSentences(text, originalMarkdown string) []Parse
 		} else {
 			sentences = append(sentences, ParsedSentence{
 				Text:     part,
+				Spoken:   p.normalize(part),
 				Position: position,
 				Original: p.findOriginalText(part, originalMarkdown),
 				Type:     SentenceTypeParagraph,
@@ -232,6 +437,59 @@ func (p *SentenceParser) extractSentences(text, originalMarkdown string) []Parse
 	return sentences
 }
 
+// normalize returns the normalized form of text, or "" when normalization
+// leaves it unchanged
+func (p *SentenceParser) normalize(text string) string {
+	normalizer := p.normalizerFor(text)
+	if normalizer == nil {
+		return ""
+	}
+	if spoken := normalizer.Normalize(text); spoken != text {
+		return spoken
+	}
+	return ""
+}
+
+// normalizerFor returns the normalizer for the language of text
+func (p *SentenceParser) normalizerFor(text string) TextNormalizer {
+	language := p.language
+	if language == "" && p.config.DetectLanguage {
+		language = DetectLanguage(text, p.config.Languages...)
+	}
+	if language == "" || baseLanguage(language) == baseLanguage(p.config.Language) {
+		return p.config.Normalizer
+	}
+	return sharedNormalizer(language)
+}
+
+// spokenForm returns the normalized spoken text for a sentence whose spoken
+// form differs from its displayed text
+func (p *SentenceParser) spokenForm(text, spoken string) string {
+	if normalized := p.normalize(spoken); normalized != "" {
+		return normalized
+	}
+	return spoken
+}
+
+// SpokenText returns the text to synthesize
+func (s ParsedSentence) SpokenText() string {
+	if s.Spoken != "" {
+		return s.Spoken
+	}
+	return s.Text
+}
+
+// Speech returns what to say for the sentence. Headings are set apart by
+// pauses and read with heading prosody; other sentences are plain text.
+func (s ParsedSentence) Speech() Speech {
+	if s.Type == SentenceTypeHeader && s.Level > 0 {
+		return headingSpeech(s.SpokenText(), s.Level, s.Language)
+	}
+	speech := Speech{Language: s.Language}
+	speech.Say(s.SpokenText())
+	return speech
+}
+
 // protectSpecialPatterns temporarily replaces patterns that shouldn't be split
 func (p *SentenceParser) protectSpecialPatterns(text string) string {
 	// Protect decimal numbers
@@ -395,15 +653,22 @@ func (p *SentenceParser) ParseSentences(text string) ([]Sentence, error) {
 	sentences := make([]Sentence, 0, len(parsed))
 	for i, ps := range parsed {
 		// Skip empty sentences
-		if ps.Text == "" {
+		if ps.SpokenText() == "" {
 			continue
 		}
 		
-		sentences = append(sentences, Sentence{
+		sentence := Sentence{
 			Text:     ps.Text,
+			Spoken:   ps.Spoken,
 			Position: i,
 			Original: ps.Original,
-		})
+			Language: ps.Language,
+			Type:     ps.Type,
+		}
+		if speech := ps.Speech(); !speech.IsPlain() {
+			sentence.Speech = &speech
+		}
+		sentences = append(sentences, sentence)
 	}
 	
 	return sentences, nil
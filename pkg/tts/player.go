@@ -59,6 +59,12 @@ func (ptr *positionTrackingReader) GetPosition() int64 {
 	return atomic.LoadInt64(&ptr.position)
 }
 
+// playbackReader is the audio source of an AudioStream
+type playbackReader interface {
+	io.ReadSeeker
+	GetPosition() int64
+}
+
 // AudioContext manages the global OTO audio context
 type AudioContext struct {
 	context *oto.Context
@@ -169,7 +175,10 @@ type AudioStream struct {
 	data []byte
 	
 	// reader provides streaming access to the audio data with position tracking
-	reader *positionTrackingReader
+	reader playbackReader
+	
+	// stream is the source of audio that is still being synthesized
+	stream *PCMStream
 	
 	// player is the audio player instance
 	player AudioPlayerInterface
@@ -237,6 +246,63 @@ func NewAudioStream(pcmData []byte) (*AudioStream, error) {
 	return stream, nil
 }
 
+// NewStreamingAudioStream creates an audio stream that plays PCM audio as
+// the engine produces it
+func NewStreamingAudioStream(pcm *PCMStream) (*AudioStream, error) {
+	if pcm == nil {
+		return nil, errors.New("nil audio stream")
+	}
+
+	audioCtx, err := GetGlobalAudioContext()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audio context: %w", err)
+	}
+	if !audioCtx.IsReady() {
+		return nil, errors.New("audio context not ready")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &AudioStream{
+		reader:   pcm.newPlaybackReader(),
+		stream:   pcm,
+		state:    PlaybackStopped,
+		refCount: 1,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	runtime.SetFinalizer(stream, (*AudioStream).finalize)
+
+	return stream, nil
+}
+
+// NewGaplessAudioStream creates an audio stream that plays a document
+// continuously from a gapless reader
+func NewGaplessAudioStream(reader *GaplessReader) (*AudioStream, error) {
+	if reader == nil {
+		return nil, errors.New("nil gapless reader")
+	}
+
+	audioCtx, err := GetGlobalAudioContext()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audio context: %w", err)
+	}
+	if !audioCtx.IsReady() {
+		return nil, errors.New("audio context not ready")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &AudioStream{
+		reader:   reader,
+		state:    PlaybackStopped,
+		refCount: 1,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+	runtime.SetFinalizer(stream, (*AudioStream).finalize)
+
+	return stream, nil
+}
+
 // pinMemory prevents the audio data from being garbage collected
 func (as *AudioStream) pinMemory() {
 	as.mu.Lock()
@@ -374,8 +440,12 @@ func (as *AudioStream) GetPosition() time.Duration {
 	return 0
 }
 
-// GetDuration returns the total duration of the audio
+// GetDuration returns the total duration of the audio, or the duration
+// received so far while a streaming engine is still synthesizing
 func (as *AudioStream) GetDuration() time.Duration {
+	if as.stream != nil {
+		return as.stream.Duration()
+	}
 	return as.duration
 }
 
@@ -397,18 +467,24 @@ func (as *AudioStream) monitorPlayback() {
 			return
 		case <-ticker.C:
 			as.mu.RLock()
-			playing := as.state == PlaybackPlaying
+			state := as.state
 			player := as.player
 			as.mu.RUnlock()
 			
-			if !playing {
+			// Keep watching while paused, as resuming does not restart
+			// monitoring
+			if state == PlaybackStopped {
 				return
 			}
+			if state == PlaybackPaused {
+				continue
+			}
 			
 			// Check if the player is still playing
 			if player != nil && !player.IsPlaying() {
 				// Player reports it's not playing, assume playback completed
 				// The mock player has already reset position, so we can't check it
+				as.reportPlayed(nil)
 				as.mu.Lock()
 				as.state = PlaybackStopped
 				if as.player != nil {
@@ -420,10 +496,29 @@ func (as *AudioStream) monitorPlayback() {
 				as.mu.Unlock()
 				return
 			}
+			as.reportPlayed(player)
 		}
 	}
 }
 
+// reportPlayed tells a gapless reader how much of its audio has been
+// heard: what the player read minus what it still buffers. A nil player
+// has played everything it read.
+func (as *AudioStream) reportPlayed(player AudioPlayerInterface) {
+	as.mu.RLock()
+	gapless, ok := as.reader.(*GaplessReader)
+	as.mu.RUnlock()
+	if !ok {
+		return
+	}
+	
+	played := gapless.GetPosition()
+	if player != nil {
+		played -= int64(player.BufferedDuration()*SampleRate/time.Second) * BytesPerSample
+	}
+	gapless.Played(played)
+}
+
 // Close releases all resources associated with the audio stream
 func (as *AudioStream) Close() error {
 	var err error
@@ -497,6 +592,46 @@ func (ap *TTSAudioPlayer) PlayPCM(pcmData []byte) error {
 	return stream.Play()
 }
 
+// PlayStream plays audio from a streaming engine, starting with the first
+// chunk that arrives
+func (ap *TTSAudioPlayer) PlayStream(pcm *PCMStream) error {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	if ap.currentStream != nil {
+		ap.currentStream.Stop()
+		ap.currentStream.Close()
+	}
+
+	stream, err := NewStreamingAudioStream(pcm)
+	if err != nil {
+		return err
+	}
+
+	ap.currentStream = stream
+	return stream.Play()
+}
+
+// PlayGapless plays the queue behind reader until it ends, replacing the
+// current playback
+func (ap *TTSAudioPlayer) PlayGapless(reader *GaplessReader) error {
+	ap.mu.Lock()
+	defer ap.mu.Unlock()
+
+	if ap.currentStream != nil {
+		ap.currentStream.Stop()
+		ap.currentStream.Close()
+	}
+
+	stream, err := NewGaplessAudioStream(reader)
+	if err != nil {
+		return err
+	}
+
+	ap.currentStream = stream
+	return stream.Play()
+}
+
 // Stop stops the current audio playback
 func (ap *TTSAudioPlayer) Stop() error {
 	ap.mu.Lock()
@@ -541,6 +676,35 @@ func (ap *TTSAudioPlayer) GetState() PlaybackState {
 	return PlaybackStopped
 }
 
+// SegmentPosition returns how far playback is into the segment being
+// played. The segment ID is only known for gapless playback, which moves
+// through segments on its own; otherwise it is empty and the offset is into
+// the audio last given to the player. It reports false when nothing plays.
+func (ap *TTSAudioPlayer) SegmentPosition() (string, time.Duration, bool) {
+	ap.mu.Lock()
+	stream := ap.currentStream
+	ap.mu.Unlock()
+
+	if stream == nil {
+		return "", 0, false
+	}
+	stream.mu.RLock()
+	reader := stream.reader
+	stream.mu.RUnlock()
+
+	if gapless, ok := reader.(*GaplessReader); ok {
+		stream.mu.RLock()
+		player := stream.player
+		stream.mu.RUnlock()
+		if player != nil {
+			stream.reportPlayed(player)
+		}
+		id, offset := gapless.SegmentPosition()
+		return id, offset, true
+	}
+	return "", stream.GetPosition(), true
+}
+
 // Close releases all resources
 func (ap *TTSAudioPlayer) Close() error {
 	ap.mu.Lock()
@@ -53,6 +53,14 @@ func NewAudioStream(pcmData []byte) (*AudioStream, error) {
 	return nil, errors.New("audio not available in nocgo build")
 }
 
+func NewStreamingAudioStream(pcm *PCMStream) (*AudioStream, error) {
+	return nil, errors.New("audio not available in nocgo build")
+}
+
+func NewGaplessAudioStream(reader *GaplessReader) (*AudioStream, error) {
+	return nil, errors.New("audio not available in nocgo build")
+}
+
 func (as *AudioStream) Play() error {
 	return errors.New("audio not available in nocgo build")
 }
@@ -98,6 +106,14 @@ func (ap *TTSAudioPlayer) PlayPCM(pcmData []byte) error {
 	return errors.New("audio not available in nocgo build")
 }
 
+func (ap *TTSAudioPlayer) PlayStream(pcm *PCMStream) error {
+	return errors.New("audio not available in nocgo build")
+}
+
+func (ap *TTSAudioPlayer) PlayGapless(reader *GaplessReader) error {
+	return errors.New("audio not available in nocgo build")
+}
+
 func (ap *TTSAudioPlayer) Stop() error {
 	return errors.New("audio not available in nocgo build")
 }
@@ -114,6 +130,10 @@ func (ap *TTSAudioPlayer) GetState() PlaybackState {
 	return PlaybackStopped
 }
 
+func (ap *TTSAudioPlayer) SegmentPosition() (string, time.Duration, bool) {
+	return "", 0, false
+}
+
 func (ap *TTSAudioPlayer) Close() error {
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,200 @@
+package tts
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// MaxProgressEntries is how many documents the progress store remembers
+const MaxProgressEntries = 200
+
+// ReadingProgress records where playback of a document stopped
+type ReadingProgress struct {
+	// Path is the absolute path of the document
+	Path string `json:"path"`
+	// Hash identifies the document content (see ContentHash)
+	Hash string `json:"hash"`
+	// Sentence is the index of the sentence to continue from
+	Sentence int `json:"sentence"`
+	// Total is the number of sentences in the document
+	Total int `json:"total"`
+	// Speed is the playback speed in use
+	Speed float64 `json:"speed"`
+	// Engine is the engine name ("piper", "gtts", ...)
+	Engine string `json:"engine"`
+	// Voice is the voice in use, as front matter selects it (see
+	// TTSConfig.VoiceSetting)
+	Voice string `json:"voice,omitempty"`
+	// UpdatedAt is when the progress was saved
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProgressStore persists reading progress in a JSON file
+type ProgressStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewProgressStore creates a store backed by path, or the default
+// location when path is empty
+func NewProgressStore(path string) *ProgressStore {
+	if path == "" {
+		path = DefaultProgressPath()
+	}
+	return &ProgressStore{path: path}
+}
+
+// DefaultProgressPath returns the progress file under the XDG state directory
+// (~/.local/state/glow/tts/progress.json)
+func DefaultProgressPath() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, _ := os.UserHomeDir()
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "glow", "tts", "progress.json")
+}
+
+// ContentHash returns a short hash of a document's content
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Path returns the file the store reads and writes
+func (s *ProgressStore) Path() string {
+	return s.path
+}
+
+// Get returns the saved progress for the document at path. Progress saved
+// for different content is ignored, since sentence indices no longer match.
+func (s *ProgressStore) Get(path, hash string) (ReadingProgress, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return ReadingProgress{}, false
+	}
+	progress, ok := entries[progressKey(path)]
+	if !ok || progress.Hash != hash {
+		return ReadingProgress{}, false
+	}
+	return progress, true
+}
+
+// Save records progress, replacing any earlier entry for the same document
+func (s *ProgressStore) Save(progress ReadingProgress) error {
+	if progress.Path == "" {
+		return errors.New("progress requires a document path")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		// A corrupt file is replaced rather than blocking playback
+		entries = make(map[string]ReadingProgress)
+	}
+
+	progress.Path = progressKey(progress.Path)
+	if progress.UpdatedAt.IsZero() {
+		progress.UpdatedAt = time.Now()
+	}
+	entries[progress.Path] = progress
+	return s.write(entries)
+}
+
+// Remove forgets the progress for the document at path
+func (s *ProgressStore) Remove(path string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.load()
+	if err != nil {
+		return err
+	}
+	key := progressKey(path)
+	if _, ok := entries[key]; !ok {
+		return nil
+	}
+	delete(entries, key)
+	return s.write(entries)
+}
+
+// load reads all entries; a missing file is an empty store
+func (s *ProgressStore) load() (map[string]ReadingProgress, error) {
+	entries := make(map[string]ReadingProgress)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return entries, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read progress file: %w", err)
+	}
+
+	var list []ReadingProgress
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse progress file: %w", err)
+	}
+	for _, progress := range list {
+		entries[progress.Path] = progress
+	}
+	return entries, nil
+}
+
+// write saves the most recent entries atomically
+func (s *ProgressStore) write(entries map[string]ReadingProgress) error {
+	list := make([]ReadingProgress, 0, len(entries))
+	for _, progress := range entries {
+		list = append(list, progress)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].UpdatedAt.After(list[j].UpdatedAt) })
+	if len(list) > MaxProgressEntries {
+		list = list[:MaxProgressEntries]
+	}
+
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode progress: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return fmt.Errorf("failed to create progress directory: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".progress-*.json")
+	if err != nil {
+		return fmt.Errorf("failed to write progress: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write progress: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write progress: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write progress: %w", err)
+	}
+	return nil
+}
+
+// progressKey normalizes a document path so relative and absolute paths match
+func progressKey(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
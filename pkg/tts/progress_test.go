@@ -0,0 +1,71 @@
+package tts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProgressStore(t *testing.T) {
+	dir := t.TempDir()
+	store := NewProgressStore(filepath.Join(dir, "state", "progress.json"))
+	doc := filepath.Join(dir, "spec.md")
+	hash := ContentHash("# Spec\n\nFirst. Second.")
+
+	if _, ok := store.Get(doc, hash); ok {
+		t.Fatal("Expected no progress in an empty store")
+	}
+
+	err := store.Save(ReadingProgress{Path: doc, Hash: hash, Sentence: 12, Total: 40, Speed: 1.5, Engine: "piper", Voice: "amy"})
+	if err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	// A fresh store reads what the first one wrote
+	progress, ok := NewProgressStore(store.Path()).Get(doc, hash)
+	if !ok {
+		t.Fatal("Expected saved progress")
+	}
+	if progress.Sentence != 12 || progress.Speed != 1.5 || progress.Voice != "amy" || progress.UpdatedAt.IsZero() {
+		t.Errorf("Unexpected progress %+v", progress)
+	}
+
+	if _, ok := store.Get(doc, ContentHash("# Spec\n\nEdited.")); ok {
+		t.Error("Expected progress for changed content to be ignored")
+	}
+
+	// Relative paths resolve to the same document
+	wd, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd) //nolint:errcheck
+	if _, ok := store.Get("spec.md", hash); !ok {
+		t.Error("Expected relative path to match")
+	}
+
+	if err := store.Remove(doc); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, ok := store.Get(doc, hash); ok {
+		t.Error("Expected progress to be removed")
+	}
+}
+
+func TestProgressStoreCorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "progress.json")
+	if err := os.WriteFile(path, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := NewProgressStore(path)
+	if _, ok := store.Get("/doc.md", "abc"); ok {
+		t.Error("Expected no progress from a corrupt file")
+	}
+	if err := store.Save(ReadingProgress{Path: "/doc.md", Hash: "abc", Sentence: 1}); err != nil {
+		t.Fatalf("Expected corrupt file to be replaced: %v", err)
+	}
+	if _, ok := store.Get("/doc.md", "abc"); !ok {
+		t.Error("Expected progress after replacing corrupt file")
+	}
+}
@@ -46,6 +46,9 @@ type TextSegment struct {
 	Text     string
 	Position int
 	Priority int
+	Language string
+	Speech   *Speech
+	Type     SentenceType
 }
 
 // AudioSegment represents a synthesized audio segment
@@ -54,7 +57,12 @@ type AudioSegment struct {
 	Text         string
 	Audio        []byte
 	ProcessedAudio []byte // After preprocessing
+	Stream       *PCMStream // Audio still arriving from a streaming engine
 	Position     int
+	Language     string // Selects the engine from QueueConfig.LanguageEngines
+	Speech       *Speech // Pauses and prosody to synthesize, nil for plain text
+	Type         SentenceType // Selects the pause before the segment in gapless playback
+	Timings      []WordTiming // When each word of Text is spoken in ProcessedAudio
 	Duration     time.Duration
 	Synthesized  time.Time
 	LastAccessed time.Time
@@ -62,6 +70,12 @@ type AudioSegment struct {
 	Played       bool
 }
 
+// isReady reports whether the segment has audio to play, either complete
+// or arriving from a streaming engine
+func (s *AudioSegment) isReady() bool {
+	return s.Audio != nil || (s.Stream != nil && s.Stream.Ready())
+}
+
 // QueueConfig contains configuration for the audio queue
 type QueueConfig struct {
 	LookaheadSize      int
@@ -72,6 +86,8 @@ type QueueConfig struct {
 	CacheManager       *TTSCacheManager
 	Engine             TTSEngine
 	Parser             TextParser
+	Lexicon            *Lexicon // Pronunciation rules applied before synthesis (optional)
+	LanguageEngines    map[string]TTSEngine // Engines for sentences in other languages (optional)
 }
 
 // DefaultQueueConfig returns default queue configuration
@@ -95,6 +111,7 @@ type TTSAudioQueue struct {
 	// State management
 	state          QueueState
 	currentIndex   int
+	startPosition  int // Segment that becomes current first (see SetStartPosition)
 	totalProcessed int64
 	totalPlayed    int64
 	
@@ -102,6 +119,7 @@ type TTSAudioQueue struct {
 	textQueue      chan TextSegment
 	synthesisQueue chan string // Segment IDs to synthesize
 	workers        []*queueWorker
+	inflight       map[string]context.CancelFunc // Cancels synthesis in progress, by segment ID
 	
 	// Memory management
 	memoryUsage    int64
@@ -171,6 +189,7 @@ func NewAudioQueue(config *QueueConfig) (*TTSAudioQueue, error) {
 		textQueue:      make(chan TextSegment, MaxQueueSize),
 		synthesisQueue: make(chan string, config.LookaheadSize*2),
 		workers:        make([]*queueWorker, 0, config.WorkerCount),
+		inflight:       make(map[string]context.CancelFunc),
 		maxMemory:      int64(config.MaxMemoryMB * 1024 * 1024),
 		ctx:            ctx,
 		cancel:         cancel,
@@ -223,9 +242,12 @@ func (aq *TTSAudioQueue) AddText(text string) error {
 		
 		segment := TextSegment{
 			ID:       fmt.Sprintf("seg-%d-%d", time.Now().UnixNano(), i),
-			Text:     sentence.Text,
+			Text:     sentence.SpokenText(),
 			Position: position,
 			Priority: 0,
+			Language: sentence.Language,
+			Speech:   sentence.Speech,
+			Type:     sentence.Type,
 		}
 		
 		log.Debug("TTS Queue: Adding segment", 
@@ -245,6 +267,54 @@ func (aq *TTSAudioQueue) AddText(text string) error {
 	return nil
 }
 
+// SetLexicon replaces the pronunciation lexicon. It applies to segments
+// synthesized from now on.
+func (aq *TTSAudioQueue) SetLexicon(lexicon *Lexicon) {
+	aq.mu.Lock()
+	defer aq.mu.Unlock()
+	aq.config.Lexicon = lexicon
+}
+
+// SetEngine replaces the synthesis engine. It applies to segments
+// synthesized from now on.
+func (aq *TTSAudioQueue) SetEngine(engine TTSEngine) {
+	aq.mu.Lock()
+	defer aq.mu.Unlock()
+	aq.config.Engine = engine
+}
+
+// SetLanguageEngines sets the engines used for sentences tagged with a
+// language, keyed by language code ("de") or locale ("pt-BR"). Sentences
+// in other languages use the main engine. It applies to segments
+// synthesized from now on.
+func (aq *TTSAudioQueue) SetLanguageEngines(engines map[string]TTSEngine) {
+	aq.mu.Lock()
+	defer aq.mu.Unlock()
+	aq.config.LanguageEngines = engines
+}
+
+// engineFor returns the engine for a language. The caller holds aq.mu.
+func (aq *TTSAudioQueue) engineFor(language string) TTSEngine {
+	if engine := LanguageEngine(aq.config.LanguageEngines, language); engine != nil {
+		return engine
+	}
+	return aq.config.Engine
+}
+
+// SetStartPosition makes playback begin at the segment with the given
+// position instead of the first one. It applies to text added to an empty
+// queue; segments before it are kept for navigation but not synthesized
+// until reached.
+func (aq *TTSAudioQueue) SetStartPosition(position int) {
+	aq.mu.Lock()
+	defer aq.mu.Unlock()
+	
+	if position < 0 {
+		position = 0
+	}
+	aq.startPosition = position
+}
+
 // processTextQueue processes incoming text segments
 func (aq *TTSAudioQueue) processTextQueue() {
 	for {
@@ -257,27 +327,31 @@ func (aq *TTSAudioQueue) processTextQueue() {
 			audioSeg.ID = segment.ID
 			audioSeg.Text = segment.Text
 			audioSeg.Position = segment.Position
+			audioSeg.Language = segment.Language
+			audioSeg.Speech = segment.Speech
+			audioSeg.Type = segment.Type
 			audioSeg.Synthesized = time.Time{}
 			audioSeg.Playing = false
 			audioSeg.Played = false
+			audioSeg.Stream = nil
 			
 			// Add to segments map and order
 			aq.segments[segment.ID] = audioSeg
 			aq.order = append(aq.order, segment.ID)
 			
-			// Initialize currentIndex to 0 for the first segment
-			if aq.currentIndex < 0 && len(aq.order) == 1 {
-				aq.currentIndex = 0
-				log.Debug("TTS Queue: Initialized currentIndex to 0")
+			// Initialize currentIndex once the starting segment arrives
+			if aq.currentIndex < 0 && len(aq.order) == aq.startPosition+1 {
+				aq.currentIndex = aq.startPosition
+				log.Debug("TTS Queue: Initialized currentIndex", "index", aq.currentIndex)
 			}
 			
 			// Queue for synthesis if within lookahead window
-			// Use max(0, currentIndex) to handle initial state
+			// Use the start position to handle the initial state
 			effectiveIndex := aq.currentIndex
 			if effectiveIndex < 0 {
-				effectiveIndex = 0
+				effectiveIndex = aq.startPosition
 			}
-			if segment.Position <= effectiveIndex+aq.config.LookaheadSize {
+			if segment.Position >= effectiveIndex && segment.Position <= effectiveIndex+aq.config.LookaheadSize {
 				log.Debug("TTS Queue: Queueing segment for synthesis",
 					"segmentID", segment.ID,
 					"position", segment.Position,
@@ -351,17 +425,36 @@ func (w *queueWorker) synthesizeSegment(segmentID string) {
 		return
 	}
 	
-	// Check if already synthesized while we have the lock
-	if segment.Audio != nil {
+	// Check if already synthesized (or streaming) while we have the lock
+	if segment.Audio != nil || segment.Stream != nil {
 		w.queue.mu.RUnlock()
 		log.Debug("TTS Worker: Segment already synthesized", "segmentID", segmentID)
 		return
 	}
 	
-	// Copy the text we need to synthesize while holding the lock
-	textToSynthesize := segment.Text
+	// Playback may have moved on while the segment waited to be picked up
+	if !w.queue.inLookahead(segment.Position) {
+		w.queue.mu.RUnlock()
+		log.Debug("TTS Worker: Segment superseded before synthesis", "segmentID", segmentID)
+		return
+	}
+	
+	// Copy the text we need to synthesize while holding the lock, with the
+	// lexicon applied so cached audio follows the pronunciation rules
+	textToSynthesize := w.queue.config.Lexicon.Apply(segment.Text)
+	var speech *Speech
+	if segment.Speech != nil {
+		mapped := segment.Speech.Map(w.queue.config.Lexicon.Apply)
+		speech = &mapped
+		textToSynthesize = speechCacheText(mapped)
+	}
+	text := segment.Text
+	engine := w.queue.engineFor(segment.Language)
 	w.queue.mu.RUnlock()
 	
+	ctx, done := w.queue.trackSynthesis(w.ctx, segmentID)
+	defer done()
+	
 	start := time.Now()
 	
 	// Check cache first
@@ -369,7 +462,7 @@ func (w *queueWorker) synthesizeSegment(segmentID string) {
 	var err error
 	
 	if w.queue.config.CacheManager != nil {
-		cacheKey := GenerateCacheKey(textToSynthesize, w.queue.config.Engine.GetName(), 1.0)
+		cacheKey := GenerateCacheKey(textToSynthesize, engine.GetName(), 1.0)
 		cached, cacheErr := w.queue.config.CacheManager.Get(cacheKey)
 		if cacheErr == nil && cached != nil {
 			audioData = cached.Audio
@@ -382,7 +475,17 @@ func (w *queueWorker) synthesizeSegment(segmentID string) {
 	// Synthesize if not cached
 	if audioData == nil {
 		log.Debug("TTS Worker: Synthesizing text", "segmentID", segmentID, "textLen", len(textToSynthesize))
-		audioData, err = w.queue.config.Engine.Synthesize(textToSynthesize, 1.0)
+		if speech != nil {
+			audioData, err = SynthesizeSpeech(ctx, engine, *speech, 1.0)
+		} else if streamer, ok := engine.(StreamingEngine); ok {
+			audioData, err = w.synthesizeStreaming(ctx, segmentID, streamer, textToSynthesize)
+		} else {
+			audioData, err = SynthesizeContext(ctx, engine, textToSynthesize, 1.0)
+		}
+		if err != nil && ctx.Err() != nil {
+			log.Debug("TTS Worker: Synthesis cancelled", "segmentID", segmentID)
+			return
+		}
 		if err != nil {
 			log.Error("TTS Worker: Synthesis failed", "segmentID", segmentID, "error", err)
 			if w.queue.onError != nil {
@@ -394,11 +497,11 @@ func (w *queueWorker) synthesizeSegment(segmentID string) {
 		
 		// Cache the result
 		if w.queue.config.CacheManager != nil && len(audioData) > 0 {
-			cacheKey := GenerateCacheKey(textToSynthesize, w.queue.config.Engine.GetName(), 1.0)
+			cacheKey := GenerateCacheKey(textToSynthesize, engine.GetName(), 1.0)
 			cacheData := &AudioData{
 				Audio:    audioData,
 				Text:     textToSynthesize,
-				Voice:    w.queue.config.Engine.GetName(),
+				Voice:    engine.GetName(),
 				Speed:    1.0,
 				CacheKey: cacheKey,
 			}
@@ -409,6 +512,10 @@ func (w *queueWorker) synthesizeSegment(segmentID string) {
 	// Preprocess audio
 	processedAudio := w.queue.preprocessAudio(audioData)
 	
+	// Word timings are estimated on the trimmed audio, whose leading
+	// silence streamed playback skips as well
+	timings := EstimateWordTimings(text, processedAudio, DefaultPCMFormat())
+	
 	// Update segment - re-fetch it safely to avoid stale pointer
 	w.queue.mu.Lock()
 	segment, exists = w.queue.segments[segmentID]
@@ -421,6 +528,7 @@ func (w *queueWorker) synthesizeSegment(segmentID string) {
 	
 	segment.Audio = audioData
 	segment.ProcessedAudio = processedAudio
+	segment.Timings = timings
 	segment.Synthesized = time.Now()
 	segment.Duration = w.queue.calculateDuration(audioData)
 	
@@ -448,6 +556,89 @@ func (w *queueWorker) synthesizeSegment(segmentID string) {
 	}
 }
 
+// synthesizeStreaming publishes the segment's audio as a stream so playback
+// can start with the first chunk, then waits for the complete audio
+func (w *queueWorker) synthesizeStreaming(ctx context.Context, segmentID string, engine StreamingEngine, text string) ([]byte, error) {
+	reader, err := engine.SynthesizeStream(ctx, text, 1.0)
+	if err != nil {
+		return nil, err
+	}
+	stream := NewPCMStream(reader)
+	
+	w.queue.mu.Lock()
+	segment, exists := w.queue.segments[segmentID]
+	if !exists {
+		w.queue.mu.Unlock()
+		stream.Close()
+		return nil, fmt.Errorf("segment cleared before streaming started")
+	}
+	segment.Stream = stream
+	w.queue.mu.Unlock()
+	
+	audio, err := stream.Wait()
+	if err != nil {
+		// Let a later lookahead pass retry the segment
+		w.queue.mu.Lock()
+		if segment, exists := w.queue.segments[segmentID]; exists && segment.Stream == stream {
+			segment.Stream = nil
+		}
+		w.queue.mu.Unlock()
+	}
+	return audio, err
+}
+
+// trackSynthesis derives a context for synthesizing a segment that
+// cancelSuperseded and CancelSynthesis can cancel. done must be called when
+// synthesis ends.
+func (aq *TTSAudioQueue) trackSynthesis(parent context.Context, segmentID string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(parent)
+	
+	aq.mu.Lock()
+	aq.inflight[segmentID] = cancel
+	aq.mu.Unlock()
+	
+	return ctx, func() {
+		aq.mu.Lock()
+		delete(aq.inflight, segmentID)
+		aq.mu.Unlock()
+		cancel()
+	}
+}
+
+// inLookahead reports whether a segment position falls in the window that
+// should be synthesized: the current segment and those ahead of it. Caller
+// must hold aq.mu.
+func (aq *TTSAudioQueue) inLookahead(position int) bool {
+	current := aq.currentIndex
+	if current < 0 {
+		current = aq.startPosition
+	}
+	return position >= current && position <= current+aq.config.LookaheadSize
+}
+
+// cancelSuperseded cancels synthesis of segments that navigation has moved
+// out of the lookahead window. Caller must hold aq.mu.
+func (aq *TTSAudioQueue) cancelSuperseded() {
+	for segmentID, cancel := range aq.inflight {
+		segment := aq.segments[segmentID]
+		if segment == nil || !aq.inLookahead(segment.Position) {
+			log.Debug("TTS Queue: Cancelling superseded synthesis", "segmentID", segmentID)
+			cancel()
+		}
+	}
+}
+
+// CancelSynthesis cancels all synthesis in progress. Cancelled segments are
+// synthesized again if playback reaches them.
+func (aq *TTSAudioQueue) CancelSynthesis() {
+	aq.mu.Lock()
+	defer aq.mu.Unlock()
+	
+	for _, cancel := range aq.inflight {
+		cancel()
+	}
+}
+
 // preprocessAudio preprocesses audio for seamless playback
 func (aq *TTSAudioQueue) preprocessAudio(audio []byte) []byte {
 	log.Debug("TTS Queue: preprocessAudio called", "inputSize", len(audio))
@@ -465,8 +656,7 @@ func (aq *TTSAudioQueue) preprocessAudio(audio []byte) []byte {
 	processed = aq.normalizeAudio(processed)
 	log.Debug("TTS Queue: After normalizeAudio", "finalSize", len(processed))
 	
-	// Add crossfade markers (actual crossfading done during playback)
-	// For now, just return the processed audio
+	// Segments are crossfaded and spaced by GaplessReader during playback
 	return processed
 }
 
@@ -598,7 +788,7 @@ func (aq *TTSAudioQueue) checkLookahead() {
 		segmentID := aq.order[i]
 		segment := aq.segments[segmentID]
 		
-		if segment != nil && segment.Audio == nil {
+		if segment != nil && segment.Audio == nil && segment.Stream == nil {
 			select {
 			case aq.synthesisQueue <- segmentID:
 			default:
@@ -644,11 +834,99 @@ func (aq *TTSAudioQueue) GetCurrent() (*AudioSegment, error) {
 	return segment, nil
 }
 
+// CurrentPosition returns the document position of the current segment, or -1 if there is none
+func (aq *TTSAudioQueue) CurrentPosition() int {
+	aq.mu.RLock()
+	defer aq.mu.RUnlock()
+	
+	if aq.currentIndex < 0 || aq.currentIndex >= len(aq.order) {
+		return -1
+	}
+	
+	segment := aq.segments[aq.order[aq.currentIndex]]
+	if segment == nil {
+		return -1
+	}
+	return segment.Position
+}
+
 // Next moves to the next segment
 func (aq *TTSAudioQueue) Next() (*AudioSegment, error) {
 	aq.mu.Lock()
 	defer aq.mu.Unlock()
+	return aq.next()
+}
+
+// advanceFrom moves to the next segment if segmentID is still the current
+// one, so a player that finished a segment cannot skip one the reader
+// navigated to in the meantime
+func (aq *TTSAudioQueue) advanceFrom(segmentID string) bool {
+	aq.mu.Lock()
+	defer aq.mu.Unlock()
 	
+	if aq.currentIndex < 0 || aq.currentIndex >= len(aq.order) || aq.order[aq.currentIndex] != segmentID {
+		return false
+	}
+	_, err := aq.next()
+	return err == nil
+}
+
+// isCurrent reports whether segmentID is the current segment
+func (aq *TTSAudioQueue) isCurrent(segmentID string) bool {
+	aq.mu.RLock()
+	defer aq.mu.RUnlock()
+	
+	return aq.currentIndex >= 0 && aq.currentIndex < len(aq.order) && aq.order[aq.currentIndex] == segmentID
+}
+
+// following returns the segment after segmentID, or the current segment
+// when segmentID is empty. It reports whether the queue ends there, with
+// no segment after it and no text waiting to be queued.
+func (aq *TTSAudioQueue) following(segmentID string) (*AudioSegment, bool) {
+	aq.mu.RLock()
+	defer aq.mu.RUnlock()
+	
+	index := aq.currentIndex
+	if segmentID != "" {
+		index = -1
+		for i, id := range aq.order {
+			if id == segmentID {
+				index = i + 1
+				break
+			}
+		}
+	}
+	if index < 0 {
+		return nil, false
+	}
+	if index >= len(aq.order) {
+		return nil, len(aq.textQueue) == 0
+	}
+	return aq.segments[aq.order[index]], false
+}
+
+// wordAt returns the index of the word spoken offset into the current
+// segment, or -1. segmentID, if set, must name the current segment.
+func (aq *TTSAudioQueue) wordAt(segmentID string, offset time.Duration) int {
+	aq.mu.RLock()
+	defer aq.mu.RUnlock()
+	
+	if aq.currentIndex < 0 || aq.currentIndex >= len(aq.order) {
+		return -1
+	}
+	current := aq.order[aq.currentIndex]
+	if segmentID != "" && segmentID != current {
+		return -1
+	}
+	segment := aq.segments[current]
+	if segment == nil {
+		return -1
+	}
+	return WordAt(segment.Timings, offset)
+}
+
+// next moves to the next segment. Caller must hold aq.mu.
+func (aq *TTSAudioQueue) next() (*AudioSegment, error) {
 	if aq.currentIndex >= len(aq.order)-1 {
 		return nil, fmt.Errorf("end of queue")
 	}
@@ -664,6 +942,7 @@ func (aq *TTSAudioQueue) Next() (*AudioSegment, error) {
 	}
 	
 	aq.currentIndex++
+	aq.cancelSuperseded()
 	
 	segmentID := aq.order[aq.currentIndex]
 	segment := aq.segments[segmentID]
@@ -702,6 +981,7 @@ func (aq *TTSAudioQueue) Previous() (*AudioSegment, error) {
 	}
 	
 	aq.currentIndex--
+	aq.cancelSuperseded()
 	
 	segmentID := aq.order[aq.currentIndex]
 	segment := aq.segments[segmentID]
@@ -741,6 +1021,7 @@ func (aq *TTSAudioQueue) Skip(n int) (*AudioSegment, error) {
 	}
 	
 	aq.currentIndex = newIndex
+	aq.cancelSuperseded()
 	
 	segmentID := aq.order[aq.currentIndex]
 	segment := aq.segments[segmentID]
@@ -798,11 +1079,22 @@ func (aq *TTSAudioQueue) Clear() {
 	aq.mu.Lock()
 	defer aq.mu.Unlock()
 	
+	// Abandon synthesis of segments that are about to be discarded
+	for _, cancel := range aq.inflight {
+		cancel()
+	}
+	
 	// Return segments to pool
 	for _, segment := range aq.segments {
 		if segment != nil {
+			if segment.Stream != nil {
+				// Stop synthesis that is still streaming
+				segment.Stream.Close()
+			}
 			segment.Audio = nil
 			segment.ProcessedAudio = nil
+			segment.Timings = nil
+			segment.Stream = nil
 			aq.segmentPool.Put(segment)
 		}
 	}
@@ -810,6 +1102,7 @@ func (aq *TTSAudioQueue) Clear() {
 	aq.segments = make(map[string]*AudioSegment)
 	aq.order = make([]string, 0, MaxQueueSize)
 	aq.currentIndex = -1
+	aq.startPosition = 0
 	atomic.StoreInt64(&aq.memoryUsage, 0)
 	
 	// Only set to Idle if we're not already stopped
@@ -872,6 +1165,8 @@ func (aq *TTSAudioQueue) cleanupMemory() {
 			audioSize := int64(len(segment.Audio) + len(segment.ProcessedAudio))
 			segment.Audio = nil
 			segment.ProcessedAudio = nil
+			segment.Timings = nil
+			segment.Stream = nil
 			atomic.AddInt64(&aq.memoryUsage, -audioSize)
 			
 			// Return to pool if completely done
@@ -1085,7 +1380,7 @@ func (aq *TTSAudioQueue) WaitForReady(timeout time.Duration) error {
 			// Check if the current segment specifically is ready
 			if aq.currentIndex >= 0 && aq.currentIndex < len(aq.order) {
 				segmentID := aq.order[aq.currentIndex]
-				if segment, exists := aq.segments[segmentID]; exists && segment.Audio != nil {
+				if segment, exists := aq.segments[segmentID]; exists && segment.isReady() {
 					hasReady = true
 					readyCount = 1
 					log.Debug("TTS Queue: Current segment is ready", 
@@ -1096,7 +1391,7 @@ func (aq *TTSAudioQueue) WaitForReady(timeout time.Duration) error {
 			} else {
 				// If no current index set, check for any ready segment
 				for id, segment := range aq.segments {
-					if segment.Audio != nil {
+					if segment.isReady() {
 						hasReady = true
 						readyCount++
 						log.Debug("TTS Queue: Found ready segment", "id", id, "audioSize", len(segment.Audio))
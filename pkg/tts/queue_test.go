@@ -1,8 +1,10 @@
 package tts
 
 import (
+	"context"
 	"encoding/binary"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -335,6 +337,78 @@ func TestLookaheadBuffer(t *testing.T) {
 	}
 }
 
+func TestQueueStartPosition(t *testing.T) {
+	var mu sync.Mutex
+	synthesized := map[string]bool{}
+	engine := &mockQueueEngine{
+		available: true,
+		synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+			mu.Lock()
+			synthesized[text] = true
+			mu.Unlock()
+			return make([]byte, 100), nil
+		},
+	}
+	parser := &mockQueueParser{
+		parseFunc: func(text string) ([]Sentence, error) {
+			parts := strings.Split(text, ". ")
+			sentences := make([]Sentence, len(parts))
+			for i, part := range parts {
+				sentences[i] = Sentence{Text: part, Position: i}
+			}
+			return sentences, nil
+		},
+	}
+
+	config := createTestQueueConfig(engine, parser)
+	config.LookaheadSize = 1
+	queue, err := NewAudioQueue(config)
+	if err != nil {
+		t.Fatalf("Failed to create queue: %v", err)
+	}
+	defer queue.Stop()
+
+	queue.SetStartPosition(3)
+	if err := queue.AddText("Zero. One. Two. Three. Four. Five"); err != nil {
+		t.Fatalf("Failed to add text: %v", err)
+	}
+	if err := queue.WaitForReady(time.Second); err != nil {
+		t.Fatalf("WaitForReady failed: %v", err)
+	}
+
+	segment, err := queue.GetCurrent()
+	if err != nil {
+		t.Fatalf("GetCurrent failed: %v", err)
+	}
+	if segment.Text != "Three" || queue.CurrentPosition() != 3 {
+		t.Errorf("Expected to start at sentence 3, got %q at %d", segment.Text, queue.CurrentPosition())
+	}
+
+	mu.Lock()
+	skipped := synthesized["Zero"] || synthesized["One"] || synthesized["Two"]
+	mu.Unlock()
+	if skipped {
+		t.Error("Expected sentences before the start position not to be synthesized")
+	}
+
+	// Earlier sentences are still reachable
+	if _, err := queue.Previous(); err != nil {
+		t.Errorf("Expected to move back from the start position: %v", err)
+	}
+	if queue.CurrentPosition() != 2 {
+		t.Errorf("Expected position 2, got %d", queue.CurrentPosition())
+	}
+
+	queue.Clear()
+	if err := queue.AddText("Again. And again"); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if queue.CurrentPosition() != 0 {
+		t.Errorf("Expected Clear to reset the start position, got %d", queue.CurrentPosition())
+	}
+}
+
 func TestAudioPreprocessing(t *testing.T) {
 	config := createTestQueueConfig(
 		&mockQueueEngine{available: true},
@@ -727,4 +801,271 @@ func BenchmarkAudioPreprocessing(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_ = queue.preprocessAudio(audio)
 	}
-}
\ No newline at end of file
+}
+// mockStreamingEngine streams audio in two chunks, holding the second back
+type mockStreamingEngine struct {
+	mockQueueEngine
+	release chan struct{}
+}
+
+func (m *mockStreamingEngine) SynthesizeStream(ctx context.Context, text string, speed float64) (io.ReadCloser, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write(make([]byte, 100)) //nolint:errcheck
+		<-m.release
+		pw.Write(make([]byte, 100)) //nolint:errcheck
+		pw.Close()
+	}()
+	return pr, nil
+}
+
+func TestQueueStreamingSynthesis(t *testing.T) {
+	engine := &mockStreamingEngine{
+		mockQueueEngine: mockQueueEngine{available: true},
+		release:         make(chan struct{}),
+	}
+	config := createTestQueueConfig(engine, &mockQueueParser{})
+	queue, err := NewAudioQueue(config)
+	if err != nil {
+		t.Fatalf("Failed to create queue: %v", err)
+	}
+	defer queue.Stop()
+
+	if err := queue.AddText("Streaming sentence."); err != nil {
+		t.Fatalf("Failed to add text: %v", err)
+	}
+
+	// Ready as soon as the first chunk arrives
+	if err := queue.WaitForReady(time.Second); err != nil {
+		t.Fatalf("WaitForReady failed: %v", err)
+	}
+	segment, err := queue.GetCurrent()
+	if err != nil {
+		t.Fatalf("GetCurrent failed: %v", err)
+	}
+
+	queue.mu.RLock()
+	stream, audio := segment.Stream, segment.Audio
+	queue.mu.RUnlock()
+	if stream == nil || audio != nil {
+		t.Fatal("Expected a stream before synthesis finished")
+	}
+
+	close(engine.release)
+	deadline := time.Now().Add(time.Second)
+	for {
+		queue.mu.RLock()
+		audio = segment.Audio
+		queue.mu.RUnlock()
+		if audio != nil || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if len(audio) != 200 {
+		t.Errorf("Expected complete audio of 200 bytes, got %d", len(audio))
+	}
+}
+
+// mockContextEngine returns audio for "Zero" and blocks on anything else
+// until its context is cancelled
+type mockContextEngine struct {
+	mockQueueEngine
+	mu        sync.Mutex
+	cancelled []string
+}
+
+func (m *mockContextEngine) SynthesizeContext(ctx context.Context, text string, speed float64) ([]byte, error) {
+	if text == "Zero" {
+		return make([]byte, 100), nil
+	}
+	<-ctx.Done()
+	m.mu.Lock()
+	m.cancelled = append(m.cancelled, text)
+	m.mu.Unlock()
+	return nil, ctx.Err()
+}
+
+func (m *mockContextEngine) wasCancelled(text string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.cancelled {
+		if c == text {
+			return true
+		}
+	}
+	return false
+}
+
+func TestQueueCancelsSupersededSynthesis(t *testing.T) {
+	engine := &mockContextEngine{mockQueueEngine: mockQueueEngine{available: true}}
+	parser := &mockQueueParser{
+		parseFunc: func(text string) ([]Sentence, error) {
+			parts := strings.Split(text, ". ")
+			sentences := make([]Sentence, len(parts))
+			for i, part := range parts {
+				sentences[i] = Sentence{Text: part, Position: i}
+			}
+			return sentences, nil
+		},
+	}
+
+	config := createTestQueueConfig(engine, parser)
+	config.LookaheadSize = 1
+	queue, err := NewAudioQueue(config)
+	if err != nil {
+		t.Fatalf("Failed to create queue: %v", err)
+	}
+
+	var errs []error
+	var errMu sync.Mutex
+	queue.SetCallbacks(nil, nil, func(err error) {
+		errMu.Lock()
+		errs = append(errs, err)
+		errMu.Unlock()
+	})
+
+	if err := queue.AddText("Zero. One. Two. Three. Four"); err != nil {
+		t.Fatalf("Failed to add text: %v", err)
+	}
+	if err := queue.WaitForReady(time.Second); err != nil {
+		t.Fatalf("WaitForReady failed: %v", err)
+	}
+
+	// "One" is in the lookahead window until we skip past it
+	waitFor := func(text string) bool {
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if engine.wasCancelled(text) {
+				return true
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		return false
+	}
+	if engine.wasCancelled("One") {
+		t.Fatal("Expected synthesis within the lookahead window to continue")
+	}
+	if _, err := queue.Skip(3); err != nil {
+		t.Fatalf("Skip failed: %v", err)
+	}
+	if !waitFor("One") {
+		t.Error("Expected synthesis of the skipped sentence to be cancelled")
+	}
+
+	// Stopping the queue abandons the rest without waiting on the engine
+	stopped := make(chan struct{})
+	go func() {
+		queue.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected Stop to cancel synthesis in progress")
+	}
+
+	errMu.Lock()
+	defer errMu.Unlock()
+	if len(errs) > 0 {
+		t.Errorf("Expected cancellation not to be reported as an error, got %v", errs)
+	}
+}
+
+func TestQueueAppliesLexicon(t *testing.T) {
+	var mu sync.Mutex
+	var spoken []string
+	engine := &mockQueueEngine{
+		available: true,
+		synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+			mu.Lock()
+			spoken = append(spoken, text)
+			mu.Unlock()
+			return make([]byte, 100), nil
+		},
+	}
+
+	lexicon, err := NewLexicon([]LexiconRule{{Match: "kubectl", Say: "cube control"}})
+	if err != nil {
+		t.Fatalf("NewLexicon failed: %v", err)
+	}
+	config := createTestQueueConfig(engine, &mockQueueParser{})
+	config.Lexicon = lexicon
+	queue, err := NewAudioQueue(config)
+	if err != nil {
+		t.Fatalf("Failed to create queue: %v", err)
+	}
+	defer queue.Stop()
+
+	if err := queue.AddText("Run kubectl apply"); err != nil {
+		t.Fatalf("Failed to add text: %v", err)
+	}
+	if err := queue.WaitForReady(time.Second); err != nil {
+		t.Fatalf("WaitForReady failed: %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(spoken) != 1 || spoken[0] != "Run cube control apply" {
+		t.Errorf("Expected engine to receive lexicon output, got %q", spoken)
+	}
+}
+
+func TestQueueRoutesLanguages(t *testing.T) {
+	var mu sync.Mutex
+	voices := make(map[string]string)
+	record := func(voice string) *mockQueueEngine {
+		return &mockQueueEngine{
+			available: true,
+			name:      voice,
+			synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+				mu.Lock()
+				voices[text] = voice
+				mu.Unlock()
+				return make([]byte, 100), nil
+			},
+		}
+	}
+
+	parser := &mockQueueParser{parseFunc: func(string) ([]Sentence, error) {
+		return []Sentence{
+			{Text: "Hello there", Position: 0},
+			{Text: "Guten Tag", Position: 1, Language: "de"},
+			{Text: "Hola amigos", Position: 2, Language: "es-MX"},
+		}, nil
+	}}
+	config := createTestQueueConfig(record("main"), parser)
+	config.LanguageEngines = map[string]TTSEngine{
+		"de": record("german"),
+		"es": record("spanish"),
+	}
+	queue, err := NewAudioQueue(config)
+	if err != nil {
+		t.Fatalf("Failed to create queue: %v", err)
+	}
+	defer queue.Stop()
+
+	if err := queue.AddText("ignored"); err != nil {
+		t.Fatalf("Failed to add text: %v", err)
+	}
+
+	want := map[string]string{"Hello there": "main", "Guten Tag": "german", "Hola amigos": "spanish"}
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		done := len(voices) == len(want)
+		mu.Unlock()
+		if done {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for text, voice := range want {
+		if voices[text] != voice {
+			t.Errorf("Expected %q to be spoken by %s, got %q", text, voice, voices[text])
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/log"
+)
+
+// readerPollInterval is how often the reader checks on playback
+const readerPollInterval = 100 * time.Millisecond
+
+// ReaderConfig configures a headless reader
+type ReaderConfig struct {
+	// Document is the path reported by Status, if the text came from a file
+	Document string
+	// OnSentence is called as each sentence starts, with its index
+	OnSentence func(index int, sentence Sentence)
+}
+
+// Reader plays a document from start to finish without a UI, following
+// the queue from sentence to sentence the way the TUI does. It implements
+// ControlTarget, so it can be driven from the control socket.
+type Reader struct {
+	controller *Controller
+	text       string
+	sentences  []Sentence
+	config     ReaderConfig
+
+	mu       sync.Mutex
+	started  bool
+	paused   bool
+	done     bool
+	reported int // sentence last passed to OnSentence
+}
+
+// NewReader creates a reader for text on an initialized controller
+func NewReader(controller *Controller, text string, config ReaderConfig) (*Reader, error) {
+	if controller == nil || controller.parser == nil {
+		return nil, errors.New("controller is not initialized")
+	}
+	sentences, err := controller.parser.ParseSentences(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse text: %w", err)
+	}
+	if len(sentences) == 0 {
+		return nil, errors.New("no text to read")
+	}
+	return &Reader{
+		controller: controller,
+		text:       text,
+		sentences:  sentences,
+		config:     config,
+		reported:   -1,
+	}, nil
+}
+
+// Sentences returns the sentences of the document
+func (r *Reader) Sentences() []Sentence {
+	return r.sentences
+}
+
+// Run reads the document from sentence start until it ends or ctx is done
+func (r *Reader) Run(ctx context.Context, start int) error {
+	if err := r.Seek(start); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(readerPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			if player := GetGlobalAudioPlayer(); player != nil {
+				player.Stop()
+			}
+			return nil
+		case <-ticker.C:
+			if r.step() {
+				return nil
+			}
+		}
+	}
+}
+
+// step reports the current sentence and moves to the next one when its
+// audio has finished. It reports true once the document has been read.
+func (r *Reader) step() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.done {
+		return true
+	}
+	r.report()
+	if r.paused {
+		return false
+	}
+
+	player := GetGlobalAudioPlayer()
+	if player == nil || player.GetState() != PlaybackStopped {
+		return false
+	}
+	if err := r.controller.Next(); err != nil {
+		log.Debug("Reader: no more segments to play", "error", err)
+		r.done = true
+		return true
+	}
+	r.report()
+	return false
+}
+
+// report calls OnSentence when playback has moved to another sentence
+func (r *Reader) report() {
+	index := r.controller.CurrentSentence()
+	if index == r.reported || index < 0 || index >= len(r.sentences) {
+		return
+	}
+	r.reported = index
+	if r.config.OnSentence != nil {
+		r.config.OnSentence(index, r.sentences[index])
+	}
+}
+
+// Play resumes reading after Pause
+func (r *Reader) Play() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.paused {
+		return nil
+	}
+	if err := r.controller.Resume(); err != nil {
+		return err
+	}
+	r.paused = false
+	return nil
+}
+
+// Pause pauses reading
+func (r *Reader) Pause() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.paused || !r.started {
+		return nil
+	}
+	if err := r.controller.Pause(); err != nil {
+		return err
+	}
+	r.paused = true
+	return nil
+}
+
+// Toggle pauses or resumes reading
+func (r *Reader) Toggle() error {
+	r.mu.Lock()
+	paused := r.paused
+	r.mu.Unlock()
+
+	if paused {
+		return r.Play()
+	}
+	return r.Pause()
+}
+
+// Next skips to the next sentence
+func (r *Reader) Next() error {
+	return r.navigate(r.controller.Next, "end of queue")
+}
+
+// Previous goes back to the previous sentence
+func (r *Reader) Previous() error {
+	return r.navigate(r.controller.Previous, "beginning of queue")
+}
+
+// navigate moves through the queue; running into boundary is not an error
+func (r *Reader) navigate(move func() error, boundary string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := move(); err != nil && !strings.Contains(err.Error(), boundary) {
+		return err
+	}
+	r.paused = false
+	r.report()
+	return nil
+}
+
+// Seek plays from a sentence, counted from 0
+func (r *Reader) Seek(sentence int) error {
+	if sentence < 0 || sentence >= len(r.sentences) {
+		return fmt.Errorf("the document has %d sentences", len(r.sentences))
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var err error
+	if !r.started && sentence == 0 {
+		err = r.controller.Play(r.text)
+	} else {
+		err = r.controller.PlayFrom(r.text, sentence)
+	}
+	if err != nil {
+		return err
+	}
+	r.started, r.paused = true, false
+	r.report()
+	return nil
+}
+
+// SetSpeed sets the playback speed
+func (r *Reader) SetSpeed(speed float64) error {
+	return r.controller.SetSpeed(speed)
+}
+
+// Status reports the state of reading
+func (r *Reader) Status() (ControlStatusResult, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	status := ControlStatusResult{
+		State:     PlaybackPlaying.String(),
+		Sentence:  -1,
+		Sentences: len(r.sentences),
+		Speed:     r.controller.GetSpeed(),
+		Document:  r.config.Document,
+	}
+	switch {
+	case !r.started || r.done:
+		status.State = PlaybackStopped.String()
+		return status, nil
+	case r.paused:
+		status.State = PlaybackPaused.String()
+	}
+	if index := r.controller.CurrentSentence(); index >= 0 && index < len(r.sentences) {
+		status.Sentence = index
+		status.Text = r.sentences[index].Text
+	}
+	return status, nil
+}
@@ -0,0 +1,70 @@
+package tts
+
+import (
+	"context"
+	"testing"
+)
+
+// newTestReaderController returns an initialized controller whose parser
+// splits every document into sentences
+func newTestReaderController(t *testing.T, sentences []Sentence) *Controller {
+	t.Helper()
+
+	controller, err := NewController(ControllerConfig{Engine: "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	controller.SetEngine(&mockEngine{name: "test", available: true})
+	controller.SetParser(&mockParser{sentences: sentences})
+	controller.SetSpeedController(newMockSpeedController())
+	if err := controller.Initialize(); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	t.Cleanup(func() { controller.GetQueue().Stop() })
+	return controller
+}
+
+func TestReader(t *testing.T) {
+	var reported []int
+	reader, err := NewReader(newTestReaderController(t, []Sentence{{Text: "One."}, {Text: "Two."}}), "One. Two.", ReaderConfig{
+		Document:   "/docs/notes.md",
+		OnSentence: func(index int, _ Sentence) { reported = append(reported, index) },
+	})
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+	if len(reader.Sentences()) != 2 {
+		t.Errorf("Expected 2 sentences, got %d", len(reader.Sentences()))
+	}
+
+	status, err := reader.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.State != "stopped" || status.Sentence != -1 || status.Sentences != 2 || status.Document != "/docs/notes.md" {
+		t.Errorf("Unexpected status before reading: %+v", status)
+	}
+
+	// Pausing before reading starts has nothing to pause
+	if err := reader.Pause(); err != nil {
+		t.Errorf("Expected Pause before reading to be a no-op, got %v", err)
+	}
+	if err := reader.Seek(2); err == nil {
+		t.Error("Expected an error seeking past the last sentence")
+	}
+	if err := reader.Run(context.Background(), -1); err == nil {
+		t.Error("Expected an error starting before the first sentence")
+	}
+	if len(reported) != 0 {
+		t.Errorf("Expected no sentences reported, got %v", reported)
+	}
+}
+
+func TestNewReaderErrors(t *testing.T) {
+	if _, err := NewReader(nil, "text", ReaderConfig{}); err == nil {
+		t.Error("Expected an error without a controller")
+	}
+	if _, err := NewReader(newTestReaderController(t, nil), "", ReaderConfig{}); err == nil {
+		t.Error("Expected an error for a document without sentences")
+	}
+}
@@ -0,0 +1,258 @@
+package tts
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"math"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/log"
+)
+
+// streamWAVDataLength is the data size advertised by streamed WAV files,
+// whose real length is unknown until synthesis ends. Players read until the
+// connection closes.
+const streamWAVDataLength = math.MaxInt32 - WAVHeaderSize
+
+// ServerConfig holds settings for serving documents as audio
+type ServerConfig struct {
+	// Root is the directory documents are read from
+	Root string
+	// Documents lists the documents to serve as slash-separated paths
+	// relative to Root, in the order the index shows them
+	Documents func() ([]string, error)
+	// Export configures synthesis; set its Cache to share audio between
+	// requests
+	Export *ExportConfig
+	// LoadLexicon returns the lexicon for a document path (optional)
+	LoadLexicon func(path string) (*Lexicon, error)
+	// Title heads the index page
+	Title string
+}
+
+// Server serves the markdown documents of a directory as streamed WAV
+// audio with JSON transcripts:
+//
+//	GET /                        index of documents
+//	GET /audio/<path>.wav        document audio, synthesized as it streams
+//	GET /transcript/<path>.json  manifest of the document's sentences
+//
+// <path> is a document's path without its markdown extension.
+type Server struct {
+	engine TTSEngine
+	config ServerConfig
+	mux    *http.ServeMux
+}
+
+// NewServer creates a server that synthesizes documents with engine
+func NewServer(engine TTSEngine, config ServerConfig) (*Server, error) {
+	if engine == nil {
+		return nil, errors.New("no TTS engine configured")
+	}
+	if config.Documents == nil {
+		return nil, errors.New("no documents to serve")
+	}
+	if config.Export == nil {
+		config.Export = DefaultExportConfig()
+	}
+	if config.Title == "" {
+		config.Title = "glow-tts"
+	}
+
+	s := &Server{engine: engine, config: config, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /{$}", s.handleIndex)
+	s.mux.HandleFunc("GET /audio/{path...}", s.handleAudio)
+	s.mux.HandleFunc("GET /transcript/{path...}", s.handleTranscript)
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// serverDocument is a document as the index lists it
+type serverDocument struct {
+	Path string
+	// Name is Path without its markdown extension, as used in URLs
+	Name string
+}
+
+var serverIndex = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; max-width: 48em; margin: 0 auto; padding: 1em; }
+li { margin-bottom: 1em; list-style: none; }
+audio { display: block; width: 100%; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<ul>
+{{- range .Documents}}
+<li>{{.Path}} <a href="/transcript/{{.Name}}.json">transcript</a>
+<audio controls preload="none" src="/audio/{{.Name}}.wav"></audio></li>
+{{- else}}
+<li>No markdown files found.</li>
+{{- end}}
+</ul>
+</body>
+</html>
+`))
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	documents, err := s.documents()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	data := struct {
+		Title     string
+		Documents []serverDocument
+	}{s.config.Title, documents}
+	if err := serverIndex.Execute(w, data); err != nil {
+		log.Warn("Failed to write index", "error", err)
+	}
+}
+
+func (s *Server) handleAudio(w http.ResponseWriter, r *http.Request) {
+	document, exporter, markdown, ok := s.open(w, r, ".wav")
+	if !ok {
+		return
+	}
+	sentences, err := exporter.Parse(markdown)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// The header goes out with the first sentence, so a document that fails
+	// to synthesize at all still gets an error status
+	started := false
+	err = exporter.Stream(r.Context(), sentences, func(_ ExportSegment, audio []byte) error {
+		if !started {
+			header, err := WAVHeader(streamWAVDataLength, DefaultPCMFormat())
+			if err != nil {
+				return err
+			}
+			w.Header().Set("Content-Type", "audio/wav")
+			w.Header().Set("Cache-Control", "no-store")
+			if _, err := w.Write(header); err != nil {
+				return err
+			}
+			started = true
+		}
+		if _, err := w.Write(audio); err != nil {
+			return err
+		}
+		return http.NewResponseController(w).Flush()
+	})
+	switch {
+	case err == nil:
+	case !started:
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	case r.Context().Err() == nil:
+		log.Warn("Audio stream ended early", "document", document.Path, "error", err)
+	}
+}
+
+func (s *Server) handleTranscript(w http.ResponseWriter, r *http.Request) {
+	document, exporter, markdown, ok := s.open(w, r, ".json")
+	if !ok {
+		return
+	}
+	sentences, err := exporter.Parse(markdown)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	// Only the timings are needed, so the audio is dropped as it arrives
+	result := &ExportResult{Format: exporter.format}
+	err = exporter.Stream(r.Context(), sentences, func(segment ExportSegment, _ []byte) error {
+		segment.Document = document.Path
+		result.Segments = append(result.Segments, segment)
+		return nil
+	})
+	if err != nil {
+		if r.Context().Err() == nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	setSourceLines(result.Segments, markdown, sentences)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := result.WriteManifest(w); err != nil {
+		log.Warn("Failed to write transcript", "document", document.Path, "error", err)
+	}
+}
+
+// open reads the document a request names with the given extension and
+// creates an exporter for it, or writes an error response
+func (s *Server) open(w http.ResponseWriter, r *http.Request, ext string) (serverDocument, *Exporter, string, bool) {
+	name, found := strings.CutSuffix(r.PathValue("path"), ext)
+	if !found {
+		http.NotFound(w, r)
+		return serverDocument{}, nil, "", false
+	}
+	documents, err := s.documents()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return serverDocument{}, nil, "", false
+	}
+
+	// Only listed documents are served, so paths cannot escape Root
+	for _, document := range documents {
+		if document.Name != name {
+			continue
+		}
+		file := filepath.Join(s.config.Root, filepath.FromSlash(document.Path))
+		b, err := os.ReadFile(file)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return document, nil, "", false
+		}
+
+		config := *s.config.Export
+		config.OnProgress = nil
+		if s.config.LoadLexicon != nil {
+			if config.Lexicon, err = s.config.LoadLexicon(file); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return document, nil, "", false
+			}
+		}
+		exporter, err := NewExporter(s.engine, &config)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return document, nil, "", false
+		}
+		return document, exporter, string(b), true
+	}
+
+	http.NotFound(w, r)
+	return serverDocument{}, nil, "", false
+}
+
+// documents returns the documents to serve
+func (s *Server) documents() ([]serverDocument, error) {
+	paths, err := s.config.Documents()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list documents: %w", err)
+	}
+	documents := make([]serverDocument, 0, len(paths))
+	for _, p := range paths {
+		documents = append(documents, serverDocument{Path: p, Name: strings.TrimSuffix(p, path.Ext(p))})
+	}
+	return documents, nil
+}
@@ -0,0 +1,205 @@
+package tts
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newTestServer serves two documents from a temporary directory with a
+// mock engine producing 100ms of audio per sentence, and counts synthesis
+func newTestServer(t *testing.T, cache *TTSCacheManager) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"README.md":      "# Guide\n\nStart here. Then read on.",
+		"docs/notes.md":  "Just notes.",
+		"secret.md":      "Not listed.",
+		"docs/empty.md":  "",
+		"docs/broken.md": "Fails to speak.",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var calls atomic.Int32
+	engine := &mockQueueEngine{
+		name:      "mock",
+		available: true,
+		synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+			if strings.Contains(text, "Fails") {
+				return nil, errors.New("engine failed")
+			}
+			calls.Add(1)
+			return make([]byte, 2205*DefaultPCMFormat().BytesPerSample()), nil
+		},
+	}
+	config := DefaultExportConfig()
+	config.Cache = cache
+	handler, err := NewServer(engine, ServerConfig{
+		Root: root,
+		Documents: func() ([]string, error) {
+			return []string{"README.md", "docs/notes.md", "docs/empty.md", "docs/broken.md"}, nil
+		},
+		Export: config,
+		Title:  "Docs",
+	})
+	if err != nil {
+		t.Fatalf("NewServer failed: %v", err)
+	}
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+// get fetches a path and returns the status and body
+func get(t *testing.T, server *httptest.Server, path string) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := http.Get(server.URL + path)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Reading %s failed: %v", path, err)
+	}
+	return resp, body
+}
+
+func TestServerIndex(t *testing.T) {
+	server, _ := newTestServer(t, nil)
+
+	resp, body := get(t, server, "/")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	page := string(body)
+	readme := strings.Index(page, `src="/audio/README.wav"`)
+	notes := strings.Index(page, `src="/audio/docs/notes.wav"`)
+	if readme < 0 || notes < readme {
+		t.Errorf("Expected documents listed in order, got:\n%s", page)
+	}
+	if !strings.Contains(page, `href="/transcript/docs/notes.json"`) || !strings.Contains(page, "<title>Docs</title>") {
+		t.Errorf("Expected transcript links and the title, got:\n%s", page)
+	}
+}
+
+func TestServerAudio(t *testing.T) {
+	server, _ := newTestServer(t, nil)
+
+	resp, body := get(t, server, "/audio/README.wav")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	if resp.Header.Get("Content-Type") != "audio/wav" {
+		t.Errorf("Expected audio/wav, got %q", resp.Header.Get("Content-Type"))
+	}
+	if len(resp.TransferEncoding) == 0 || resp.TransferEncoding[0] != "chunked" {
+		t.Errorf("Expected a chunked response, got %v", resp.TransferEncoding)
+	}
+
+	pcm, format, err := DecodeWAV(body)
+	if err != nil {
+		t.Fatalf("Invalid WAV: %v", err)
+	}
+	if format.SampleRate != SampleRate {
+		t.Errorf("Expected %d Hz, got %d", SampleRate, format.SampleRate)
+	}
+
+	// The stream carries the same audio as an export
+	exported := exportTestDocument(t, "# Guide\n\nStart here. Then read on.")
+	if !bytes.Equal(pcm, exported.Audio) {
+		t.Errorf("Expected %d bytes of exported audio, got %d", len(exported.Audio), len(pcm))
+	}
+}
+
+func TestServerTranscript(t *testing.T) {
+	server, _ := newTestServer(t, nil)
+
+	resp, body := get(t, server, "/transcript/README.json")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", resp.StatusCode, body)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		t.Fatalf("Invalid transcript: %v", err)
+	}
+	if len(manifest.Segments) != 3 {
+		t.Fatalf("Expected 3 segments, got %+v", manifest.Segments)
+	}
+	first, last := manifest.Segments[0], manifest.Segments[2]
+	if first.Type != "heading" || first.FirstLine != 1 || first.Document != "README.md" {
+		t.Errorf("Unexpected first segment: %+v", first)
+	}
+	if last.FirstLine != 3 || last.End != manifest.Duration {
+		t.Errorf("Unexpected last segment: %+v (duration %v)", last, manifest.Duration)
+	}
+
+	// The timings match an export of the same document
+	exported := exportTestDocument(t, "# Guide\n\nStart here. Then read on.").Manifest()
+	if manifest.Duration != exported.Duration || last.Start != exported.Segments[2].Start {
+		t.Errorf("Expected the exported timings %+v, got %+v", exported, manifest)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	server, _ := newTestServer(t, nil)
+
+	for path, want := range map[string]int{
+		"/audio/secret.wav":            http.StatusNotFound,
+		"/audio/../secret.wav":         http.StatusNotFound,
+		"/audio/README.mp3":            http.StatusNotFound,
+		"/transcript/missing.json":     http.StatusNotFound,
+		"/audio/docs/empty.wav":        http.StatusUnprocessableEntity,
+		"/audio/docs/broken.wav":       http.StatusInternalServerError,
+		"/transcript/docs/notes.wav":   http.StatusNotFound,
+		"/transcript/docs/empty.json":  http.StatusUnprocessableEntity,
+		"/transcript/docs/broken.json": http.StatusInternalServerError,
+	} {
+		if resp, _ := get(t, server, path); resp.StatusCode != want {
+			t.Errorf("GET %s: expected %d, got %d", path, want, resp.StatusCode)
+		}
+	}
+}
+
+func TestServerSharedCache(t *testing.T) {
+	config := DefaultCacheConfig()
+	config.CacheDir = t.TempDir()
+	cache, err := NewTTSCacheManager(config)
+	if err != nil {
+		t.Fatalf("Failed to create cache manager: %v", err)
+	}
+	defer cache.Close()
+
+	server, calls := newTestServer(t, cache)
+	get(t, server, "/transcript/README.json")
+	synthesized := calls.Load()
+	if synthesized != 3 {
+		t.Fatalf("Expected 3 sentences synthesized, got %d", synthesized)
+	}
+
+	resp, _ := get(t, server, "/audio/README.wav")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	if calls.Load() != synthesized {
+		t.Errorf("Expected the stream to reuse cached audio, got %d more syntheses", calls.Load()-synthesized)
+	}
+}
@@ -0,0 +1,192 @@
+package tts
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Sidecar file extensions written next to exported audio
+var sidecarExtensions = []string{".srt", ".vtt", ".json"}
+
+// SourceLines returns the 1-based first and last source line of each
+// sentence parsed from markdown, or 0, 0 for sentences that cannot be
+// found, such as the front matter announcement. Sentences are located in
+// order, so repeated text maps to successive occurrences, and a sentence
+// runs until the next one starts or its paragraph ends.
+func SourceLines(markdown string, sentences []ParsedSentence) [][2]int {
+	_, body, _ := ParseFrontMatter(markdown)
+	cursor := len(markdown) - len(body)
+
+	starts := make([]int, len(sentences))
+	for i, s := range sentences {
+		starts[i] = -1
+		for _, needle := range []string{s.Original, s.Text} {
+			pattern := sourcePattern(needle)
+			if pattern == nil {
+				continue
+			}
+			if match := pattern.FindStringIndex(markdown[cursor:]); match != nil {
+				starts[i] = cursor + match[0]
+				// The next sentence may start inside a loosely matched Original
+				cursor = starts[i] + 1
+				break
+			}
+		}
+	}
+
+	lines := make([][2]int, len(sentences))
+	for i, start := range starts {
+		if start < 0 {
+			continue
+		}
+		end := len(markdown)
+		for _, next := range starts[i+1:] {
+			if next > start {
+				end = next
+				break
+			}
+		}
+		region := markdown[start:end]
+		if blank := strings.Index(region, "\n\n"); blank >= 0 {
+			region = region[:blank]
+		}
+		first := strings.Count(markdown[:start], "\n") + 1
+		lines[i] = [2]int{first, first + strings.Count(strings.TrimRight(region, " \t\r\n"), "\n")}
+	}
+	return lines
+}
+
+// sourcePattern matches text in markdown regardless of how it is wrapped
+func sourcePattern(text string) *regexp.Regexp {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	for i, word := range words {
+		words[i] = regexp.QuoteMeta(word)
+	}
+	return regexp.MustCompile(strings.Join(words, `\s+`))
+}
+
+// Manifest maps each exported sentence to its place in the audio
+type Manifest struct {
+	// Duration is the length of the audio in seconds
+	Duration   float64           `json:"duration"`
+	SampleRate int               `json:"sample_rate"`
+	Segments   []ManifestSegment `json:"segments"`
+}
+
+// ManifestSegment is one sentence of a manifest; times are in seconds
+type ManifestSegment struct {
+	Index     int     `json:"index"`
+	Document  string  `json:"document,omitempty"`
+	Text      string  `json:"text"`
+	Spoken    string  `json:"spoken,omitempty"`
+	Original  string  `json:"original,omitempty"`
+	Type      string  `json:"type"`
+	Language  string  `json:"language,omitempty"`
+	FirstLine int     `json:"first_line,omitempty"`
+	LastLine  int     `json:"last_line,omitempty"`
+	Start     float64 `json:"start"`
+	End       float64 `json:"end"`
+}
+
+// Manifest returns the manifest of the exported audio
+func (r *ExportResult) Manifest() Manifest {
+	manifest := Manifest{
+		Duration:   r.Duration().Seconds(),
+		SampleRate: r.Format.SampleRate,
+		Segments:   make([]ManifestSegment, 0, len(r.Segments)),
+	}
+	for i, segment := range r.Segments {
+		s := segment.Sentence
+		manifest.Segments = append(manifest.Segments, ManifestSegment{
+			Index:     i,
+			Document:  segment.Document,
+			Text:      s.Text,
+			Spoken:    s.Spoken,
+			Original:  s.Original,
+			Type:      s.Type.String(),
+			Language:  s.Language,
+			FirstLine: segment.FirstLine,
+			LastLine:  segment.LastLine,
+			Start:     segment.Offset.Seconds(),
+			End:       (segment.Offset + segment.Duration).Seconds(),
+		})
+	}
+	return manifest
+}
+
+// WriteManifest writes the manifest as indented JSON
+func (r *ExportResult) WriteManifest(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r.Manifest())
+}
+
+// WriteSRT writes a SubRip cue for each sentence
+func (r *ExportResult) WriteSRT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	for i, segment := range r.Segments {
+		fmt.Fprintf(bw, "%d\n%s --> %s\n%s\n\n", i+1,
+			cueTimestamp(segment.Offset, ","), cueTimestamp(segment.Offset+segment.Duration, ","), cueText(segment.Sentence))
+	}
+	return bw.Flush()
+}
+
+// WriteWebVTT writes a WebVTT cue for each sentence
+func (r *ExportResult) WriteWebVTT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("WEBVTT\n\n")
+	escape := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	for i, segment := range r.Segments {
+		fmt.Fprintf(bw, "%d\n%s --> %s\n%s\n\n", i+1,
+			cueTimestamp(segment.Offset, "."), cueTimestamp(segment.Offset+segment.Duration, "."), escape.Replace(cueText(segment.Sentence)))
+	}
+	return bw.Flush()
+}
+
+// WriteSidecars writes SRT, WebVTT and JSON manifest files named after
+// base, e.g. talk.srt for the base talk, and returns their paths
+func (r *ExportResult) WriteSidecars(base string) ([]string, error) {
+	writers := []func(io.Writer) error{r.WriteSRT, r.WriteWebVTT, r.WriteManifest}
+	paths := make([]string, 0, len(writers))
+	for i, write := range writers {
+		path := base + sidecarExtensions[i]
+		f, err := os.Create(path)
+		if err != nil {
+			return paths, fmt.Errorf("unable to create %s: %w", path, err)
+		}
+		if err := write(f); err != nil {
+			_ = f.Close()
+			return paths, fmt.Errorf("unable to write %s: %w", path, err)
+		}
+		if err := f.Close(); err != nil {
+			return paths, fmt.Errorf("unable to write %s: %w", path, err)
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// cueText is the caption for a sentence: its text on one line, or what is
+// spoken for sentences that are not displayed
+func cueText(s ParsedSentence) string {
+	text := s.Text
+	if text == "" {
+		text = s.SpokenText()
+	}
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// cueTimestamp formats d as HH:MM:SS followed by sep and milliseconds
+func cueTimestamp(d time.Duration, sep string) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%s%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
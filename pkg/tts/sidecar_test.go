@@ -0,0 +1,124 @@
+package tts
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSourceLines(t *testing.T) {
+	markdown := "---\ntitle: Notes\n---\n# Notes\n\nRun it.\nThen wait\nfor it.\n\n- Run it.\n"
+	sentences := []ParsedSentence{
+		{Text: "Document: Notes."},
+		{Text: "Notes", Original: "# Notes"},
+		{Text: "Run it."},
+		{Text: "Then wait for it."},
+		{Text: "Run it."},
+	}
+
+	got := SourceLines(markdown, sentences)
+	want := [][2]int{{0, 0}, {4, 4}, {6, 6}, {7, 8}, {10, 10}}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Sentence %d (%q): expected lines %v, got %v", i, sentences[i].Text, want[i], got[i])
+		}
+	}
+}
+
+func TestExportSourceLines(t *testing.T) {
+	result := exportTestDocument(t, "# Title\n\nFirst line.\n\nSecond line.")
+	lines := map[string]int{}
+	for _, segment := range result.Segments {
+		lines[segment.Sentence.Text] = segment.FirstLine
+	}
+	if lines["Title"] != 1 || lines["First line"] != 3 || lines["Second line"] != 5 {
+		t.Errorf("Unexpected source lines: %v", lines)
+	}
+}
+
+// sidecarTestResult has two sentences of 1.5s and 2.5s
+func sidecarTestResult() *ExportResult {
+	return &ExportResult{
+		Format: DefaultPCMFormat(),
+		Audio:  make([]byte, 22050*2*4),
+		Segments: []ExportSegment{
+			{Sentence: ParsedSentence{Text: "Fish & chips", Type: SentenceTypeHeader, Original: "# Fish & chips"},
+				Duration: 1500 * time.Millisecond, FirstLine: 1, LastLine: 1},
+			{Sentence: ParsedSentence{Text: "Use <b>\nbold</b>.", Spoken: "Use bold."},
+				Offset: 1500 * time.Millisecond, Duration: 2500 * time.Millisecond, FirstLine: 3, LastLine: 4, Document: "a.md"},
+		},
+	}
+}
+
+func TestWriteSRT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sidecarTestResult().WriteSRT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "1\n00:00:00,000 --> 00:00:01,500\nFish & chips\n\n" +
+		"2\n00:00:01,500 --> 00:00:04,000\nUse <b> bold</b>.\n\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected SRT:\n%s", buf.String())
+	}
+}
+
+func TestWriteWebVTT(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sidecarTestResult().WriteWebVTT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := "WEBVTT\n\n1\n00:00:00.000 --> 00:00:01.500\nFish &amp; chips\n\n" +
+		"2\n00:00:01.500 --> 00:00:04.000\nUse &lt;b&gt; bold&lt;/b&gt;.\n\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected WebVTT:\n%s", buf.String())
+	}
+}
+
+func TestCueTimestamp(t *testing.T) {
+	if got := cueTimestamp(2*time.Hour+3*time.Minute+4*time.Second+5*time.Millisecond, ","); got != "02:03:04,005" {
+		t.Errorf("Expected 02:03:04,005, got %s", got)
+	}
+}
+
+func TestManifest(t *testing.T) {
+	var buf bytes.Buffer
+	if err := sidecarTestResult().WriteManifest(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(buf.Bytes(), &manifest); err != nil {
+		t.Fatalf("Invalid manifest JSON: %v", err)
+	}
+
+	if manifest.Duration != 4 || manifest.SampleRate != 22050 || len(manifest.Segments) != 2 {
+		t.Fatalf("Unexpected manifest: %+v", manifest)
+	}
+	heading, second := manifest.Segments[0], manifest.Segments[1]
+	if heading.Type != "heading" || heading.Original != "# Fish & chips" || heading.End != 1.5 {
+		t.Errorf("Unexpected heading: %+v", heading)
+	}
+	if second.Index != 1 || second.Start != 1.5 || second.End != 4 ||
+		second.FirstLine != 3 || second.LastLine != 4 || second.Document != "a.md" || second.Spoken != "Use bold." {
+		t.Errorf("Unexpected segment: %+v", second)
+	}
+}
+
+func TestWriteSidecars(t *testing.T) {
+	base := filepath.Join(t.TempDir(), "talk")
+	paths, err := sidecarTestResult().WriteSidecars(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(paths, " ") != base+".srt "+base+".vtt "+base+".json" {
+		t.Errorf("Unexpected sidecar paths: %v", paths)
+	}
+	for _, path := range paths {
+		if info, err := os.Stat(path); err != nil || info.Size() == 0 {
+			t.Errorf("Expected %s to be written: %v", path, err)
+		}
+	}
+}
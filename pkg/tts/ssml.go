@@ -0,0 +1,276 @@
+package tts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SpeechNodeKind identifies what a SpeechNode holds
+type SpeechNodeKind int
+
+const (
+	// SpeechText is plain text
+	SpeechText SpeechNodeKind = iota
+	// SpeechPause is a silence
+	SpeechPause
+	// SpeechEmphasis is text read with emphasis
+	SpeechEmphasis
+	// SpeechSayAs is text with a hint on how to read it
+	SpeechSayAs
+	// SpeechProsody is text read at a different rate or pitch
+	SpeechProsody
+)
+
+// Emphasis levels
+const (
+	EmphasisStrong   = "strong"
+	EmphasisModerate = "moderate"
+)
+
+// SpeechNode is one piece of a Speech
+type SpeechNode struct {
+	Kind SpeechNodeKind
+	// Text is what is said; empty for pauses
+	Text string
+	// Pause is the length of a pause
+	Pause time.Duration
+	// Level is the emphasis level
+	Level string
+	// InterpretAs is the say-as hint, such as "characters" or "cardinal"
+	InterpretAs string
+	// Rate and Pitch are prosody changes in SSML notation ("90%", "+10%")
+	Rate  string
+	Pitch string
+}
+
+// Speech is what an engine is asked to say: text with the pauses, emphasis,
+// heading prosody and say-as hints the markdown implies. Engines that accept
+// SSML receive it as markup; others get the text with the pauses as silence.
+type Speech struct {
+	// Language is the language code for xml:lang, or "" for the default
+	Language string
+	Nodes    []SpeechNode
+}
+
+// Say appends plain text
+func (s *Speech) Say(text string) {
+	if strings.TrimSpace(text) != "" {
+		s.Nodes = append(s.Nodes, SpeechNode{Kind: SpeechText, Text: text})
+	}
+}
+
+// Pause appends a silence
+func (s *Speech) Pause(d time.Duration) {
+	if d > 0 {
+		s.Nodes = append(s.Nodes, SpeechNode{Kind: SpeechPause, Pause: d})
+	}
+}
+
+// Emphasize appends text read with emphasis at level
+func (s *Speech) Emphasize(text, level string) {
+	if strings.TrimSpace(text) != "" {
+		s.Nodes = append(s.Nodes, SpeechNode{Kind: SpeechEmphasis, Text: text, Level: level})
+	}
+}
+
+// SayAs appends text with a say-as hint
+func (s *Speech) SayAs(text, interpretAs string) {
+	if strings.TrimSpace(text) != "" {
+		s.Nodes = append(s.Nodes, SpeechNode{Kind: SpeechSayAs, Text: text, InterpretAs: interpretAs})
+	}
+}
+
+// Prosody appends text read at another rate or pitch
+func (s *Speech) Prosody(text, rate, pitch string) {
+	if strings.TrimSpace(text) != "" {
+		s.Nodes = append(s.Nodes, SpeechNode{Kind: SpeechProsody, Text: text, Rate: rate, Pitch: pitch})
+	}
+}
+
+// IsPlain reports whether the speech is only text, with nothing an SSML
+// engine would read differently
+func (s Speech) IsPlain() bool {
+	for _, node := range s.Nodes {
+		if node.Kind != SpeechText {
+			return false
+		}
+	}
+	return true
+}
+
+// Text flattens the speech to the words it says, dropping pauses and hints
+func (s Speech) Text() string {
+	var words []string
+	for _, node := range s.Nodes {
+		if text := strings.TrimSpace(node.Text); text != "" {
+			words = append(words, text)
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Map returns a copy of the speech with fn applied to the text of each node,
+// e.g. to apply a lexicon
+func (s Speech) Map(fn func(string) string) Speech {
+	mapped := Speech{Language: s.Language, Nodes: make([]SpeechNode, len(s.Nodes))}
+	for i, node := range s.Nodes {
+		if node.Text != "" {
+			node.Text = fn(node.Text)
+		}
+		mapped.Nodes[i] = node
+	}
+	return mapped
+}
+
+// SSML renders the speech as an SSML <speak> document
+func (s Speech) SSML() string {
+	var b strings.Builder
+	b.WriteString("<speak")
+	if s.Language != "" {
+		fmt.Fprintf(&b, ` xml:lang="%s"`, escapeSSML(s.Language))
+	}
+	b.WriteString(">")
+
+	for i, node := range s.Nodes {
+		if i > 0 && node.Kind != SpeechPause && s.Nodes[i-1].Kind != SpeechPause {
+			b.WriteString(" ")
+		}
+		text := escapeSSML(strings.TrimSpace(node.Text))
+		switch node.Kind {
+		case SpeechPause:
+			fmt.Fprintf(&b, `<break time="%dms"/>`, node.Pause.Milliseconds())
+		case SpeechEmphasis:
+			level := node.Level
+			if level == "" {
+				level = EmphasisModerate
+			}
+			fmt.Fprintf(&b, `<emphasis level="%s">%s</emphasis>`, escapeSSML(level), text)
+		case SpeechSayAs:
+			fmt.Fprintf(&b, `<say-as interpret-as="%s">%s</say-as>`, escapeSSML(node.InterpretAs), text)
+		case SpeechProsody:
+			b.WriteString("<prosody")
+			if node.Rate != "" {
+				fmt.Fprintf(&b, ` rate="%s"`, escapeSSML(node.Rate))
+			}
+			if node.Pitch != "" {
+				fmt.Fprintf(&b, ` pitch="%s"`, escapeSSML(node.Pitch))
+			}
+			fmt.Fprintf(&b, ">%s</prosody>", text)
+		default:
+			b.WriteString(text)
+		}
+	}
+
+	b.WriteString("</speak>")
+	return b.String()
+}
+
+var ssmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+
+// escapeSSML escapes text for use in SSML content and attributes
+func escapeSSML(text string) string {
+	return ssmlEscaper.Replace(text)
+}
+
+// speechChunk is a run of text to synthesize, or a pause between runs
+type speechChunk struct {
+	text  string
+	pause time.Duration
+}
+
+// chunks splits the flattened speech at its pauses, merging adjacent
+// pauses and adjacent text
+func (s Speech) chunks() []speechChunk {
+	var chunks []speechChunk
+	var words []string
+	flush := func() {
+		if len(words) > 0 {
+			chunks = append(chunks, speechChunk{text: strings.Join(words, " ")})
+			words = nil
+		}
+	}
+	for _, node := range s.Nodes {
+		if node.Kind != SpeechPause {
+			if text := strings.TrimSpace(node.Text); text != "" {
+				words = append(words, text)
+			}
+			continue
+		}
+		flush()
+		if n := len(chunks); n > 0 && chunks[n-1].text == "" {
+			chunks[n-1].pause += node.Pause
+		} else {
+			chunks = append(chunks, speechChunk{pause: node.Pause})
+		}
+	}
+	flush()
+	return chunks
+}
+
+// SynthesizeSpeech synthesizes speech with engine. Engines that accept SSML
+// get the markup unless the speech is plain text; others synthesize the
+// text between pauses, with the pauses realised as silence scaled to speed.
+func SynthesizeSpeech(ctx context.Context, engine TTSEngine, speech Speech, speed float64) ([]byte, error) {
+	if strings.TrimSpace(speech.Text()) == "" {
+		return nil, errors.New("empty text")
+	}
+	if speed <= 0 {
+		speed = 1.0
+	}
+	if se, ok := engine.(SSMLEngine); ok && se.SupportsSSML() && !speech.IsPlain() {
+		return se.SynthesizeSSML(ctx, speech.SSML(), speed)
+	}
+
+	format := DefaultPCMFormat()
+	var audio []byte
+	for _, chunk := range speech.chunks() {
+		if chunk.text == "" {
+			audio = append(audio, GenerateSilence(chunk.pause.Seconds()/speed, format)...)
+			continue
+		}
+		pcm, err := SynthesizeContext(ctx, engine, chunk.text, speed)
+		if err != nil {
+			return nil, err
+		}
+		audio = append(audio, pcm...)
+	}
+	return audio, nil
+}
+
+// speechCacheText is the text cached audio for speech is keyed by. Plain
+// speech shares its cache entries with the same text synthesized directly.
+func speechCacheText(speech Speech) string {
+	if speech.IsPlain() {
+		return speech.Text()
+	}
+	return speech.SSML()
+}
+
+// headingPause is the silence around a heading, longer for higher levels
+func headingPause(level int) time.Duration {
+	return max(800*time.Millisecond-time.Duration(level-1)*100*time.Millisecond, 300*time.Millisecond)
+}
+
+// headingPitch raises the voice for top-level headings
+func headingPitch(level int) string {
+	switch level {
+	case 1:
+		return "+10%"
+	case 2:
+		return "+5%"
+	}
+	return ""
+}
+
+// headingSpeech is a heading set apart by pauses and read a little slower
+func headingSpeech(text string, level int, language string) Speech {
+	speech := Speech{Language: language}
+	pause := headingPause(level)
+	speech.Pause(pause)
+	speech.Prosody(text, "90%", headingPitch(level))
+	speech.Pause(pause / 2)
+	return speech
+}
@@ -0,0 +1,126 @@
+package tts
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockSSMLEngine records the SSML it is given
+type mockSSMLEngine struct {
+	mockQueueEngine
+	ssml []string
+}
+
+func (m *mockSSMLEngine) SupportsSSML() bool { return true }
+
+func (m *mockSSMLEngine) SynthesizeSSML(ctx context.Context, ssml string, speed float64) ([]byte, error) {
+	m.ssml = append(m.ssml, ssml)
+	return make([]byte, 100), nil
+}
+
+func TestSpeechSSML(t *testing.T) {
+	speech := Speech{Language: "en-GB"}
+	speech.Pause(500 * time.Millisecond)
+	speech.Prosody("Q&A", "90%", "+10%")
+	speech.Pause(250 * time.Millisecond)
+	speech.Say("Read")
+	speech.Emphasize("this", EmphasisStrong)
+	speech.SayAs("ls", "characters")
+
+	want := `<speak xml:lang="en-GB"><break time="500ms"/><prosody rate="90%" pitch="+10%">Q&amp;A</prosody><break time="250ms"/>Read <emphasis level="strong">this</emphasis> <say-as interpret-as="characters">ls</say-as></speak>`
+	if got := speech.SSML(); got != want {
+		t.Errorf("SSML:\n got %s\nwant %s", got, want)
+	}
+	if got := speech.Text(); got != "Q&A Read this ls" {
+		t.Errorf("Expected flattened text, got %q", got)
+	}
+	if speech.IsPlain() {
+		t.Error("Speech with pauses is not plain")
+	}
+
+	upper := speech.Map(strings.ToUpper)
+	if upper.Text() != "Q&A READ THIS LS" || speech.Text() != "Q&A Read this ls" {
+		t.Errorf("Map should return a modified copy, got %q", upper.Text())
+	}
+}
+
+func TestSynthesizeSpeech(t *testing.T) {
+	format := DefaultPCMFormat()
+	textAudio := make([]byte, 2205*format.BytesPerSample())
+	var texts []string
+	engine := &mockQueueEngine{
+		available: true,
+		synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+			texts = append(texts, text)
+			return textAudio, nil
+		},
+	}
+
+	speech := headingSpeech("Introduction", 1, "")
+	audio, err := SynthesizeSpeech(context.Background(), engine, speech, 2.0)
+	if err != nil {
+		t.Fatalf("SynthesizeSpeech failed: %v", err)
+	}
+
+	// Pauses become silence, shortened with the speed
+	pauses := len(GenerateSilence(headingPause(1).Seconds()/2, format)) + len(GenerateSilence((headingPause(1)/2).Seconds()/2, format))
+	if len(audio) != len(textAudio)+pauses {
+		t.Errorf("Expected %d bytes, got %d", len(textAudio)+pauses, len(audio))
+	}
+	if len(texts) != 1 || texts[0] != "Introduction" {
+		t.Errorf("Expected the heading text without pause markers, got %q", texts)
+	}
+
+	ssmlEngine := &mockSSMLEngine{mockQueueEngine: mockQueueEngine{available: true}}
+	if _, err := SynthesizeSpeech(context.Background(), ssmlEngine, speech, 1.0); err != nil {
+		t.Fatalf("SynthesizeSpeech failed: %v", err)
+	}
+	if len(ssmlEngine.ssml) != 1 || !strings.Contains(ssmlEngine.ssml[0], "<prosody") {
+		t.Errorf("Expected SSML for an SSML engine, got %q", ssmlEngine.ssml)
+	}
+
+	if _, err := SynthesizeSpeech(context.Background(), engine, Speech{}, 1.0); err == nil {
+		t.Error("Expected error for empty speech")
+	}
+}
+
+func TestHeadingSentenceSpeech(t *testing.T) {
+	parser, err := NewSentenceParser(nil)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+	sentences, err := parser.ParseSentences("## Getting Started\n\nInstall the tool first.")
+	if err != nil {
+		t.Fatalf("ParseSentences failed: %v", err)
+	}
+	if len(sentences) != 2 {
+		t.Fatalf("Expected the heading on its own, got %+v", sentences)
+	}
+	if sentences[0].Text != "Getting Started" || sentences[0].Speech == nil {
+		t.Errorf("Expected heading speech, got %+v", sentences[0])
+	} else if ssml := sentences[0].Speech.SSML(); !strings.Contains(ssml, `pitch="+5%"`) || strings.Contains(ssml, "...") {
+		t.Errorf("Unexpected heading SSML %s", ssml)
+	}
+	if sentences[1].Speech != nil {
+		t.Errorf("Expected plain text for a paragraph, got %+v", sentences[1].Speech)
+	}
+}
+
+func TestMarkdownProcessorSpeech(t *testing.T) {
+	processor := NewMarkdownProcessor(DefaultParserConfig())
+
+	heading := processor.ElementSpeech(MarkdownElement{Type: ElementHeading, Level: 2, Content: "Usage"})
+	if heading.Text() != "Usage" || heading.Nodes[0].Kind != SpeechPause {
+		t.Errorf("Expected a paused heading, got %+v", heading)
+	}
+	if got := processor.elementToSpeech(MarkdownElement{Type: ElementHorizontalRule}); got != "" {
+		t.Errorf("Expected a rule to be silent text, got %q", got)
+	}
+
+	strong := processor.ElementSpeech(MarkdownElement{Type: ElementStrong, Content: "never"})
+	if len(strong.Nodes) != 1 || strong.Nodes[0].Level != EmphasisStrong {
+		t.Errorf("Expected strong emphasis, got %+v", strong)
+	}
+}
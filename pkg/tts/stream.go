@@ -0,0 +1,254 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+	"time"
+)
+
+// streamChunkSize is how much engine output is read at a time
+const streamChunkSize = 4096
+
+// PCMStream collects PCM audio from a streaming engine as it is produced.
+// Any number of readers can consume it concurrently, each from its own
+// offset, so a segment can be played while synthesis is still running and
+// replayed later.
+type PCMStream struct {
+	src io.ReadCloser
+
+	mu   sync.Mutex
+	cond *sync.Cond
+	data []byte
+	done bool
+	err  error
+}
+
+// NewPCMStream starts reading src in the background
+func NewPCMStream(src io.ReadCloser) *PCMStream {
+	s := &PCMStream{src: src}
+	s.cond = sync.NewCond(&s.mu)
+	go s.fill()
+	return s
+}
+
+// fill copies engine output into the buffer until it ends
+func (s *PCMStream) fill() {
+	buf := make([]byte, streamChunkSize)
+	for {
+		n, err := s.src.Read(buf)
+		if err != nil {
+			// Closing reaps the engine process and reports how it exited
+			if closeErr := s.src.Close(); closeErr != nil && errors.Is(err, io.EOF) {
+				err = closeErr
+			}
+		}
+
+		s.mu.Lock()
+		if s.done {
+			// Closed by the consumer
+			s.mu.Unlock()
+			return
+		}
+		if n > 0 {
+			s.data = append(s.data, buf[:n]...)
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.err = fmt.Errorf("streaming synthesis failed: %w", err)
+			}
+			// Drop a trailing partial sample
+			s.data = s.data[:len(s.data)-len(s.data)%BytesPerSample]
+			s.done = true
+		}
+		s.cond.Broadcast()
+		s.mu.Unlock()
+
+		if err != nil {
+			return
+		}
+	}
+}
+
+// Wait blocks until synthesis finishes and returns the complete audio
+func (s *PCMStream) Wait() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for !s.done {
+		s.cond.Wait()
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	if len(s.data) == 0 {
+		return nil, errors.New("no audio data generated")
+	}
+	return s.data, nil
+}
+
+// Ready reports whether audio has started arriving (or the stream ended)
+func (s *PCMStream) Ready() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.data) > 0 || s.done
+}
+
+// Done reports whether the engine has finished producing audio
+func (s *PCMStream) Done() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.done
+}
+
+// Duration returns the length of the audio received so far
+func (s *PCMStream) Duration() time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return time.Duration(len(s.data)/BytesPerSample) * time.Second / SampleRate
+}
+
+// Close stops synthesis; audio already received stays readable
+func (s *PCMStream) Close() error {
+	s.mu.Lock()
+	if s.done {
+		s.mu.Unlock()
+		return nil
+	}
+	s.done = true
+	s.data = s.data[:len(s.data)-len(s.data)%BytesPerSample]
+	s.cond.Broadcast()
+	s.mu.Unlock()
+
+	return s.src.Close()
+}
+
+// NewReader returns a reader that blocks until audio is available
+func (s *PCMStream) NewReader() *PCMStreamReader {
+	return &PCMStreamReader{stream: s}
+}
+
+// newPlaybackReader returns a reader that reports no data instead of
+// blocking, so a slow engine cannot stall the audio mixer. It skips the
+// leading silence the queue trims from the finished audio, so positions
+// match the word timings computed on it.
+func (s *PCMStream) newPlaybackReader() *PCMStreamReader {
+	return &PCMStreamReader{stream: s, nonBlocking: true, trimLead: true}
+}
+
+// PCMStreamReader reads a PCMStream from its own offset
+type PCMStreamReader struct {
+	stream      *PCMStream
+	nonBlocking bool
+	trimLead    bool
+
+	mu     sync.Mutex
+	offset int64
+	lead   int64 // bytes of leading silence skipped
+	voiced bool  // whether the leading silence has been skipped
+}
+
+// Read implements io.Reader, returning whole samples only
+func (r *PCMStreamReader) Read(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := r.stream
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.trimLead && !r.voiced {
+		if r.skipSilence(); !r.voiced {
+			return 0, nil
+		}
+	}
+	for !s.done && int64(len(s.data))-r.offset < BytesPerSample {
+		if r.nonBlocking {
+			return 0, nil
+		}
+		s.cond.Wait()
+	}
+
+	var available []byte
+	if r.offset < int64(len(s.data)) {
+		available = s.data[r.offset:]
+	}
+	if len(available) == 0 {
+		if s.err != nil {
+			return 0, s.err
+		}
+		return 0, io.EOF
+	}
+
+	n := min(len(p), len(available))
+	n -= n % BytesPerSample
+	copy(p, available[:n])
+	r.offset += int64(n)
+	return n, nil
+}
+
+// skipSilence scans the samples received so far for the first audible
+// one and starts reading there. Audio that is silent throughout is played
+// as it is, as trimSilence leaves it. Caller must hold both locks.
+func (r *PCMStreamReader) skipSilence() {
+	s := r.stream
+	end := int64(len(s.data) - len(s.data)%BytesPerSample)
+	for ; r.lead < end; r.lead += BytesPerSample {
+		sample := int16(binary.LittleEndian.Uint16(s.data[r.lead:]))
+		if math.Abs(float64(sample)/32768.0) > SilenceThreshold {
+			r.offset, r.voiced = r.lead, true
+			return
+		}
+	}
+	if s.done {
+		r.offset, r.lead, r.voiced = 0, 0, true
+		return
+	}
+	r.offset = r.lead
+}
+
+// Seek implements io.Seeker relative to the start or current offset.
+// Positions exclude the leading silence a playback reader skips.
+func (r *PCMStreamReader) Seek(offset int64, whence int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += r.offset - r.lead
+	default:
+		return r.offset - r.lead, errors.New("seek relative to the end of a stream is not supported")
+	}
+	if offset < 0 {
+		return r.offset - r.lead, errors.New("negative seek position")
+	}
+	r.offset = r.lead + offset
+	return offset, nil
+}
+
+// GetPosition returns the number of bytes read, excluding skipped silence
+func (r *PCMStreamReader) GetPosition() int64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.offset - r.lead
+}
+
+// SynthesizeStream synthesizes text with a streaming engine when possible,
+// otherwise it synthesizes the whole text and returns it as a stream
+func SynthesizeStream(ctx context.Context, engine TTSEngine, text string, speed float64) (io.ReadCloser, error) {
+	if streamer, ok := engine.(StreamingEngine); ok {
+		return streamer.SynthesizeStream(ctx, text, speed)
+	}
+
+	audio, err := SynthesizeContext(ctx, engine, text, speed)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(audio)), nil
+}
@@ -0,0 +1,187 @@
+package tts
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+// errAfterReader returns its data and then fails
+type errAfterReader struct {
+	data []byte
+	err  error
+}
+
+func (r *errAfterReader) Read(p []byte) (int, error) {
+	if len(r.data) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.data)
+	r.data = r.data[n:]
+	return n, nil
+}
+
+func (r *errAfterReader) Close() error { return nil }
+
+func TestPCMStreamChunks(t *testing.T) {
+	pr, pw := io.Pipe()
+	stream := NewPCMStream(pr)
+	defer stream.Close()
+
+	if stream.Ready() {
+		t.Error("Expected stream not to be ready before any audio arrives")
+	}
+
+	playback := stream.newPlaybackReader()
+	buf := make([]byte, 64)
+	if n, err := playback.Read(buf); n != 0 || err != nil {
+		t.Errorf("Expected non-blocking read to return 0, nil; got %d, %v", n, err)
+	}
+
+	if _, err := pw.Write([]byte{1, 2, 3, 4}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A blocking reader waits for the first chunk
+	reader := stream.NewReader()
+	n, err := reader.Read(buf)
+	if err != nil || n != 4 {
+		t.Fatalf("Expected 4 bytes, got %d, %v", n, err)
+	}
+	if !stream.Ready() || stream.Done() {
+		t.Error("Expected stream to be ready but not done")
+	}
+
+	// Odd trailing byte is dropped when the engine finishes
+	if _, err := pw.Write([]byte{5, 6, 7}); err != nil {
+		t.Fatal(err)
+	}
+	pw.Close()
+
+	audio, err := stream.Wait()
+	if err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	if !bytes.Equal(audio, []byte{1, 2, 3, 4, 5, 6}) {
+		t.Errorf("Unexpected audio %v", audio)
+	}
+
+	rest, err := io.ReadAll(reader)
+	if err != nil || !bytes.Equal(rest, []byte{5, 6}) {
+		t.Errorf("Expected remaining samples [5 6], got %v, %v", rest, err)
+	}
+
+	// Readers are independent, so the audio can be replayed
+	all, err := io.ReadAll(stream.NewReader())
+	if err != nil || len(all) != 6 {
+		t.Errorf("Expected a new reader to see all 6 bytes, got %d, %v", len(all), err)
+	}
+}
+
+func TestPCMStreamError(t *testing.T) {
+	failure := errors.New("engine crashed")
+	stream := NewPCMStream(&errAfterReader{data: []byte{1, 2}, err: failure})
+
+	if _, err := stream.Wait(); !errors.Is(err, failure) {
+		t.Errorf("Expected engine error from Wait, got %v", err)
+	}
+
+	reader := stream.NewReader()
+	if n, err := reader.Read(make([]byte, 8)); n != 2 || err != nil {
+		t.Errorf("Expected audio received before the failure, got %d, %v", n, err)
+	}
+	if _, err := reader.Read(make([]byte, 8)); !errors.Is(err, failure) {
+		t.Errorf("Expected engine error at end of stream, got %v", err)
+	}
+
+	empty := NewPCMStream(io.NopCloser(bytes.NewReader(nil)))
+	if _, err := empty.Wait(); err == nil {
+		t.Error("Expected an error for a stream without audio")
+	}
+}
+
+func TestPCMStreamClose(t *testing.T) {
+	pr, pw := io.Pipe()
+	stream := NewPCMStream(pr)
+
+	go pw.Write([]byte{1, 2}) //nolint:errcheck
+	reader := stream.NewReader()
+	if _, err := reader.Read(make([]byte, 8)); err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		_, _ = reader.Read(make([]byte, 8))
+		close(done)
+	}()
+
+	stream.Close()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected Close to release blocked readers")
+	}
+
+	if _, err := pw.Write([]byte{3, 4}); err == nil {
+		t.Error("Expected the engine output to be closed")
+	}
+}
+
+func TestPCMStreamPlaybackSkipsLeadingSilence(t *testing.T) {
+	pr, pw := io.Pipe()
+	stream := NewPCMStream(pr)
+	defer stream.Close()
+
+	// 100 samples of silence, then a tone the queue keeps when trimming
+	silence := make([]byte, 200)
+	tone := bytes.Repeat([]byte{0x80, 0x3e}, 50)
+	audio := append(append([]byte(nil), silence...), tone...)
+
+	playback := stream.newPlaybackReader()
+	buf := make([]byte, len(audio))
+	if _, err := pw.Write(silence); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := playback.Read(buf); n != 0 || err != nil {
+		t.Errorf("Expected silence to be skipped while waiting, got %d, %v", n, err)
+	}
+	if playback.GetPosition() != 0 {
+		t.Errorf("Expected position 0 in the silence, got %d", playback.GetPosition())
+	}
+
+	go func() {
+		pw.Write(tone) //nolint:errcheck
+		pw.Close()
+	}()
+	if _, err := stream.Wait(); err != nil {
+		t.Fatalf("Wait failed: %v", err)
+	}
+	played, err := io.ReadAll(playback)
+	if err != nil || !bytes.Equal(played, tone) {
+		t.Errorf("Expected only the tone to play, got %d bytes, %v", len(played), err)
+	}
+
+	// Positions match the trimmed audio the word timings are computed on
+	lead, _ := voicedRange(audio, DefaultPCMFormat())
+	if playback.GetPosition() != int64(len(audio)-lead) {
+		t.Errorf("Expected position %d, got %d", len(audio)-lead, playback.GetPosition())
+	}
+	if _, err := playback.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := playback.Read(buf); !bytes.Equal(buf[:n], tone) {
+		t.Errorf("Expected a rewound reader to start at the tone, got %d bytes", n)
+	}
+
+	// Audio that is silent throughout plays as it is
+	quiet := NewPCMStream(io.NopCloser(bytes.NewReader(silence)))
+	if _, err := quiet.Wait(); err != nil {
+		t.Fatal(err)
+	}
+	if all, err := io.ReadAll(quiet.newPlaybackReader()); err != nil || len(all) != len(silence) {
+		t.Errorf("Expected silent audio to play untrimmed, got %d bytes, %v", len(all), err)
+	}
+}
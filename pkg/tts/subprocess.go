@@ -266,6 +266,10 @@ type SafeProcessOptions struct {
 
 	// Environment variables
 	Env []string
+
+	// StdoutOnly keeps stderr out of the returned output even without Input,
+	// which matters when stdout carries binary data such as audio
+	StdoutOnly bool
 }
 
 // ExecuteSafe provides a high-level safe subprocess execution with all protections.
@@ -285,7 +289,7 @@ func (sm *SubprocessManager) ExecuteSafe(ctx context.Context, opts SafeProcessOp
 	}
 
 	// Execute with or without stdin
-	if opts.Input != "" {
+	if opts.Input != "" || opts.StdoutOnly {
 		return sm.ExecuteWithStdin(ctx, opts.Input, opts.Command, opts.Args...)
 	}
 	return sm.Execute(ctx, opts.Command, opts.Args...)
@@ -0,0 +1,136 @@
+package tts
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yuin/goldmark/ast"
+	east "github.com/yuin/goldmark/extension/ast"
+)
+
+// extractTableRows returns the cell text of a table, header row first
+func (mp *MarkdownProcessor) extractTableRows(table *east.Table, source string) [][]string {
+	var rows [][]string
+	for row := table.FirstChild(); row != nil; row = row.NextSibling() {
+		var cells []string
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, plainText(cell, source))
+		}
+		rows = append(rows, cells)
+	}
+	return rows
+}
+
+// plainText collects all text below node, including code spans
+func plainText(node ast.Node, source string) string {
+	var b strings.Builder
+	_ = ast.Walk(node, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch t := n.(type) {
+		case *ast.Text:
+			b.Write(t.Segment.Value([]byte(source)))
+			if t.SoftLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			b.Write(t.Value)
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(b.String())
+}
+
+// tableLines describes a table as pairs of display and spoken text: an
+// introduction, then one entry per data row unless the table has more
+// than summaryRows rows. Display text is the cell contents as they appear
+// on screen; spoken text labels each cell with its column header.
+func tableLines(rows [][]string, summaryRows int) (display, spoken []string) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	header, data := rows[0], rows[1:]
+
+	display = append(display, strings.Join(nonEmpty(header), " "))
+	intro := fmt.Sprintf("Table with %s and %s", plural(len(data), "row"), plural(len(header), "column"))
+	if labels := nonEmpty(header); len(labels) > 0 {
+		intro += ": " + strings.Join(labels, ", ")
+	}
+	spoken = append(spoken, intro)
+
+	if summaryRows > 0 && len(data) > summaryRows {
+		return display, spoken
+	}
+
+	for i, row := range data {
+		var cells, pairs []string
+		for j, cell := range row {
+			if cell == "" {
+				continue
+			}
+			cells = append(cells, cell)
+			if j < len(header) && header[j] != "" {
+				pairs = append(pairs, header[j]+", "+cell)
+			} else {
+				pairs = append(pairs, cell)
+			}
+		}
+		if len(cells) == 0 {
+			continue
+		}
+		display = append(display, strings.Join(cells, " "))
+		spoken = append(spoken, fmt.Sprintf("Row %d: %s", i+1, strings.Join(pairs, "; ")))
+	}
+	return display, spoken
+}
+
+// tableSentences turns table rows (header first) into sentences
+func (p *SentenceParser) tableSentences(rows [][]string) []ParsedSentence {
+	display, spoken := tableLines(rows, p.config.TableSummaryRows)
+	sentences := make([]ParsedSentence, 0, len(display))
+	for i := range display {
+		speech := spoken[i]
+		if normalized := p.normalize(speech); normalized != "" {
+			speech = normalized
+		}
+		sentences = append(sentences, ParsedSentence{
+			Text:     display[i],
+			Spoken:   speech,
+			Original: display[i],
+			Type:     SentenceTypeTable,
+		})
+	}
+	return sentences
+}
+
+// parseTable reads the first table in markdown
+func (p *SentenceParser) parseTable(markdown string) []ParsedSentence {
+	elements, err := NewMarkdownProcessor(p.config).ProcessMarkdown(markdown)
+	if err != nil {
+		return nil
+	}
+	for _, elem := range elements {
+		if elem.Type == ElementTable {
+			return p.tableSentences(elem.Rows)
+		}
+	}
+	return nil
+}
+
+func nonEmpty(values []string) []string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", word)
+	}
+	return fmt.Sprintf("%d %ss", n, word)
+}
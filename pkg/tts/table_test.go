@@ -0,0 +1,113 @@
+package tts
+
+import (
+	"testing"
+)
+
+const tableMarkdown = `Users are listed below.
+
+| Name | Role |
+|------|:----:|
+| Alice | admin |
+| Bob | ` + "`viewer`" + ` |
+| | |
+
+That is everyone.`
+
+func TestParserReadsTables(t *testing.T) {
+	parser, err := NewSentenceParser(DefaultParserConfig())
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	sentences, err := parser.Parse(tableMarkdown)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	want := []struct{ text, spoken string }{
+		{"Users are listed below", "Users are listed below"},
+		{"Name Role", "Table with 3 rows and 2 columns: Name, Role"},
+		{"Alice admin", "Row 1: Name, Alice; Role, admin"},
+		{"Bob viewer", "Row 2: Name, Bob; Role, viewer"},
+		{"That is everyone", "That is everyone"},
+	}
+	if len(sentences) != len(want) {
+		t.Fatalf("Expected %d sentences, got %d: %+v", len(want), len(sentences), sentences)
+	}
+	for i, w := range want {
+		s := sentences[i]
+		if s.Text != w.text || s.SpokenText() != w.spoken {
+			t.Errorf("Sentence %d = (%q, %q), want (%q, %q)", i, s.Text, s.SpokenText(), w.text, w.spoken)
+		}
+		if s.Position != i {
+			t.Errorf("Sentence %d has position %d", i, s.Position)
+		}
+	}
+}
+
+func TestParserSummarizesLargeTables(t *testing.T) {
+	config := DefaultParserConfig()
+	config.TableSummaryRows = 2
+
+	parser, err := NewSentenceParser(config)
+	if err != nil {
+		t.Fatalf("Failed to create parser: %v", err)
+	}
+
+	sentences, err := parser.Parse("| A | B | C |\n|---|---|---|\n| 1 | 2 | 3 |\n| 4 | 5 | 6 |\n| 7 | 8 | 9 |")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(sentences) != 1 {
+		t.Fatalf("Expected a single summary sentence, got %+v", sentences)
+	}
+	if got := sentences[0].SpokenText(); got != "Table with 3 rows and 3 columns: A, B, C" {
+		t.Errorf("Unexpected summary: %q", got)
+	}
+}
+
+func TestSplitMarkdownBlocksIgnoresTablesInCode(t *testing.T) {
+	blocks := splitMarkdownBlocks("```\n| a | b |\n|---|---|\n```\nText | with pipe")
+	for _, block := range blocks {
+		if block.kind == blockTable {
+			t.Errorf("Expected no table outside code, got %q", block.text)
+		}
+	}
+}
+
+func TestMarkdownProcessorTables(t *testing.T) {
+	processor := NewMarkdownProcessor(DefaultParserConfig())
+
+	elements, err := processor.ProcessMarkdown(tableMarkdown)
+	if err != nil {
+		t.Fatalf("ProcessMarkdown failed: %v", err)
+	}
+
+	var table *MarkdownElement
+	for i := range elements {
+		if elements[i].Type == ElementTable {
+			table = &elements[i]
+		}
+	}
+	if table == nil {
+		t.Fatal("Expected a table element")
+	}
+	if len(table.Rows) != 4 || table.Rows[2][1] != "viewer" {
+		t.Errorf("Unexpected table rows: %q", table.Rows)
+	}
+
+	sentences, err := processor.ExtractSpeakableSentences(tableMarkdown)
+	if err != nil {
+		t.Fatalf("ExtractSpeakableSentences failed: %v", err)
+	}
+	found := false
+	for _, s := range sentences {
+		if s.SpokenText() == "Row 1: Name, Alice; Role, admin" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected table rows to be spoken, got %+v", sentences)
+	}
+}
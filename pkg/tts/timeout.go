@@ -48,15 +48,21 @@ func NewTimeoutExecutor(config TimeoutConfig) *TimeoutExecutor {
 // RunWithTimeout executes a command with timeout protection
 // It implements a graceful shutdown sequence: SIGINT -> wait -> SIGKILL
 func (te *TimeoutExecutor) RunWithTimeout(cmd *exec.Cmd) error {
+	return te.RunWithTimeoutContext(context.Background(), cmd)
+}
+
+// RunWithTimeoutContext is like RunWithTimeout but also kills the command
+// when parent is cancelled, returning the parent's error
+func (te *TimeoutExecutor) RunWithTimeoutContext(parent context.Context, cmd *exec.Cmd) error {
 	if te.config.UseContext {
-		return te.runWithContext(cmd)
+		return te.runWithContext(parent, cmd)
 	}
-	return te.runWithTimer(cmd)
+	return te.runWithTimer(parent, cmd)
 }
 
 // runWithContext uses context cancellation for timeout management
-func (te *TimeoutExecutor) runWithContext(cmd *exec.Cmd) error {
-	ctx, cancel := context.WithTimeout(context.Background(), te.config.Timeout)
+func (te *TimeoutExecutor) runWithContext(parent context.Context, cmd *exec.Cmd) error {
+	ctx, cancel := context.WithTimeout(parent, te.config.Timeout)
 	defer cancel()
 	
 	// Track execution time
@@ -83,6 +89,11 @@ func (te *TimeoutExecutor) runWithContext(cmd *exec.Cmd) error {
 	// Log the execution
 	LogSubprocessExecution(cmd.Path, cmd.Args[1:], duration, err)
 	
+	// The caller gave up on the command
+	if parent.Err() != nil {
+		return parent.Err()
+	}
+	
 	// Check if context was cancelled (timeout)
 	if ctx.Err() == context.DeadlineExceeded {
 		log.Warn("Command timed out", 
@@ -101,7 +112,7 @@ func (te *TimeoutExecutor) runWithContext(cmd *exec.Cmd) error {
 }
 
 // runWithTimer uses a timer-based approach for timeout management
-func (te *TimeoutExecutor) runWithTimer(cmd *exec.Cmd) error {
+func (te *TimeoutExecutor) runWithTimer(parent context.Context, cmd *exec.Cmd) error {
 	// Start the command
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start command: %w", err)
@@ -145,6 +156,17 @@ func (te *TimeoutExecutor) runWithTimer(cmd *exec.Cmd) error {
 		<-done
 		
 		return fmt.Errorf("command timed out after %v", te.config.Timeout)
+		
+	case <-parent.Done():
+		// Cancelled by the caller; no need for a graceful shutdown
+		if cmd.Process != nil {
+			if err := cmd.Process.Kill(); err != nil {
+				log.Error("Failed to kill process", "error", err)
+			}
+		}
+		<-done
+		
+		return parent.Err()
 	}
 }
 
@@ -0,0 +1,110 @@
+package tts
+
+import (
+	"encoding/binary"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// WordTiming is when a word is spoken, relative to the start of its audio
+type WordTiming struct {
+	Word  string
+	Start time.Duration
+	End   time.Duration
+}
+
+// TimingWords splits text into the words timings refer to: runs of letters
+// and digits, so punctuation and markup never count as words
+func TimingWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// WordAt returns the index of the word being spoken at offset, or -1
+// before the first word
+func WordAt(timings []WordTiming, offset time.Duration) int {
+	return sort.Search(len(timings), func(i int) bool {
+		return timings[i].Start > offset
+	}) - 1
+}
+
+// EstimateWordTimings spreads the words of text over the voiced part of
+// audio in proportion to their length, with extra time for the pauses
+// punctuation implies
+func EstimateWordTimings(text string, audio []byte, format PCMFormat) []WordTiming {
+	fields := strings.Fields(text)
+	var words []string
+	var weights []float64
+	total := 0.0
+	for _, field := range fields {
+		parts := TimingWords(field)
+		for i, word := range parts {
+			weight := float64(len([]rune(word)))
+			if i == len(parts)-1 {
+				weight += punctuationWeight(field)
+			}
+			words = append(words, word)
+			weights = append(weights, weight)
+			total += weight
+		}
+	}
+	if len(words) == 0 {
+		return nil
+	}
+
+	start, end := voicedRange(audio, format)
+	from := pcmDuration(start, format)
+	span := pcmDuration(end, format) - from
+
+	timings := make([]WordTiming, len(words))
+	elapsed := 0.0
+	for i, word := range words {
+		timings[i] = WordTiming{
+			Word:  word,
+			Start: from + time.Duration(float64(span)*elapsed/total),
+		}
+		elapsed += weights[i]
+		timings[i].End = from + time.Duration(float64(span)*elapsed/total)
+	}
+	return timings
+}
+
+// punctuationWeight is the pause after a word, in letters, implied by the
+// punctuation that ends field
+func punctuationWeight(field string) float64 {
+	switch {
+	case strings.ContainsAny(field, ".!?"):
+		return 3
+	case strings.ContainsAny(field, ",;:"):
+		return 2
+	}
+	return 0
+}
+
+// voicedRange returns the byte offsets of the first and last audible
+// samples, or the whole audio if it is silent
+func voicedRange(audio []byte, format PCMFormat) (int, int) {
+	bps := format.BytesPerSample()
+	samples := len(audio) / bps
+	audible := func(i int) bool {
+		sample := int16(binary.LittleEndian.Uint16(audio[i*bps:]))
+		return math.Abs(float64(sample)/32768.0) > SilenceThreshold
+	}
+
+	first := 0
+	for first < samples && !audible(first) {
+		first++
+	}
+	if first == samples {
+		return 0, samples * bps
+	}
+	last := samples - 1
+	for last > first && !audible(last) {
+		last--
+	}
+	return first * bps, (last + 1) * bps
+}
@@ -0,0 +1,115 @@
+package tts
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+)
+
+func TestEstimateWordTimings(t *testing.T) {
+	format := DefaultPCMFormat()
+	// 100ms of silence around 1s of tone
+	silence := GenerateSilence(0.1, format)
+	tone := make([]byte, SampleRate*format.BytesPerSample())
+	for i := 0; i < len(tone); i += 2 {
+		binary.LittleEndian.PutUint16(tone[i:], uint16(12000))
+	}
+	audio := append(append(append([]byte(nil), silence...), tone...), silence...)
+
+	timings := EstimateWordTimings("Go, now stop.", audio, format)
+	if len(timings) != 3 {
+		t.Fatalf("Expected 3 words, got %+v", timings)
+	}
+	if timings[0].Start != 100*time.Millisecond {
+		t.Errorf("Expected the first word to start after the silence, got %v", timings[0].Start)
+	}
+	if end := timings[2].End; end < 1099*time.Millisecond || end > 1100*time.Millisecond {
+		t.Errorf("Expected the last word to end with the tone, got %v", end)
+	}
+	// "Go," weighs 2 letters and 2 for the comma, "now" 3 letters
+	if got, want := timings[0].End-timings[0].Start, timings[1].End-timings[1].Start; got <= want {
+		t.Errorf("Expected the comma to lengthen the first word: %v vs %v", got, want)
+	}
+	for i := 1; i < len(timings); i++ {
+		if timings[i].Start != timings[i-1].End {
+			t.Errorf("Expected word %d to start where the previous ended", i)
+		}
+	}
+
+	if EstimateWordTimings("...", audio, format) != nil {
+		t.Error("Expected no timings for text without words")
+	}
+}
+
+func TestWordAt(t *testing.T) {
+	timings := []WordTiming{
+		{Word: "one", Start: 100 * time.Millisecond, End: 300 * time.Millisecond},
+		{Word: "two", Start: 300 * time.Millisecond, End: 500 * time.Millisecond},
+	}
+	tests := []struct {
+		offset time.Duration
+		want   int
+	}{
+		{0, -1},
+		{100 * time.Millisecond, 0},
+		{299 * time.Millisecond, 0},
+		{300 * time.Millisecond, 1},
+		{time.Second, 1},
+	}
+	for _, tt := range tests {
+		if got := WordAt(timings, tt.offset); got != tt.want {
+			t.Errorf("WordAt(%v) = %d, want %d", tt.offset, got, tt.want)
+		}
+	}
+	if WordAt(nil, time.Second) != -1 {
+		t.Error("Expected -1 without timings")
+	}
+}
+
+func TestQueueWordTimings(t *testing.T) {
+	// 100ms of leading silence, then 900ms of tone
+	engine := &mockQueueEngine{
+		available: true,
+		synthesizeFunc: func(text string, speed float64) ([]byte, error) {
+			format := DefaultPCMFormat()
+			audio := make([]byte, SampleRate*format.BytesPerSample())
+			for i := SampleRate / 10 * 2; i < len(audio); i += 2 {
+				binary.LittleEndian.PutUint16(audio[i:], uint16(12000))
+			}
+			return audio, nil
+		},
+	}
+	queue, err := NewAudioQueue(createTestQueueConfig(engine, &mockQueueParser{}))
+	if err != nil {
+		t.Fatalf("Failed to create queue: %v", err)
+	}
+	defer queue.Stop()
+
+	if err := queue.AddText("Hello big world"); err != nil {
+		t.Fatal(err)
+	}
+	queue.Preload()
+	if err := queue.WaitForReady(5 * time.Second); err != nil {
+		t.Fatal(err)
+	}
+
+	segment, err := queue.GetCurrent()
+	if err != nil {
+		t.Fatal(err)
+	}
+	queue.mu.RLock()
+	timings := segment.Timings
+	queue.mu.RUnlock()
+
+	// Timings are estimated on the trimmed audio: "hello" and "world" get
+	// 5/11 of the 900ms each, "big" 3/11
+	if len(timings) != 3 || timings[0].Start != 0 {
+		t.Fatalf("Expected estimates on the trimmed audio, got %+v", timings)
+	}
+	if got := queue.wordAt("", 450*time.Millisecond); got != 1 {
+		t.Errorf("Expected the second word at 450ms, got %d", got)
+	}
+	if got := queue.wordAt("another-segment", 450*time.Millisecond); got != -1 {
+		t.Errorf("Expected no word for a segment that is not current, got %d", got)
+	}
+}
@@ -0,0 +1,169 @@
+package tts
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dgnsrekt/glow-tts/utils"
+)
+
+// Voice describes a voice an engine can speak with
+type Voice struct {
+	// Engine is the engine name ("piper", "gtts", ...)
+	Engine string `json:"engine"`
+	// ID is the value that selects the voice for its engine
+	ID string `json:"id"`
+	// Name is a human-readable description
+	Name string `json:"name"`
+	// Language is the language code (e.g. "en_US", "fr")
+	Language string `json:"language"`
+	// Quality is the model quality where known (e.g. "medium")
+	Quality string `json:"quality,omitempty"`
+	// SampleRate is the native sample rate in Hz where known
+	SampleRate int `json:"sample_rate,omitempty"`
+	// Path is the model file for file-based voices
+	Path string `json:"path,omitempty"`
+}
+
+// VoiceLister is implemented by engines that can enumerate their voices
+type VoiceLister interface {
+	ListVoices() ([]Voice, error)
+}
+
+// piperVoiceConfig is the subset of a Piper .onnx.json file used for the catalog
+type piperVoiceConfig struct {
+	Dataset string `json:"dataset"`
+	Audio   struct {
+		SampleRate int    `json:"sample_rate"`
+		Quality    string `json:"quality"`
+	} `json:"audio"`
+	Language struct {
+		Code           string `json:"code"`
+		NameEnglish    string `json:"name_english"`
+		CountryEnglish string `json:"country_english"`
+	} `json:"language"`
+}
+
+// DefaultPiperVoiceDirs returns the standard Piper voice locations
+func DefaultPiperVoiceDirs() []string {
+	home := os.Getenv("HOME")
+	return []string{
+		filepath.Join(home, ".local", "share", "piper-voices"),
+		"/usr/share/piper-voices",
+		"/usr/local/share/piper-voices",
+		filepath.Join(home, ".config", "piper", "voices"),
+		filepath.Join(home, "piper-voices"),
+		"/opt/piper/voices",
+	}
+}
+
+// PiperVoiceDirs returns the configured voice directories, the directory of
+// the configured model and the standard locations, without duplicates
+func PiperVoiceDirs(config *TTSConfig) []string {
+	var dirs []string
+	if config != nil {
+		for _, dir := range config.Engines.Piper.VoiceDirs {
+			dirs = append(dirs, utils.ExpandPath(dir))
+		}
+		if model := config.Engines.Piper.ModelPath; model != "" {
+			dirs = append(dirs, filepath.Dir(utils.ExpandPath(model)))
+		}
+	}
+	dirs = append(dirs, DefaultPiperVoiceDirs()...)
+
+	seen := make(map[string]bool)
+	unique := dirs[:0]
+	for _, dir := range dirs {
+		dir = filepath.Clean(dir)
+		if !seen[dir] {
+			seen[dir] = true
+			unique = append(unique, dir)
+		}
+	}
+	return unique
+}
+
+// ScanPiperVoices walks dirs for .onnx models and describes each one using
+// its .onnx.json config, falling back to the lang-name-quality file name
+// convention. Missing directories are skipped.
+func ScanPiperVoices(dirs ...string) []Voice {
+	var voices []Voice
+	seen := make(map[string]bool)
+
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil // Keep walking past unreadable entries
+			}
+			if d.IsDir() || !strings.HasSuffix(path, ".onnx") {
+				return nil
+			}
+			if real, err := filepath.EvalSymlinks(path); err == nil {
+				if seen[real] {
+					return nil
+				}
+				seen[real] = true
+			}
+			voices = append(voices, describePiperVoice(path))
+			return nil
+		})
+	}
+
+	sort.SliceStable(voices, func(i, j int) bool {
+		if voices[i].Language != voices[j].Language {
+			return voices[i].Language < voices[j].Language
+		}
+		return voices[i].ID < voices[j].ID
+	})
+	return voices
+}
+
+// describePiperVoice builds the catalog entry for one model file
+func describePiperVoice(modelPath string) Voice {
+	id := strings.TrimSuffix(filepath.Base(modelPath), ".onnx")
+	voice := Voice{
+		Engine: "piper",
+		ID:     id,
+		Name:   id,
+		Path:   modelPath,
+	}
+
+	// Piper voices are named like en_US-amy-medium
+	if parts := strings.Split(id, "-"); len(parts) == 3 {
+		voice.Language = parts[0]
+		voice.Name = parts[1]
+		voice.Quality = parts[2]
+	}
+
+	data, err := os.ReadFile(modelPath + ".json")
+	if err != nil {
+		return voice
+	}
+	var config piperVoiceConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return voice
+	}
+
+	if config.Language.Code != "" {
+		voice.Language = config.Language.Code
+	}
+	if config.Audio.Quality != "" {
+		voice.Quality = config.Audio.Quality
+	}
+	voice.SampleRate = config.Audio.SampleRate
+	if config.Dataset != "" {
+		voice.Name = config.Dataset
+	}
+	if config.Language.NameEnglish != "" {
+		language := config.Language.NameEnglish
+		if config.Language.CountryEnglish != "" {
+			language += ", " + config.Language.CountryEnglish
+		}
+		voice.Name += " (" + language + ")"
+	}
+	return voice
+}
@@ -0,0 +1,103 @@
+package tts
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestScanPiperVoices(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "en", "en_GB")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		filepath.Join(dir, "en_US-amy-medium.onnx"): "",
+		filepath.Join(dir, "en_US-amy-medium.onnx.json"): `{
+			"dataset": "amy",
+			"audio": {"sample_rate": 22050, "quality": "medium"},
+			"language": {"code": "en_US", "name_english": "English", "country_english": "United States"}
+		}`,
+		filepath.Join(nested, "en_GB-alan-low.onnx"): "",
+		filepath.Join(dir, "custom.onnx"):            "",
+		filepath.Join(dir, "notes.txt"):              "",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Scanning the same directory twice must not duplicate voices
+	voices := ScanPiperVoices(dir, dir, filepath.Join(dir, "missing"))
+	if len(voices) != 3 {
+		t.Fatalf("Expected 3 voices, got %d: %+v", len(voices), voices)
+	}
+
+	// Sorted by language, with unknown languages first
+	if voices[0].ID != "custom" || voices[0].Language != "" {
+		t.Errorf("Expected unlabelled model first, got %+v", voices[0])
+	}
+
+	alan := voices[1]
+	if alan.ID != "en_GB-alan-low" || alan.Language != "en_GB" || alan.Quality != "low" || alan.Name != "alan" {
+		t.Errorf("Expected voice parsed from file name, got %+v", alan)
+	}
+
+	amy := voices[2]
+	want := Voice{
+		Engine:     "piper",
+		ID:         "en_US-amy-medium",
+		Name:       "amy (English, United States)",
+		Language:   "en_US",
+		Quality:    "medium",
+		SampleRate: 22050,
+		Path:       filepath.Join(dir, "en_US-amy-medium.onnx"),
+	}
+	if amy != want {
+		t.Errorf("Expected %+v, got %+v", want, amy)
+	}
+}
+
+func TestPiperVoiceDirs(t *testing.T) {
+	config := DefaultTTSConfig()
+	config.Engines.Piper.VoiceDirs = []string{"/srv/voices", "/srv/voices/"}
+	config.Engines.Piper.ModelPath = "/srv/voices/en_US-amy-medium.onnx"
+
+	dirs := PiperVoiceDirs(config)
+	if dirs[0] != "/srv/voices" {
+		t.Errorf("Expected configured directory first, got %q", dirs)
+	}
+	for _, dir := range dirs[1:] {
+		if dir == "/srv/voices" {
+			t.Errorf("Expected directories without duplicates, got %q", dirs)
+		}
+	}
+}
+
+func TestCheckDependenciesUsesConfiguredVoiceDirs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	voices := filepath.Join(t.TempDir(), "voices")
+	if err := os.MkdirAll(voices, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(voices, "custom.onnx"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	configFile := filepath.Join(home, ".config", "glow", "glow-tts.yml")
+	if err := os.MkdirAll(filepath.Dir(configFile), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	config := "engines:\n  piper:\n    voice_dirs:\n      - " + voices + "\n"
+	if err := os.WriteFile(configFile, []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	deps, _ := CheckSystemDependencies("piper")
+	if models := deps.Results["piper_models"]; !models.Installed || models.Path != voices {
+		t.Errorf("Expected models found in %s, got %+v", voices, models)
+	}
+}
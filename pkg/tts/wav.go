@@ -0,0 +1,176 @@
+package tts
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WAVHeaderSize is the size of a canonical PCM WAV header in bytes
+const WAVHeaderSize = 44
+
+// WAV format tags
+const (
+	wavFormatPCM   = 1
+	wavFormatFloat = 3
+)
+
+// WAVHeader builds a canonical RIFF/WAVE header for PCM data of the given length
+func WAVHeader(dataLen int, format PCMFormat) ([]byte, error) {
+	if format.SampleRate <= 0 || format.Channels <= 0 || format.BitDepth <= 0 {
+		return nil, fmt.Errorf("invalid PCM format: %d Hz, %d channels, %d bits",
+			format.SampleRate, format.Channels, format.BitDepth)
+	}
+	if format.ByteOrder != nil && format.ByteOrder != binary.LittleEndian {
+		return nil, errors.New("WAV requires little-endian PCM data")
+	}
+	if dataLen < 0 || int64(dataLen) > math.MaxUint32-WAVHeaderSize+8 {
+		return nil, fmt.Errorf("PCM data length %d does not fit in a WAV file", dataLen)
+	}
+
+	formatTag := uint16(wavFormatPCM)
+	if format.IsFloat {
+		formatTag = wavFormatFloat
+	}
+
+	blockAlign := format.BytesPerSample()
+	byteRate := format.SampleRate * blockAlign
+
+	header := make([]byte, WAVHeaderSize)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(WAVHeaderSize-8+dataLen))
+	copy(header[8:12], "WAVE")
+
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16)
+	binary.LittleEndian.PutUint16(header[20:22], formatTag)
+	binary.LittleEndian.PutUint16(header[22:24], uint16(format.Channels))
+	binary.LittleEndian.PutUint32(header[24:28], uint32(format.SampleRate))
+	binary.LittleEndian.PutUint32(header[28:32], uint32(byteRate))
+	binary.LittleEndian.PutUint16(header[32:34], uint16(blockAlign))
+	binary.LittleEndian.PutUint16(header[34:36], uint16(format.BitDepth))
+
+	copy(header[36:40], "data")
+	binary.LittleEndian.PutUint32(header[40:44], uint32(dataLen))
+
+	return header, nil
+}
+
+// WriteWAV writes PCM data to w as a complete WAV file
+func WriteWAV(w io.Writer, pcm []byte, format PCMFormat) error {
+	header, err := WAVHeader(len(pcm), format)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write WAV header: %w", err)
+	}
+	if _, err := w.Write(pcm); err != nil {
+		return fmt.Errorf("failed to write WAV data: %w", err)
+	}
+
+	return nil
+}
+
+// DecodeWAV extracts the PCM data and format from a WAV file. Streamed WAV
+// output (such as from a pipe) often carries placeholder chunk sizes, so the
+// data chunk is clamped to the bytes actually available.
+func DecodeWAV(data []byte) ([]byte, PCMFormat, error) {
+	var format PCMFormat
+
+	if len(data) < 12 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		return nil, format, errors.New("not a RIFF/WAVE file")
+	}
+
+	haveFormat := false
+	offset := 12
+	for offset+8 <= len(data) {
+		chunkID := string(data[offset : offset+4])
+		chunkSize := int64(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+
+		switch chunkID {
+		case "fmt ":
+			if chunkSize < 16 || body+16 > len(data) {
+				return nil, format, errors.New("truncated WAV fmt chunk")
+			}
+			formatTag := binary.LittleEndian.Uint16(data[body : body+2])
+			if formatTag != wavFormatPCM && formatTag != wavFormatFloat {
+				return nil, format, fmt.Errorf("unsupported WAV encoding: %d", formatTag)
+			}
+			bitDepth := int(binary.LittleEndian.Uint16(data[body+14 : body+16]))
+			format = PCMFormat{
+				Channels:   int(binary.LittleEndian.Uint16(data[body+2 : body+4])),
+				SampleRate: int(binary.LittleEndian.Uint32(data[body+4 : body+8])),
+				BitDepth:   bitDepth,
+				ByteOrder:  binary.LittleEndian,
+				IsSigned:   formatTag == wavFormatPCM && bitDepth > 8, // 8-bit WAV is unsigned
+				IsFloat:    formatTag == wavFormatFloat,
+			}
+			haveFormat = true
+
+		case "data":
+			if !haveFormat {
+				return nil, format, errors.New("WAV data chunk appears before fmt chunk")
+			}
+			end := int64(body) + chunkSize
+			if chunkSize == 0 || end > int64(len(data)) {
+				end = int64(len(data))
+			}
+			pcm := data[body:end]
+			if frame := format.BytesPerSample(); frame > 0 {
+				pcm = pcm[:len(pcm)-len(pcm)%frame]
+			}
+			return pcm, format, nil
+		}
+
+		// Chunks are padded to an even number of bytes
+		next := int64(body) + chunkSize + chunkSize%2
+		if next > int64(len(data)) {
+			break
+		}
+		offset = int(next)
+	}
+
+	return nil, format, errors.New("WAV file has no data chunk")
+}
+
+// WAVToPCM decodes a WAV file into the default TTS PCM format (22050Hz mono
+// s16le), downmixing and resampling as needed
+func WAVToPCM(data []byte) ([]byte, error) {
+	pcm, format, err := DecodeWAV(data)
+	if err != nil {
+		return nil, err
+	}
+	if format.BitDepth != BitDepth || format.IsFloat {
+		return nil, fmt.Errorf("unsupported WAV sample format: %d-bit", format.BitDepth)
+	}
+
+	if format.Channels > 1 {
+		pcm = downmixPCM(pcm, format.Channels)
+		format.Channels = 1
+	}
+
+	return ResamplePCM(pcm, format, DefaultPCMFormat())
+}
+
+// downmixPCM averages interleaved 16-bit channels into a single channel
+func downmixPCM(pcm []byte, channels int) []byte {
+	frameSize := channels * BytesPerSample
+	frames := len(pcm) / frameSize
+	out := make([]byte, frames*BytesPerSample)
+
+	for i := 0; i < frames; i++ {
+		sum := 0
+		for ch := 0; ch < channels; ch++ {
+			offset := i*frameSize + ch*BytesPerSample
+			sum += int(int16(binary.LittleEndian.Uint16(pcm[offset:])))
+		}
+		binary.LittleEndian.PutUint16(out[i*BytesPerSample:], uint16(int16(sum/channels)))
+	}
+
+	return out
+}
@@ -0,0 +1,127 @@
+package tts
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestWAVHeader(t *testing.T) {
+	format := DefaultPCMFormat()
+	pcm := make([]byte, 4410)
+
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, pcm, format); err != nil {
+		t.Fatalf("WriteWAV failed: %v", err)
+	}
+
+	data := buf.Bytes()
+	if len(data) != WAVHeaderSize+len(pcm) {
+		t.Fatalf("Expected %d bytes, got %d", WAVHeaderSize+len(pcm), len(data))
+	}
+
+	if string(data[0:4]) != "RIFF" || string(data[8:12]) != "WAVE" {
+		t.Error("Missing RIFF/WAVE markers")
+	}
+	if string(data[12:16]) != "fmt " || string(data[36:40]) != "data" {
+		t.Error("Missing fmt/data chunk markers")
+	}
+
+	checks := []struct {
+		name     string
+		got      uint32
+		expected uint32
+	}{
+		{"riff size", binary.LittleEndian.Uint32(data[4:8]), uint32(36 + len(pcm))},
+		{"format tag", uint32(binary.LittleEndian.Uint16(data[20:22])), 1},
+		{"channels", uint32(binary.LittleEndian.Uint16(data[22:24])), 1},
+		{"sample rate", binary.LittleEndian.Uint32(data[24:28]), 22050},
+		{"byte rate", binary.LittleEndian.Uint32(data[28:32]), 44100},
+		{"block align", uint32(binary.LittleEndian.Uint16(data[32:34])), 2},
+		{"bit depth", uint32(binary.LittleEndian.Uint16(data[34:36])), 16},
+		{"data size", binary.LittleEndian.Uint32(data[40:44]), uint32(len(pcm))},
+	}
+	for _, c := range checks {
+		if c.got != c.expected {
+			t.Errorf("%s: expected %d, got %d", c.name, c.expected, c.got)
+		}
+	}
+
+	t.Run("rejects big-endian", func(t *testing.T) {
+		bad := format
+		bad.ByteOrder = binary.BigEndian
+		if _, err := WAVHeader(10, bad); err == nil {
+			t.Error("Expected error for big-endian format")
+		}
+	})
+}
+
+func TestDecodeWAV(t *testing.T) {
+	format := DefaultPCMFormat()
+	pcm := []byte{1, 0, 2, 0, 3, 0, 4, 0}
+
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, pcm, format); err != nil {
+		t.Fatalf("WriteWAV failed: %v", err)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		got, gotFormat, err := DecodeWAV(buf.Bytes())
+		if err != nil {
+			t.Fatalf("DecodeWAV failed: %v", err)
+		}
+		if !bytes.Equal(got, pcm) {
+			t.Errorf("Expected PCM %v, got %v", pcm, got)
+		}
+		if gotFormat.SampleRate != format.SampleRate || gotFormat.Channels != format.Channels || gotFormat.BitDepth != format.BitDepth {
+			t.Errorf("Format mismatch: %+v", gotFormat)
+		}
+	})
+
+	t.Run("streamed placeholder sizes", func(t *testing.T) {
+		streamed := append([]byte(nil), buf.Bytes()...)
+		binary.LittleEndian.PutUint32(streamed[4:8], 0xFFFFFFFF)
+		binary.LittleEndian.PutUint32(streamed[40:44], 0xFFFFFFFF)
+
+		got, _, err := DecodeWAV(streamed)
+		if err != nil {
+			t.Fatalf("DecodeWAV failed: %v", err)
+		}
+		if !bytes.Equal(got, pcm) {
+			t.Errorf("Expected PCM %v, got %v", pcm, got)
+		}
+	})
+
+	t.Run("not a wav", func(t *testing.T) {
+		if _, _, err := DecodeWAV([]byte("hello world, this is not audio")); err == nil {
+			t.Error("Expected error for non-WAV data")
+		}
+	})
+}
+
+func TestWAVToPCM(t *testing.T) {
+	// One second of stereo 16 kHz audio
+	input := PCMFormat{SampleRate: 16000, Channels: 2, BitDepth: 16, ByteOrder: binary.LittleEndian, IsSigned: true}
+	pcm := make([]byte, input.SampleRate*input.BytesPerSample())
+	for i := 0; i+3 < len(pcm); i += 4 {
+		binary.LittleEndian.PutUint16(pcm[i:], uint16(int16(1000)))
+		binary.LittleEndian.PutUint16(pcm[i+2:], uint16(int16(3000)))
+	}
+
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, pcm, input); err != nil {
+		t.Fatalf("WriteWAV failed: %v", err)
+	}
+
+	out, err := WAVToPCM(buf.Bytes())
+	if err != nil {
+		t.Fatalf("WAVToPCM failed: %v", err)
+	}
+
+	if got := CalculatePCMDuration(len(out), DefaultPCMFormat()); got < 0.99 || got > 1.01 {
+		t.Errorf("Expected about 1 second of audio, got %.3f", got)
+	}
+	if sample := int16(binary.LittleEndian.Uint16(out[100:])); sample != 2000 {
+		t.Errorf("Expected downmixed sample 2000, got %d", sample)
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+	"github.com/dgnsrekt/glow-tts/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ttsAudiobookOutput   string
+	ttsAudiobookSpeed    float64
+	ttsAudiobookTitle    string
+	ttsAudiobookAuthor   string
+	ttsAudiobookBitrate  string
+	ttsAudiobookAll      bool
+	ttsAudiobookSidecars bool
+
+	ttsAudiobookCmd = &cobra.Command{
+		Use:     "audiobook SOURCE",
+		Short:   "Export markdown to an M4B or MP3 audiobook with chapters",
+		Long:    paragraph(fmt.Sprintf("\n%s a markdown document, or every markdown file in a directory in the order glow lists them, to an audiobook. Each # and ## heading becomes a chapter, and the title and author come from front matter unless given. Encoding needs ffmpeg; the format follows the output extension (.m4b or .mp3). With --sidecars, captions and a JSON transcript of the whole book are written next to it.", keyword("Export"))),
+		Example: paragraph("glow tts audiobook handbook/ -o handbook.m4b\nglow tts audiobook guide.md -o guide.mp3 --author \"Docs Team\"\ncat doc.md | glow tts audiobook - -o doc.m4b"),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runTTSAudiobook,
+	}
+)
+
+// audiobookSource is one document of an audiobook
+type audiobookSource struct {
+	markdown string
+	// path is the file the document was read from, "" for stdin and URLs
+	path string
+	// name titles the document's chapter if it has no headings
+	name string
+}
+
+func runTTSAudiobook(cmd *cobra.Command, args []string) error {
+	if ttsAudiobookSpeed < tts.MinSpeed || ttsAudiobookSpeed > tts.MaxSpeed {
+		return fmt.Errorf("speed %.2f out of range [%.2f, %.2f]", ttsAudiobookSpeed, tts.MinSpeed, tts.MaxSpeed)
+	}
+
+	sources, base, err := audiobookSources(args[0])
+	if err != nil {
+		return err
+	}
+
+	output := ttsAudiobookOutput
+	if output == "" {
+		if base == "" {
+			return errors.New("--output is required when reading from stdin or a URL")
+		}
+		output = base + ".m4b"
+	}
+	format, err := tts.AudiobookFormatFor(output)
+	if err != nil {
+		return err
+	}
+
+	metadata := tts.AudiobookMetadata{Title: ttsAudiobookTitle, Author: ttsAudiobookAuthor}
+	book := tts.NewAudiobook(metadata)
+	for i, src := range sources {
+		if len(sources) > 1 {
+			fmt.Fprintf(os.Stderr, "[%d/%d] %s\n", i+1, len(sources), src.name)
+		}
+		result, frontMatter, err := exportDocument(cmd, src.markdown, src.path, ttsAudiobookSpeed)
+		if err != nil {
+			return fmt.Errorf("%s: %w", src.name, err)
+		}
+
+		name := src.name
+		if frontMatter.Title != "" {
+			name = frontMatter.Title
+		}
+		book.AddDocument(result, name, src.path)
+
+		// The first document names the book unless flags did
+		if i == 0 {
+			if book.Metadata.Title == "" {
+				book.Metadata.Title = name
+				if len(sources) > 1 {
+					book.Metadata.Title = filepath.Base(base)
+				}
+			}
+			if book.Metadata.Author == "" {
+				book.Metadata.Author = strings.Join(frontMatter.Authors, ", ")
+			}
+		}
+	}
+
+	if err := book.Encode(cmd.Context(), output, format, ttsAudiobookBitrate); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s (%d chapters, %s)\n",
+		output, len(book.Chapters), book.Duration().Round(time.Second))
+	if ttsAudiobookSidecars {
+		return writeSidecars(&book.ExportResult, output)
+	}
+	return nil
+}
+
+// audiobookSources reads the documents of an audiobook: the markdown files
+// of a directory in stash order, or a single source. base is the output
+// path without an extension, or "" if there is no file to name it after.
+func audiobookSources(arg string) ([]audiobookSource, string, error) {
+	if info, err := os.Stat(arg); err == nil && info.IsDir() {
+		cfg, err := env.ParseAs[ui.Config]()
+		if err != nil {
+			return nil, "", fmt.Errorf("error parsing config: %v", err)
+		}
+		cfg.ShowAllFiles = ttsAudiobookAll
+
+		paths, err := ui.FindMarkdownFiles(cfg, arg)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(paths) == 0 {
+			return nil, "", fmt.Errorf("no markdown files found in %s", arg)
+		}
+
+		sources := make([]audiobookSource, 0, len(paths))
+		for _, path := range paths {
+			b, err := os.ReadFile(path)
+			if err != nil {
+				return nil, "", err
+			}
+			sources = append(sources, audiobookSource{markdown: string(b), path: path, name: documentName(path)})
+		}
+		base, err := filepath.Abs(arg)
+		if err != nil {
+			return nil, "", err
+		}
+		return sources, base, nil
+	}
+
+	markdown, src, err := readMarkdownArg(arg)
+	if err != nil {
+		return nil, "", err
+	}
+	if src.URL == "" || isURL(src.URL) {
+		return []audiobookSource{{markdown: markdown, name: "Audiobook"}}, "", nil
+	}
+	return []audiobookSource{{markdown: markdown, path: src.URL, name: documentName(src.URL)}},
+		strings.TrimSuffix(src.URL, filepath.Ext(src.URL)), nil
+}
+
+// documentName is a file name without its directory or extension
+func documentName(path string) string {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func init() {
+	ttsAudiobookCmd.Flags().StringVarP(&ttsAudiobookOutput, "output", "o", "", "output .m4b or .mp3 file (default: SOURCE with a .m4b extension)")
+	ttsAudiobookCmd.Flags().Float64Var(&ttsAudiobookSpeed, "speed", 1.0, "speaking speed (0.5 to 2.0)")
+	ttsAudiobookCmd.Flags().StringVar(&ttsAudiobookTitle, "title", "", "book title (default: front matter title, or the directory name)")
+	ttsAudiobookCmd.Flags().StringVar(&ttsAudiobookAuthor, "author", "", "book author (default: front matter author)")
+	ttsAudiobookCmd.Flags().StringVar(&ttsAudiobookBitrate, "bitrate", "64k", "audio bitrate")
+	ttsAudiobookCmd.Flags().BoolVarP(&ttsAudiobookAll, "all", "a", false, "include files hidden by .gitignore and dot directories")
+	ttsAudiobookCmd.Flags().BoolVar(&ttsAudiobookSidecars, "sidecars", false, "also write .srt and .vtt captions and a .json transcript")
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+	"github.com/dgnsrekt/glow-tts/pkg/tts/engines"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ttsCmdEngine string
+
+	ttsCmd = &cobra.Command{
+		Use:   "tts",
+		Short: "Text-to-speech tools",
+		Long:  paragraph(fmt.Sprintf("\n%s tools that work without the TUI. The engine defaults to the one set in your glow-tts.yml.", keyword("Text-to-speech"))),
+		Args:  cobra.NoArgs,
+	}
+)
+
+// newTTSEngine creates the requested engine (or the configured default)
+// and returns it along with the loaded TTS configuration. The document's
+// front matter settings, if any, are applied to a copy of the configuration.
+func newTTSEngine(name string, doc tts.DocumentSettings) (tts.TTSEngine, *tts.TTSConfig, string, error) {
+	cfg, err := tts.LoadTTSConfig()
+	if err != nil {
+		log.Warn("Failed to load TTS config, using defaults", "error", err)
+		cfg = tts.DefaultTTSConfig()
+	}
+
+	name = cfg.GetEngineOrDefault(name)
+	cfg = cfg.ForDocument(name, doc)
+	if err := tts.ValidateEngineAvailability(name); err != nil {
+		return nil, nil, "", err
+	}
+
+	engine, err := engines.NewEngine(name, cfg)
+	if err != nil {
+		return nil, nil, "", err
+	}
+	if err := engine.Validate(); err != nil {
+		return nil, nil, "", fmt.Errorf("%s engine is not ready: %w", name, err)
+	}
+
+	return engine, cfg, name, nil
+}
+
+// closeTTSEngine releases any resources held by the engine.
+func closeTTSEngine(engine tts.TTSEngine, name string) {
+	if err := tts.NewEngineLifecycle(engine, name).Shutdown(context.Background()); err != nil {
+		log.Warn("TTS engine cleanup failed", "engine", name, "error", err)
+	}
+}
+
+// readMarkdownArg reads a markdown source argument. Front matter is kept so
+// the TTS parser can announce the title and apply the document's settings.
+func readMarkdownArg(arg string) (string, *source, error) {
+	src, err := sourceFromArg(arg)
+	if err != nil {
+		return "", nil, err
+	}
+	defer src.reader.Close() //nolint:errcheck
+
+	b, err := io.ReadAll(src.reader)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to read from reader: %w", err)
+	}
+
+	return string(b), src, nil
+}
+
+func init() {
+	ttsCmd.PersistentFlags().StringVarP(&ttsCmdEngine, "engine", "e", "", "TTS engine to use (default from glow-tts.yml)")
+	ttsCmd.AddCommand(ttsExportCmd)
+	ttsCmd.AddCommand(ttsAudiobookCmd)
+	ttsCmd.AddCommand(ttsVoicesCmd)
+	ttsCmd.AddCommand(ttsCtlCmd)
+	ttsCmd.AddCommand(ttsReadCmd)
+	ttsCmd.AddCommand(ttsServeCmd)
+}
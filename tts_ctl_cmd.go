@@ -0,0 +1,103 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ttsCtlSocket string
+	ttsCtlJSON   bool
+
+	ttsCtlCmd = &cobra.Command{
+		Use:       "ctl ACTION [ARG]",
+		Short:     "Control playback in a running glow",
+		Long:      paragraph(fmt.Sprintf("\n%s a glow that is reading aloud through its control socket. Actions are play, pause, next, previous, seek SENTENCE (counted from 1, as in the status bar), speed RATE and status.", keyword("Control"))),
+		Example:   paragraph("glow tts ctl pause\nglow tts ctl seek 12\nglow tts ctl speed 1.25\nglow tts ctl status --json"),
+		Args:      cobra.RangeArgs(1, 2),
+		ValidArgs: []string{tts.ControlPlay, tts.ControlPause, tts.ControlNext, tts.ControlPrevious, tts.ControlSeek, tts.ControlSpeed, tts.ControlStatus},
+		RunE:      runTTSCtl,
+	}
+)
+
+func runTTSCtl(cmd *cobra.Command, args []string) error {
+	method := args[0]
+	var params any
+	switch method {
+	case tts.ControlSeek:
+		if len(args) != 2 {
+			return fmt.Errorf("seek needs a sentence number")
+		}
+		n, err := strconv.Atoi(args[1])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid sentence number: %s", args[1])
+		}
+		params = tts.ControlSeekParams{Sentence: n - 1}
+	case tts.ControlSpeed:
+		if len(args) != 2 {
+			return fmt.Errorf("speed needs a rate")
+		}
+		speed, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return fmt.Errorf("invalid speed: %s", args[1])
+		}
+		params = tts.ControlSpeedParams{Speed: speed}
+	case tts.ControlPlay, tts.ControlPause, tts.ControlNext, tts.ControlPrevious, tts.ControlStatus:
+		if len(args) != 1 {
+			return fmt.Errorf("%s takes no argument", method)
+		}
+	default:
+		return fmt.Errorf("unknown action: %s", method)
+	}
+
+	path := ttsCtlSocket
+	if path == "" {
+		cfg, err := tts.LoadTTSConfig()
+		if err != nil {
+			log.Warn("Failed to load TTS config, using defaults", "error", err)
+			cfg = tts.DefaultTTSConfig()
+		}
+		path = cfg.ControlSocket()
+	}
+
+	client, err := tts.DialControl(path)
+	if err != nil {
+		return err
+	}
+	defer client.Close() //nolint:errcheck
+
+	if method != tts.ControlStatus {
+		return client.Call(method, params, nil)
+	}
+
+	var status tts.ControlStatusResult
+	if err := client.Call(method, nil, &status); err != nil {
+		return err
+	}
+	if ttsCtlJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(status)
+	}
+
+	out := cmd.OutOrStdout()
+	if status.Sentence < 0 {
+		fmt.Fprintf(out, "%s %.2fx\n", status.State, status.Speed)
+		return nil
+	}
+	fmt.Fprintf(out, "%s %d/%d %.2fx\n", status.State, status.Sentence+1, status.Sentences, status.Speed)
+	if status.Text != "" {
+		fmt.Fprintln(out, status.Text)
+	}
+	return nil
+}
+
+func init() {
+	ttsCtlCmd.Flags().StringVar(&ttsCtlSocket, "socket", "", "control socket (default from glow-tts.yml)")
+	ttsCtlCmd.Flags().BoolVar(&ttsCtlJSON, "json", false, "print status as JSON")
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+	"github.com/dgnsrekt/glow-tts/pkg/tts/engines"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	ttsExportOutput   string
+	ttsExportSpeed    float64
+	ttsExportSidecars bool
+
+	ttsExportCmd = &cobra.Command{
+		Use:     "export SOURCE",
+		Short:   "Export a markdown document to a WAV file",
+		Long:    paragraph(fmt.Sprintf("\n%s a markdown document to a single WAV file. Every sentence is synthesized with the selected engine and joined with a short pause. With --sidecars, SRT and WebVTT captions and a JSON transcript are written next to the audio.", keyword("Export"))),
+		Example: paragraph("glow tts export README.md\nglow tts export -e gtts notes.md -o notes.wav\nglow tts export talk.md --sidecars\ncat doc.md | glow tts export - -o doc.wav"),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runTTSExport,
+	}
+)
+
+func runTTSExport(cmd *cobra.Command, args []string) error {
+	if ttsExportSpeed < tts.MinSpeed || ttsExportSpeed > tts.MaxSpeed {
+		return fmt.Errorf("speed %.2f out of range [%.2f, %.2f]", ttsExportSpeed, tts.MinSpeed, tts.MaxSpeed)
+	}
+
+	markdown, src, err := readMarkdownArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	output := ttsExportOutput
+	if output == "" {
+		if src.URL == "" || isURL(src.URL) {
+			return errors.New("--output is required when reading from stdin or a URL")
+		}
+		output = strings.TrimSuffix(src.URL, filepath.Ext(src.URL)) + ".wav"
+	}
+	if output == "-" && ttsExportSidecars {
+		return errors.New("--sidecars needs an output file to name them after")
+	}
+
+	documentPath := ""
+	if src.URL != "" && !isURL(src.URL) {
+		documentPath = src.URL
+	}
+	result, _, err := exportDocument(cmd, markdown, documentPath, ttsExportSpeed)
+	if err != nil {
+		return err
+	}
+
+	if output == "-" {
+		return result.WriteWAV(cmd.OutOrStdout())
+	}
+
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("unable to create output file: %w", err)
+	}
+	if err := result.WriteWAV(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("unable to write output file: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s (%d sentences, %s)\n",
+		output, len(result.Segments), result.Duration().Round(time.Second))
+	if ttsExportSidecars {
+		return writeSidecars(result, output)
+	}
+	return nil
+}
+
+// writeSidecars writes captions and a transcript named after output
+func writeSidecars(result *tts.ExportResult, output string) error {
+	paths, err := result.WriteSidecars(strings.TrimSuffix(output, filepath.Ext(output)))
+	for _, path := range paths {
+		fmt.Fprintf(os.Stderr, "Wrote %s\n", path)
+	}
+	return err
+}
+
+// exportDocument synthesizes a markdown document with the voice, speed and
+// lexicon it asks for. speed applies unless the document sets its own and
+// the --speed flag was not given.
+func exportDocument(cmd *cobra.Command, markdown, documentPath string, speed float64) (*tts.ExportResult, tts.FrontMatter, error) {
+	frontMatter, _, err := tts.ParseFrontMatter(markdown)
+	if err != nil {
+		log.Warn("Ignoring front matter", "error", err)
+	}
+
+	engine, cfg, engineName, err := newTTSEngine(ttsCmdEngine, frontMatter.TTS)
+	if err != nil {
+		return nil, frontMatter, err
+	}
+	defer closeTTSEngine(engine, engineName)
+
+	config := tts.DefaultExportConfig()
+	config.LanguageEngines = engines.NewLanguageEngines(engineName, cfg)
+	for _, languageEngine := range config.LanguageEngines {
+		defer closeTTSEngine(languageEngine, engineName)
+	}
+	config.Speed = speed
+	if speed := frontMatter.TTS.Speed; speed > 0 && !cmd.Flags().Changed("speed") {
+		if speed < tts.MinSpeed || speed > tts.MaxSpeed {
+			log.Warn("Ignoring document speed", "speed", speed)
+		} else {
+			config.Speed = speed
+		}
+	}
+	config.Parser = cfg.ParserConfig()
+
+	if config.Lexicon, err = cfg.LoadLexicon(documentPath); err != nil {
+		return nil, frontMatter, err
+	}
+	progress := false
+	if term.IsTerminal(int(os.Stderr.Fd())) {
+		config.OnProgress = func(done, total int) {
+			fmt.Fprintf(os.Stderr, "\rSynthesizing sentence %d/%d", done, total)
+			progress = true
+		}
+	}
+
+	exporter, err := tts.NewExporter(engine, config)
+	if err != nil {
+		return nil, frontMatter, err
+	}
+
+	result, err := exporter.Export(markdown)
+	// End the progress line however the export finished
+	if progress {
+		fmt.Fprintln(os.Stderr)
+	}
+	if err != nil {
+		return nil, frontMatter, fmt.Errorf("unable to export audio: %w", err)
+	}
+	return result, frontMatter, nil
+}
+
+func init() {
+	ttsExportCmd.Flags().StringVarP(&ttsExportOutput, "output", "o", "", "output WAV file, or - for stdout (default: SOURCE with a .wav extension)")
+	ttsExportCmd.Flags().Float64Var(&ttsExportSpeed, "speed", 1.0, "speaking speed (0.5 to 2.0)")
+	ttsExportCmd.Flags().BoolVar(&ttsExportSidecars, "sidecars", false, "also write .srt and .vtt captions and a .json transcript")
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+	"github.com/dgnsrekt/glow-tts/pkg/tts/engines"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var (
+	ttsReadFrom int
+
+	ttsReadCmd = &cobra.Command{
+		Use:     "read SOURCE",
+		Short:   "Read a markdown document aloud without the TUI",
+		Long:    paragraph(fmt.Sprintf("\n%s a markdown document aloud from start to finish, printing each sentence to stderr. On a terminal, space pauses, n and p skip between sentences, + and - change speed, and q quits. The control socket works as it does in the TUI.", keyword("Read"))),
+		Example: paragraph("glow tts read README.md\nglow tts read --from 40 notes.md\ncurl -s https://example.com/doc.md | glow tts read -"),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runTTSRead,
+	}
+)
+
+func runTTSRead(cmd *cobra.Command, args []string) error {
+	markdown, src, err := readMarkdownArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	frontMatter, _, err := tts.ParseFrontMatter(markdown)
+	if err != nil {
+		log.Warn("Ignoring front matter", "error", err)
+	}
+
+	engine, cfg, engineName, err := newTTSEngine(ttsCmdEngine, frontMatter.TTS)
+	if err != nil {
+		return err
+	}
+	defer closeTTSEngine(engine, engineName)
+
+	documentPath := ""
+	if src.URL != "" && !isURL(src.URL) {
+		documentPath = src.URL
+	}
+
+	speedCtrl := tts.NewSpeedController()
+	if speed := frontMatter.TTS.Speed; speed > 0 {
+		if err := speedCtrl.SetSpeed(speed); err != nil {
+			log.Warn("Ignoring document speed", "speed", speed)
+		}
+	}
+	languageEngines := engines.NewLanguageEngines(engineName, cfg)
+	for _, languageEngine := range languageEngines {
+		defer closeTTSEngine(languageEngine, engineName)
+	}
+	controller, err := newTTSReadController(engine, engineName, cfg, languageEngines, documentPath, speedCtrl)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = controller.Stop()
+		if queue := controller.GetQueue(); queue != nil {
+			queue.Stop()
+		}
+	}()
+
+	// Keys come from the terminal, even when the document is piped in
+	keys, restore := openTTSReadKeys()
+	defer restore()
+	newline := "\n"
+	if keys != nil {
+		newline = "\r\n"
+	}
+	say := func(format string, a ...any) {
+		fmt.Fprintf(os.Stderr, format+newline, a...)
+	}
+
+	var total int
+	reader, err := tts.NewReader(controller, markdown, tts.ReaderConfig{
+		Document: documentPath,
+		OnSentence: func(index int, sentence tts.Sentence) {
+			say("[%d/%d] %s", index+1, total, sentence.Text)
+		},
+	})
+	if err != nil {
+		return err
+	}
+	total = len(reader.Sentences())
+	if ttsReadFrom < 1 || ttsReadFrom > total {
+		return fmt.Errorf("--from must be between 1 and %d", total)
+	}
+
+	if cfg.Control.Enabled {
+		server, err := tts.ListenControl(cfg.ControlSocket(), reader)
+		if err != nil {
+			log.Warn("TTS control socket unavailable", "error", err)
+		} else {
+			defer server.Close() //nolint:errcheck
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if keys != nil {
+		go readTTSKeys(keys, reader, speedCtrl, stop, say)
+	}
+
+	return reader.Run(ctx, ttsReadFrom-1)
+}
+
+// newTTSReadController sets up a controller the way the TUI does, for the
+// engine and configuration of the document being read.
+func newTTSReadController(engine tts.TTSEngine, engineName string, cfg *tts.TTSConfig, languageEngines map[string]tts.TTSEngine, documentPath string, speedCtrl *tts.TTSSpeedController) (*tts.Controller, error) {
+	controller, err := tts.NewController(tts.ControllerConfig{
+		Engine:             engineName,
+		LookaheadSentences: 3,
+		DefaultSpeed:       1.0,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := controller.SetEngine(engine); err != nil {
+		return nil, err
+	}
+	controller.SetLanguageEngines(languageEngines)
+
+	parser, err := tts.NewSentenceParser(cfg.ParserConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create parser: %w", err)
+	}
+	if err := controller.SetParser(parser); err != nil {
+		return nil, err
+	}
+	if err := controller.SetSpeedController(speedCtrl); err != nil {
+		return nil, err
+	}
+
+	lexicon, err := cfg.LoadLexicon(documentPath)
+	if err != nil {
+		return nil, err
+	}
+	controller.SetLexicon(lexicon)
+	controller.SetGapless(cfg.GaplessConfig())
+
+	if err := controller.Initialize(); err != nil {
+		return nil, fmt.Errorf("failed to initialize controller: %w", err)
+	}
+	return controller, nil
+}
+
+// openTTSReadKeys puts the terminal into raw mode for single-key controls.
+// It returns nil when there is no terminal to read from.
+func openTTSReadKeys() (io.Reader, func()) {
+	in := os.Stdin
+	if !term.IsTerminal(int(in.Fd())) {
+		// stdin may hold the document; try the controlling terminal
+		tty, err := os.Open("/dev/tty")
+		if err != nil {
+			return nil, func() {}
+		}
+		in = tty
+	}
+
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		if in != os.Stdin {
+			_ = in.Close()
+		}
+		return nil, func() {}
+	}
+	return in, func() {
+		_ = term.Restore(int(in.Fd()), state)
+		if in != os.Stdin {
+			_ = in.Close()
+		}
+	}
+}
+
+// readTTSKeys carries out single-key controls until quit is pressed
+func readTTSKeys(keys io.Reader, reader *tts.Reader, speedCtrl *tts.TTSSpeedController, quit func(), say func(string, ...any)) {
+	buf := make([]byte, 16)
+	for {
+		n, err := keys.Read(buf)
+		if err != nil {
+			return
+		}
+
+		var action error
+		switch key := string(buf[:n]); key {
+		case " ":
+			action = reader.Toggle()
+		case "n", "\x1b[C":
+			action = reader.Next()
+		case "p", "\x1b[D":
+			action = reader.Previous()
+		case "+", "=":
+			if speed, err := speedCtrl.NextSpeed(); err == nil {
+				say("Speed %.2fx", speed)
+			}
+		case "-", "_":
+			if speed, err := speedCtrl.PreviousSpeed(); err == nil {
+				say("Speed %.2fx", speed)
+			}
+		case "q", "\x1b", "\x03":
+			quit()
+			return
+		}
+		if action != nil {
+			say("Error: %v", action)
+		}
+	}
+}
+
+func init() {
+	ttsReadCmd.Flags().IntVar(&ttsReadFrom, "from", 1, "sentence to start reading from")
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/caarlos0/env/v11"
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+	"github.com/dgnsrekt/glow-tts/pkg/tts/engines"
+	"github.com/dgnsrekt/glow-tts/ui"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ttsServeAddr  string
+	ttsServeSpeed float64
+	ttsServeTitle string
+	ttsServeAll   bool
+
+	ttsServeCmd = &cobra.Command{
+		Use:     "serve DIR",
+		Short:   "Serve the markdown files of a directory as streamed audio",
+		Long:    paragraph(fmt.Sprintf("\n%s the markdown files of a directory over HTTP. The index lists them in the order glow does, each with a player; audio is synthesized as it streams and cached, so a document is only synthesized once. /transcript/<path>.json maps every sentence to its place in the audio.", keyword("Serve"))),
+		Example: paragraph("glow tts serve docs/\nglow tts serve --addr :7070 handbook/"),
+		Args:    cobra.ExactArgs(1),
+		RunE:    runTTSServe,
+	}
+)
+
+func runTTSServe(cmd *cobra.Command, args []string) error {
+	if ttsServeSpeed < tts.MinSpeed || ttsServeSpeed > tts.MaxSpeed {
+		return fmt.Errorf("speed %.2f out of range [%.2f, %.2f]", ttsServeSpeed, tts.MinSpeed, tts.MaxSpeed)
+	}
+	root, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+	if info, err := os.Stat(root); err != nil {
+		return err
+	} else if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", args[0])
+	}
+
+	uiCfg, err := env.ParseAs[ui.Config]()
+	if err != nil {
+		return fmt.Errorf("error parsing config: %v", err)
+	}
+	uiCfg.ShowAllFiles = ttsServeAll
+
+	engine, cfg, engineName, err := newTTSEngine(ttsCmdEngine, tts.DocumentSettings{})
+	if err != nil {
+		return err
+	}
+	defer closeTTSEngine(engine, engineName)
+
+	config := tts.DefaultExportConfig()
+	config.Speed = ttsServeSpeed
+	config.Parser = cfg.ParserConfig()
+	config.LanguageEngines = engines.NewLanguageEngines(engineName, cfg)
+	for _, languageEngine := range config.LanguageEngines {
+		defer closeTTSEngine(languageEngine, engineName)
+	}
+	if cacheConfig := cfg.CacheConfig(); cacheConfig != nil {
+		cache, err := tts.NewTTSCacheManager(cacheConfig)
+		if err != nil {
+			log.Warn("TTS cache unavailable", "error", err)
+		} else {
+			defer cache.Close() //nolint:errcheck
+			config.Cache = cache
+		}
+	}
+
+	title := ttsServeTitle
+	if title == "" {
+		title = filepath.Base(root)
+	}
+	handler, err := tts.NewServer(engine, tts.ServerConfig{
+		Root:        root,
+		Documents:   func() ([]string, error) { return servedDocuments(uiCfg, root) },
+		Export:      config,
+		LoadLexicon: cfg.LoadLexicon,
+		Title:       title,
+	})
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", ttsServeAddr)
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: handler, ReadHeaderTimeout: 10 * time.Second}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = server.Shutdown(shutdownCtx)
+	}()
+
+	fmt.Fprintf(os.Stderr, "Serving %s at http://%s\n", root, listener.Addr())
+	if err := server.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// servedDocuments lists the markdown files under root in stash order, as
+// slash-separated paths relative to root
+func servedDocuments(cfg ui.Config, root string) ([]string, error) {
+	paths, err := ui.FindMarkdownFiles(cfg, root)
+	if err != nil {
+		return nil, err
+	}
+	documents := make([]string, 0, len(paths))
+	for _, path := range paths {
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, filepath.ToSlash(rel))
+	}
+	return documents, nil
+}
+
+func init() {
+	ttsServeCmd.Flags().StringVar(&ttsServeAddr, "addr", "localhost:7070", "address to listen on; use :7070 to share on the local network")
+	ttsServeCmd.Flags().Float64Var(&ttsServeSpeed, "speed", 1.0, "speaking speed (0.5 to 2.0)")
+	ttsServeCmd.Flags().StringVar(&ttsServeTitle, "title", "", "index page title (default: the directory name)")
+	ttsServeCmd.Flags().BoolVarP(&ttsServeAll, "all", "a", false, "include files hidden by .gitignore and dot directories")
+}
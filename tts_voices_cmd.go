@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/charmbracelet/log"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+	"github.com/dgnsrekt/glow-tts/pkg/tts/engines"
+	"github.com/spf13/cobra"
+)
+
+var (
+	ttsVoicesJSON bool
+
+	ttsVoicesCmd = &cobra.Command{
+		Use:     "voices",
+		Short:   "List the voices available to each engine",
+		Long:    paragraph(fmt.Sprintf("\n%s the voices each TTS engine can use. Piper models are found in the configured voice_dirs and the standard locations. Without --engine every engine is listed.", keyword("List"))),
+		Example: paragraph("glow tts voices\nglow tts voices -e piper\nglow tts voices --json"),
+		Args:    cobra.NoArgs,
+		RunE:    runTTSVoices,
+	}
+)
+
+func runTTSVoices(cmd *cobra.Command, _ []string) error {
+	cfg, err := tts.LoadTTSConfig()
+	if err != nil {
+		log.Warn("Failed to load TTS config, using defaults", "error", err)
+		cfg = tts.DefaultTTSConfig()
+	}
+
+	names := engines.Names
+	if ttsCmdEngine != "" {
+		if !engines.IsSupported(ttsCmdEngine) {
+			return fmt.Errorf("invalid TTS engine: %s", ttsCmdEngine)
+		}
+		names = []string{ttsCmdEngine}
+	}
+
+	voices := []tts.Voice{}
+	for _, name := range names {
+		list, err := engines.ListVoices(name, cfg)
+		if err != nil {
+			if ttsCmdEngine != "" {
+				return err
+			}
+			log.Debug("Skipping engine voices", "engine", name, "error", err)
+			continue
+		}
+		voices = append(voices, list...)
+	}
+
+	if ttsVoicesJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		return enc.Encode(voices)
+	}
+
+	if len(voices) == 0 {
+		fmt.Fprintln(os.Stderr, "No voices found. Run glow --check-deps to see which engines are installed.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ENGINE\tVOICE\tLANGUAGE\tQUALITY\tRATE\tNAME\tPATH")
+	for _, v := range voices {
+		rate := ""
+		if v.SampleRate > 0 {
+			rate = strconv.Itoa(v.SampleRate)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", v.Engine, v.ID, v.Language, v.Quality, rate, v.Name, v.Path)
+	}
+	return w.Flush()
+}
+
+func init() {
+	ttsVoicesCmd.Flags().BoolVar(&ttsVoicesJSON, "json", false, "print voices as JSON")
+}
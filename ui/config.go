@@ -15,7 +15,7 @@ type Config struct {
 	Path string
 
 	// TTS configuration
-	TTSEngine string // "piper" or "gtts" or empty for disabled
+	TTSEngine string // engine name (see engines.Names), empty for disabled
 
 	// For debugging the UI
 	HighPerformancePager bool `env:"GLOW_HIGH_PERFORMANCE_PAGER" envDefault:"true"`
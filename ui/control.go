@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+// controlTimeout bounds how long a control request waits for the TUI,
+// including the synthesis of the first sentence when playback starts
+const controlTimeout = 30 * time.Second
+
+// ttsControlMsg carries a control socket request into the Bubble Tea loop
+type ttsControlMsg struct {
+	method   string
+	sentence int
+	speed    float64
+	reply    chan ttsControlReply
+}
+
+// ttsControlReply is the outcome of a control request
+type ttsControlReply struct {
+	status tts.ControlStatusResult
+	err    error
+}
+
+// programControl drives a running program from the control socket. Each
+// request becomes a message so the model is only touched by its own loop.
+type programControl struct {
+	program *tea.Program
+}
+
+// ServeTTSControl listens on the control socket at path and forwards its
+// requests to the program
+func ServeTTSControl(p *tea.Program, path string) (*tts.ControlServer, error) {
+	return tts.ListenControl(path, &programControl{program: p})
+}
+
+func (c *programControl) send(msg ttsControlMsg) ttsControlReply {
+	msg.reply = make(chan ttsControlReply, 1)
+	go c.program.Send(msg)
+	select {
+	case reply := <-msg.reply:
+		return reply
+	case <-time.After(controlTimeout):
+		return ttsControlReply{err: errors.New("glow did not respond")}
+	}
+}
+
+func (c *programControl) Play() error {
+	return c.send(ttsControlMsg{method: tts.ControlPlay}).err
+}
+
+func (c *programControl) Pause() error {
+	return c.send(ttsControlMsg{method: tts.ControlPause}).err
+}
+
+func (c *programControl) Next() error {
+	return c.send(ttsControlMsg{method: tts.ControlNext}).err
+}
+
+func (c *programControl) Previous() error {
+	return c.send(ttsControlMsg{method: tts.ControlPrevious}).err
+}
+
+func (c *programControl) Seek(sentence int) error {
+	return c.send(ttsControlMsg{method: tts.ControlSeek, sentence: sentence}).err
+}
+
+func (c *programControl) SetSpeed(speed float64) error {
+	return c.send(ttsControlMsg{method: tts.ControlSpeed, speed: speed}).err
+}
+
+func (c *programControl) Status() (tts.ControlStatusResult, error) {
+	reply := c.send(ttsControlMsg{method: tts.ControlStatus})
+	return reply.status, reply.err
+}
+
+// handleTTSControl carries out a control request the way the matching key
+// would. Requests that start work reply once it is done, so the client sees
+// any error.
+func (m model) handleTTSControl(msg ttsControlMsg) tea.Cmd {
+	if msg.method == tts.ControlStatus {
+		msg.reply <- ttsControlReply{status: m.ttsControlStatus()}
+		return nil
+	}
+
+	if err := m.ttsControlReady(); err != nil {
+		msg.reply <- ttsControlReply{err: err}
+		return nil
+	}
+	m.tts.lastError = nil
+
+	switch msg.method {
+	case tts.ControlPlay:
+		if m.tts.isPlaying {
+			msg.reply <- ttsControlReply{}
+			return nil
+		}
+		m.tts.resumeOffer = nil
+		m.tts.SetLoadingState(true, false, "Synthesizing audio...")
+		return tea.Batch(
+			m.tts.loadingSpinner.Tick,
+			replyWhenDone(playTTSCmd(m.tts.controller, m.ttsDocumentText()), msg.reply),
+		)
+
+	case tts.ControlPause:
+		if !m.tts.isPlaying {
+			msg.reply <- ttsControlReply{}
+			return nil
+		}
+		return replyWhenDone(pauseTTSCmd(m.tts.controller), msg.reply)
+
+	case tts.ControlNext:
+		return replyWhenDone(nextSentenceCmd(m.tts.controller, m.tts.currentSentenceIndex, m.tts.totalSentences), msg.reply)
+
+	case tts.ControlPrevious:
+		return replyWhenDone(prevSentenceCmd(m.tts.controller, m.tts.currentSentenceIndex), msg.reply)
+
+	case tts.ControlSeek:
+		if msg.sentence >= m.tts.totalSentences {
+			msg.reply <- ttsControlReply{err: fmt.Errorf("the document has %d sentences", m.tts.totalSentences)}
+			return nil
+		}
+		m.tts.resumeOffer = nil
+		m.tts.controller.SetSpeed(m.tts.speedController.GetSpeed())
+		m.tts.SetLoadingState(true, false, "Synthesizing audio...")
+		return tea.Batch(
+			m.tts.loadingSpinner.Tick,
+			replyWhenDone(playFromTTSCmd(m.tts.controller, m.ttsDocumentText(), msg.sentence), msg.reply),
+		)
+
+	case tts.ControlSpeed:
+		return replyWhenDone(changeSpeedCmd(m.tts.controller, msg.speed), msg.reply)
+	}
+
+	msg.reply <- ttsControlReply{err: fmt.Errorf("unknown method %s", msg.method)}
+	return nil
+}
+
+// ttsControlReady returns why playback cannot be driven right now, if it
+// cannot
+func (m model) ttsControlReady() error {
+	switch {
+	case !m.tts.IsEnabled():
+		return errors.New("TTS is not enabled")
+	case !m.tts.isInitialized:
+		return errors.New("TTS is still initializing")
+	case m.state != stateShowDocument:
+		return errors.New("no document is open")
+	}
+	return nil
+}
+
+// ttsControlStatus reports playback as shown in the status bar
+func (m model) ttsControlStatus() tts.ControlStatusResult {
+	status := tts.ControlStatusResult{State: tts.PlaybackStopped.String(), Sentence: -1}
+	if !m.tts.IsEnabled() {
+		return status
+	}
+
+	status.Speed = m.tts.speedController.GetSpeed()
+	if m.state != stateShowDocument {
+		return status
+	}
+	status.Sentences = m.tts.totalSentences
+	status.Document = m.tts.docPath
+
+	switch {
+	case m.tts.isPlaying:
+		status.State = tts.PlaybackPlaying.String()
+	case m.tts.isPaused:
+		status.State = tts.PlaybackPaused.String()
+	default:
+		return status
+	}
+	status.Sentence = m.tts.currentSentenceIndex
+	if status.Sentence < len(m.tts.sentences) {
+		status.Text = m.tts.sentences[status.Sentence].Text
+	}
+	return status
+}
+
+// replyWhenDone runs cmd and replies with the error of the message it
+// produces, which is then handled as usual
+func replyWhenDone(cmd tea.Cmd, reply chan<- ttsControlReply) tea.Cmd {
+	return func() tea.Msg {
+		msg := cmd()
+		var err error
+		switch msg := msg.(type) {
+		case ttsPlayMsg:
+			err = msg.err
+		case ttsPauseMsg:
+			err = msg.err
+		case ttsNextMsg:
+			err = msg.err
+		case ttsPrevMsg:
+			err = msg.err
+		case ttsSpeedChangeMsg:
+			err = msg.err
+		}
+		reply <- ttsControlReply{err: err}
+		return msg
+	}
+}
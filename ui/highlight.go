@@ -0,0 +1,311 @@
+package ui
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+var ttsHighlightStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.AdaptiveColor{Light: "#1B1B1B", Dark: "#1B1B1B"}).
+	Background(lipgloss.AdaptiveColor{Light: "#FFE99A", Dark: "#E8C95A"})
+
+var ttsWordHighlightStyle = ttsHighlightStyle.
+	Background(lipgloss.AdaptiveColor{Light: "#FFB84D", Dark: "#F29D38"}).
+	Bold(true)
+
+// sentenceSpan is the location of a spoken sentence in the rendered output.
+// Columns are byte offsets into the ANSI-stripped lines.
+type sentenceSpan struct {
+	found     bool
+	startLine int
+	startCol  int
+	endLine   int
+	endCol    int
+	// firstWord and lastWord index the sentence's words in renderedDocument
+	firstWord int
+	lastWord  int
+}
+
+// renderedLine is a rendered line with its escape sequences separated from
+// the printable text.
+type renderedLine struct {
+	raw   string
+	plain string
+	// rawPos maps each byte of plain to its offset in raw
+	rawPos []int
+	// sgr holds the SGR sequences in effect before each byte of plain
+	sgr []string
+}
+
+// renderedWord is a normalized word found in the rendered output.
+type renderedWord struct {
+	text  string
+	line  int
+	start int
+	end   int
+}
+
+// renderedDocument indexes glamour output so spoken sentences can be found
+// in it.
+type renderedDocument struct {
+	lines []renderedLine
+	words []renderedWord
+	// gutter is the width of the line numbers before each line's content
+	gutter int
+}
+
+// newRenderedDocument splits rendered content into lines and words. The
+// first gutter columns of every line (line numbers) are ignored.
+func newRenderedDocument(content string, gutter int) *renderedDocument {
+	doc := &renderedDocument{gutter: gutter}
+	for i, raw := range strings.Split(content, "\n") {
+		line := parseRenderedLine(raw)
+		doc.lines = append(doc.lines, line)
+
+		start := -1
+		for col, r := range line.plain {
+			if col < gutter {
+				continue
+			}
+			isWordRune := unicode.IsLetter(r) || unicode.IsDigit(r)
+			if isWordRune && start < 0 {
+				start = col
+			} else if !isWordRune && start >= 0 {
+				doc.addWord(line.plain, i, start, col)
+				start = -1
+			}
+		}
+		if start >= 0 {
+			doc.addWord(line.plain, i, start, len(line.plain))
+		}
+	}
+	return doc
+}
+
+func (d *renderedDocument) addWord(plain string, line, start, end int) {
+	d.words = append(d.words, renderedWord{
+		text:  strings.ToLower(plain[start:end]),
+		line:  line,
+		start: start,
+		end:   end,
+	})
+}
+
+// parseRenderedLine separates printable text from CSI and OSC escape
+// sequences, remembering which SGR attributes apply to each byte.
+func parseRenderedLine(raw string) renderedLine {
+	line := renderedLine{raw: raw}
+	var plain strings.Builder
+	active := ""
+
+	for i := 0; i < len(raw); {
+		if raw[i] == '\x1b' && i+1 < len(raw) {
+			switch raw[i+1] {
+			case '[':
+				j := i + 2
+				for j < len(raw) && (raw[j] < 0x40 || raw[j] > 0x7e) {
+					j++
+				}
+				if j < len(raw) {
+					j++
+				}
+				seq := raw[i:j]
+				if strings.HasSuffix(seq, "m") {
+					if seq == "\x1b[0m" || seq == "\x1b[m" {
+						active = ""
+					} else {
+						active += seq
+					}
+				}
+				i = j
+				continue
+			case ']':
+				j := i + 2
+				for j < len(raw) {
+					if raw[j] == '\a' {
+						j++
+						break
+					}
+					if raw[j] == '\x1b' && j+1 < len(raw) && raw[j+1] == '\\' {
+						j += 2
+						break
+					}
+					j++
+				}
+				i = j
+				continue
+			}
+		}
+
+		_, size := utf8.DecodeRuneInString(raw[i:])
+		for k := 0; k < size; k++ {
+			line.rawPos = append(line.rawPos, i+k)
+			line.sgr = append(line.sgr, active)
+		}
+		plain.WriteString(raw[i : i+size])
+		i += size
+	}
+
+	line.plain = plain.String()
+	return line
+}
+
+// sentenceWords normalizes a sentence into the same words used for the
+// rendered output.
+func sentenceWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// locate finds words in the document starting at word index from. When an
+// exact match is not possible it falls back to matching the opening words.
+func (d *renderedDocument) locate(words []string, from int) (int, int, bool) {
+	if len(words) == 0 {
+		return 0, 0, false
+	}
+	if start, ok := d.find(words, from); ok {
+		return start, start + len(words) - 1, true
+	}
+
+	prefix := words[:min(len(words), 4)]
+	if start, ok := d.find(prefix, from); ok {
+		return start, min(start+len(words), len(d.words)) - 1, true
+	}
+	return 0, 0, false
+}
+
+func (d *renderedDocument) find(words []string, from int) (int, bool) {
+	for i := max(from, 0); i+len(words) <= len(d.words); i++ {
+		matched := true
+		for j, w := range words {
+			if d.words[i+j].text != w {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// mapSentences locates each sentence in the rendered output. Sentences are
+// searched in document order so repeated phrases resolve to the right place.
+func (d *renderedDocument) mapSentences(sentences []tts.Sentence) []sentenceSpan {
+	spans := make([]sentenceSpan, len(sentences))
+	next := 0
+
+	for i, s := range sentences {
+		first, last, ok := d.locate(sentenceWords(s.Text), next)
+		if !ok {
+			continue
+		}
+		spans[i] = sentenceSpan{
+			found:     true,
+			startLine: d.words[first].line,
+			startCol:  d.words[first].start,
+			endLine:   d.words[last].line,
+			endCol:    d.words[last].end,
+			firstWord: first,
+			lastWord:  last,
+		}
+		next = last + 1
+	}
+
+	return spans
+}
+
+// highlight returns the rendered content with span styled as the sentence
+// currently being spoken and, if word is not -1, the word-th word of the
+// sentence styled as the word being spoken.
+func (d *renderedDocument) highlight(span sentenceSpan, word int) string {
+	spoken := renderedWord{line: -1}
+	if word >= 0 && span.firstWord+word <= span.lastWord {
+		spoken = d.words[span.firstWord+word]
+	}
+
+	out := make([]string, len(d.lines))
+	for i, line := range d.lines {
+		if !span.found || i < span.startLine || i > span.endLine {
+			out[i] = line.raw
+			continue
+		}
+
+		start, end := 0, len(line.plain)
+		if i == span.startLine {
+			start = span.startCol
+		}
+		if i == span.endLine {
+			end = span.endCol
+		}
+		if i != span.startLine {
+			start = d.contentStart(line)
+		}
+		if spoken.line == i && spoken.start >= start && spoken.end <= end {
+			out[i] = line.highlightRanges(
+				styledRange{start, spoken.start, ttsHighlightStyle},
+				styledRange{spoken.start, spoken.end, ttsWordHighlightStyle},
+				styledRange{spoken.end, end, ttsHighlightStyle},
+			)
+			continue
+		}
+		out[i] = line.highlightRange(start, end)
+	}
+	return strings.Join(out, "\n")
+}
+
+// contentStart returns where a line's content begins, past the line number
+// gutter and the margin glamour indents wrapped lines with
+func (d *renderedDocument) contentStart(line renderedLine) int {
+	if len(line.plain) <= d.gutter {
+		return len(line.plain)
+	}
+	content := line.plain[d.gutter:]
+	return d.gutter + len(content) - len(strings.TrimLeft(content, " "))
+}
+
+// styledRange is a range of plain bytes of a line and the style to draw it
+// with
+type styledRange struct {
+	start int
+	end   int
+	style lipgloss.Style
+}
+
+// highlightRange styles plain bytes [start, end) of the line while keeping
+// the surrounding escape sequences intact.
+func (l renderedLine) highlightRange(start, end int) string {
+	return l.highlightRanges(styledRange{start, end, ttsHighlightStyle})
+}
+
+// highlightRanges styles adjacent ranges of the line, in order, while
+// keeping the surrounding escape sequences intact. Empty ranges are
+// skipped.
+func (l renderedLine) highlightRanges(ranges ...styledRange) string {
+	var parts []styledRange
+	for _, r := range ranges {
+		if r.start < r.end {
+			parts = append(parts, r)
+		}
+	}
+	if len(parts) == 0 || parts[len(parts)-1].end > len(l.plain) {
+		return l.raw
+	}
+
+	start, end := parts[0].start, parts[len(parts)-1].end
+	var b strings.Builder
+	b.WriteString(l.raw[:l.rawPos[start]])
+	for _, r := range parts {
+		b.WriteString(r.style.Render(l.plain[r.start:r.end]))
+	}
+	b.WriteString("\x1b[0m")
+	b.WriteString(l.sgr[end-1])
+	b.WriteString(l.raw[l.rawPos[end-1]+1:])
+	return b.String()
+}
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
+)
+
+func TestParseRenderedLine(t *testing.T) {
+	raw := "\x1b[1mHello\x1b[0m \x1b[38;5;252mworld\x1b[0m"
+	line := parseRenderedLine(raw)
+
+	if line.plain != "Hello world" {
+		t.Fatalf("Expected plain text %q, got %q", "Hello world", line.plain)
+	}
+	if len(line.rawPos) != len(line.plain) {
+		t.Fatalf("Expected %d raw positions, got %d", len(line.plain), len(line.rawPos))
+	}
+	if raw[line.rawPos[6]] != 'w' {
+		t.Errorf("Expected raw position of 'w', got %q", raw[line.rawPos[6]])
+	}
+	if line.sgr[0] != "\x1b[1m" {
+		t.Errorf("Expected bold to be active for first byte, got %q", line.sgr[0])
+	}
+	if line.sgr[5] != "" {
+		t.Errorf("Expected no active style after reset, got %q", line.sgr[5])
+	}
+}
+
+func TestMapSentences(t *testing.T) {
+	content := strings.Join([]string{
+		"  \x1b[1mIntroduction\x1b[0m",
+		"",
+		"  This is the first sentence of a long",
+		"  paragraph. Here's another one.",
+		"",
+		"  • This is the first sentence of a long paragraph.",
+	}, "\n")
+
+	doc := newRenderedDocument(content, 0)
+	sentences := []tts.Sentence{
+		{Text: "Introduction"},
+		{Text: "This is the first sentence of a long paragraph"},
+		{Text: "Here's another one"},
+		{Text: "This is the first sentence of a long paragraph"},
+		{Text: "Something that is not in the document"},
+	}
+
+	spans := doc.mapSentences(sentences)
+	expected := []sentenceSpan{
+		{found: true, startLine: 0, startCol: 2, endLine: 0, endCol: 14, firstWord: 0, lastWord: 0},
+		{found: true, startLine: 2, startCol: 2, endLine: 3, endCol: 11, firstWord: 1, lastWord: 9},
+		{found: true, startLine: 3, startCol: 13, endLine: 3, endCol: 31, firstWord: 10, lastWord: 13},
+		{found: true, startLine: 5, startCol: 6, endLine: 5, endCol: 52, firstWord: 14, lastWord: 22},
+		{found: false},
+	}
+
+	for i, want := range expected {
+		if spans[i] != want {
+			t.Errorf("Sentence %d: expected %+v, got %+v", i, want, spans[i])
+		}
+	}
+}
+
+func TestHighlightPreservesText(t *testing.T) {
+	content := "  \x1b[38;5;252mFirst line of text\x1b[0m\n  second line here."
+	doc := newRenderedDocument(content, 0)
+
+	spans := doc.mapSentences([]tts.Sentence{{Text: "text second line"}})
+	if !spans[0].found {
+		t.Fatal("Expected sentence to be found across wrapped lines")
+	}
+
+	highlighted := doc.highlight(spans[0], -1)
+	lines := strings.Split(highlighted, "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 lines, got %d", len(lines))
+	}
+
+	for i, line := range lines {
+		if got := parseRenderedLine(line).plain; got != doc.lines[i].plain {
+			t.Errorf("Line %d text changed: expected %q, got %q", i, doc.lines[i].plain, got)
+		}
+	}
+
+	if doc.highlight(sentenceSpan{}, -1) != content {
+		t.Error("Expected content to be unchanged when no span is found")
+	}
+}
+
+func TestHighlightSpokenWord(t *testing.T) {
+	content := "  \x1b[38;5;252mFirst line of text\x1b[0m\n  second line here."
+	doc := newRenderedDocument(content, 0)
+	spans := doc.mapSentences([]tts.Sentence{{Text: "text second line"}})
+
+	// The second word of the sentence is "second", at the start of line 1
+	line := doc.lines[1]
+	want := line.highlightRanges(
+		styledRange{2, 8, ttsWordHighlightStyle},
+		styledRange{8, 13, ttsHighlightStyle},
+	)
+	lines := strings.Split(doc.highlight(spans[0], 1), "\n")
+	if lines[1] != want {
+		t.Errorf("Expected the spoken word styled on its own:\n got %q\nwant %q", lines[1], want)
+	}
+	if got := parseRenderedLine(lines[1]).plain; got != line.plain {
+		t.Errorf("Line text changed: expected %q, got %q", line.plain, got)
+	}
+
+	// Words past the sentence are ignored
+	if doc.highlight(spans[0], 5) != doc.highlight(spans[0], -1) {
+		t.Error("Expected a word outside the sentence to highlight only the sentence")
+	}
+}
+
+func TestRenderedDocumentGutter(t *testing.T) {
+	content := "   1Hello there\n   2general Kenobi"
+	doc := newRenderedDocument(content, lineNumberWidth)
+
+	spans := doc.mapSentences([]tts.Sentence{{Text: "Hello there general Kenobi"}})
+	if !spans[0].found {
+		t.Fatal("Expected line numbers to be ignored when matching")
+	}
+	if spans[0].startCol != lineNumberWidth {
+		t.Errorf("Expected match to start after the gutter, got column %d", spans[0].startCol)
+	}
+
+	// Line numbers on wrapped lines stay unhighlighted
+	lines := strings.Split(doc.highlight(spans[0], -1), "\n")
+	if want := doc.lines[1].highlightRange(lineNumberWidth, len(doc.lines[1].plain)); lines[1] != want {
+		t.Errorf("Expected the wrapped line highlighted after the gutter:\n got %q\nwant %q", lines[1], want)
+	}
+	indented := newRenderedDocument("   1  Hello there\n   2  general Kenobi", lineNumberWidth)
+	span := indented.mapSentences([]tts.Sentence{{Text: "Hello there general Kenobi"}})[0]
+	if start := indented.contentStart(indented.lines[1]); start != lineNumberWidth+2 {
+		t.Errorf("Expected content to start after the gutter and margin, got column %d", start)
+	}
+	if !strings.HasPrefix(strings.Split(indented.highlight(span, -1), "\n")[1], "   2  ") {
+		t.Error("Expected the gutter and margin of the wrapped line left alone")
+	}
+}
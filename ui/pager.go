@@ -111,6 +111,14 @@ type pagerModel struct {
 	// TTS state reference for status display
 	tts *TTSState
 
+	// Rendered content before any TTS highlighting is applied, plus the
+	// index used to locate spoken sentences in it
+	renderedContent     string
+	rendered            *renderedDocument
+	sentenceSpans       []sentenceSpan
+	highlightedSentence int
+	highlightedWord     int
+
 	watcher *fsnotify.Watcher
 }
 
@@ -121,9 +129,11 @@ func newPagerModel(common *commonModel) pagerModel {
 	// HighPerformanceRendering is deprecated in Bubble Tea
 
 	m := pagerModel{
-		common:   common,
-		state:    pagerStateBrowse,
-		viewport: vp,
+		common:              common,
+		state:               pagerStateBrowse,
+		viewport:            vp,
+		highlightedSentence: -1,
+		highlightedWord:     -1,
 	}
 	m.initWatcher()
 	return m
@@ -184,6 +194,7 @@ func (m *pagerModel) unload() {
 	m.state = pagerStateBrowse
 	m.viewport.SetContent("")
 	m.viewport.YOffset = 0
+	m.resetTTSHighlight("")
 	m.unwatchFile()
 }
 
@@ -245,6 +256,7 @@ func (m pagerModel) update(msg tea.Msg) (pagerModel, tea.Cmd) {
 			"rawMarkdownLength", len(msg.rawMarkdown))
 
 		m.setContent(msg.content)
+		m.resetTTSHighlight(msg.content)
 		m.rawMarkdownText = msg.rawMarkdown
 		log.Debug("pager rawMarkdownText set", "length", len(m.rawMarkdownText))
 		cmds = append(cmds, m.watchFile)
@@ -279,14 +291,102 @@ func (m pagerModel) update(msg tea.Msg) (pagerModel, tea.Cmd) {
 
 	case statusMessageTimeoutMsg:
 		m.state = pagerStateBrowse
+
+	// Sentences changed, so previously located spans are stale
+	case ttsSentencesParsedMsg:
+		m.sentenceSpans = nil
+		m.highlightedSentence = -1
+		m.highlightedWord = -1
 	}
 
+	m.syncTTSHighlight()
+
 	m.viewport, cmd = m.viewport.Update(msg)
 	cmds = append(cmds, cmd)
 
 	return m, tea.Batch(cmds...)
 }
 
+// resetTTSHighlight forgets sentence locations after the content changes.
+func (m *pagerModel) resetTTSHighlight(content string) {
+	m.renderedContent = content
+	m.rendered = nil
+	m.sentenceSpans = nil
+	m.highlightedSentence = -1
+	m.highlightedWord = -1
+}
+
+// activeSentence returns the index of the sentence being spoken, or -1 when
+// nothing should be highlighted.
+func (m pagerModel) activeSentence() int {
+	if m.tts == nil || !m.tts.IsEnabled() || m.renderedContent == "" {
+		return -1
+	}
+	if !m.tts.isPlaying && !m.tts.isPaused {
+		return -1
+	}
+	if m.tts.currentSentenceIndex < 0 || m.tts.currentSentenceIndex >= len(m.tts.sentences) {
+		return -1
+	}
+	return m.tts.currentSentenceIndex
+}
+
+// syncTTSHighlight highlights the sentence and word currently being spoken
+// and scrolls the viewport so the sentence stays visible.
+func (m *pagerModel) syncTTSHighlight() {
+	idx := m.activeSentence()
+	word := -1
+	if idx >= 0 {
+		word = m.tts.activeWord()
+	}
+	if idx == m.highlightedSentence && word == m.highlightedWord {
+		return
+	}
+	moved := idx != m.highlightedSentence
+	m.highlightedSentence = idx
+	m.highlightedWord = word
+
+	if idx < 0 {
+		m.setContent(m.renderedContent)
+		return
+	}
+
+	if m.rendered == nil {
+		m.rendered = newRenderedDocument(m.renderedContent, m.gutterWidth())
+	}
+	if m.sentenceSpans == nil {
+		m.sentenceSpans = m.rendered.mapSentences(m.tts.sentences)
+	}
+
+	span := m.sentenceSpans[idx]
+	if !span.found {
+		log.Debug("spoken sentence not found in rendered output", "index", idx)
+		m.setContent(m.renderedContent)
+		return
+	}
+
+	m.setContent(m.rendered.highlight(span, word))
+	if !moved {
+		return
+	}
+
+	// Keep the sentence in view, leaving some context above it
+	top := m.viewport.YOffset
+	bottom := top + m.viewport.Height - 1
+	if span.startLine < top || span.endLine > bottom {
+		m.viewport.SetYOffset(span.startLine - m.viewport.Height/3)
+	}
+}
+
+// gutterWidth returns the width of the line number gutter added by
+// glamourRender, if any.
+func (m pagerModel) gutterWidth() int {
+	if m.common.cfg.ShowLineNumbers || !utils.IsMarkdownFile(m.currentDocument.Note) {
+		return lineNumberWidth
+	}
+	return 0
+}
+
 func (m pagerModel) View() string {
 	var b strings.Builder
 	fmt.Fprint(&b, m.viewport.View()+"\n")
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
@@ -10,6 +11,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/dgnsrekt/glow-tts/pkg/tts"
 	"github.com/dgnsrekt/glow-tts/pkg/tts/engines"
+	"github.com/dgnsrekt/glow-tts/utils"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/log"
 )
@@ -19,7 +21,7 @@ type TTSState struct {
 	// Controller manages the TTS pipeline
 	controller *tts.Controller
 
-	// Engine being used (piper or gtts)
+	// Engine being used (see engines.Names)
 	engine string
 
 	// Initialization state
@@ -46,9 +48,35 @@ type TTSState struct {
 	currentSentenceIndex int
 	totalSentences       int
 
+	// Word being spoken, as reported by the last monitor tick
+	currentWord spokenWord
+
 	// Speed control
 	speedController *tts.TTSSpeedController
 
+	// config is the loaded TTS configuration (set during initialization)
+	config *tts.TTSConfig
+
+	// Reading progress (progress is nil when resume is disabled)
+	progress       *tts.ProgressStore
+	progressWrites *progressWriter
+	docPath        string
+	docHash        string
+	resumeOffer    *tts.ReadingProgress
+	savedIndex     int
+
+	// The open document including its front matter, which the parser
+	// reads for the title and per-document settings
+	docText  string
+	document tts.DocumentSettings
+
+	// Per-document voice and speed from front matter. baseEngine is the
+	// configured engine and docEngine the document's own, if any;
+	// baseSpeed is the speed to return to after a document that set one.
+	baseEngine tts.TTSEngine
+	docEngine  tts.TTSEngine
+	baseSpeed  float64
+
 	// Error state
 	lastError error
 }
@@ -71,6 +99,9 @@ func NewTTSState(engine string) *TTSState {
 		loadingSpinner:  s,
 		loadingMessage:  "Initializing TTS engine",
 		playbackTimer:   t,
+		savedIndex:      -1,
+		progressWrites:  &progressWriter{applied: make(map[string]uint64)},
+		currentWord:     spokenWord{sentence: -1},
 	}
 }
 
@@ -159,6 +190,9 @@ type ttsSpeedChangeMsg struct {
 // ttsSentencesParsedMsg is sent when sentences are parsed from document
 type ttsSentencesParsedMsg struct {
 	sentences []tts.Sentence
+	hash      string
+	text      string
+	document  tts.DocumentSettings
 	err       error
 }
 
@@ -237,31 +271,25 @@ func initTTSWithTimeout(engine string, ttsState *TTSState) tea.Msg {
 		}
 		log.Debug("TTS controller created successfully")
 
-		// Set up the engine based on the engine string
-		var ttsEngine tts.TTSEngine
-		switch engine {
-		case "piper":
-			log.Debug("creating Piper engine")
-			piperEngine, err := engines.NewPiperEngine()
-			if err != nil {
-				log.Error("Piper engine creation failed", "error", err)
-				return ttsInitMsg{err: fmt.Errorf("failed to create Piper engine: %w", err)}
-			}
-			log.Debug("Piper engine created")
-			ttsEngine = piperEngine
-		case "gtts":
-			log.Debug("creating Google TTS engine")
-			gttsEngine, err := engines.NewGTTSEngine()
-			if err != nil {
-				log.Error("Google TTS engine creation failed", "error", err)
-				return ttsInitMsg{err: fmt.Errorf("failed to create Google TTS engine: %w", err)}
-			}
-			log.Debug("Google TTS engine created")
-			ttsEngine = gttsEngine
-		default:
-			return ttsInitMsg{err: fmt.Errorf("unsupported engine: %s", engine)}
+		// Set up the engine, honouring any engine settings from the TTS config
+		ttsConfig, err := tts.LoadTTSConfig()
+		if err != nil {
+			log.Warn("failed to load TTS config, using defaults", "error", err)
+			ttsConfig = tts.DefaultTTSConfig()
+		}
+		ttsState.config = ttsConfig
+		if ttsConfig.Playback.Resume {
+			ttsState.progress = tts.NewProgressStore(utils.ExpandPath(ttsConfig.Playback.ProgressFile))
 		}
 
+		ttsEngine, err := engines.NewEngine(engine, ttsConfig)
+		if err != nil {
+			log.Error("TTS engine creation failed", "engine", engine, "error", err)
+			return ttsInitMsg{err: err}
+		}
+		log.Debug("TTS engine created", "engine", engine)
+		ttsState.baseEngine = ttsEngine
+
 		// Set the engine
 		log.Debug("setting engine on controller")
 		if err := controller.SetEngine(ttsEngine); err != nil {
@@ -270,13 +298,13 @@ func initTTSWithTimeout(engine string, ttsState *TTSState) tea.Msg {
 		}
 		log.Debug("engine set successfully")
 
+		// Voices for sentences in other languages
+		languageEngines := engines.NewLanguageEngines(engine, ttsConfig)
+		controller.SetLanguageEngines(languageEngines)
+
 		// Set the parser
 		log.Debug("creating parser")
-		parserConfig := &tts.ParserConfig{
-			MinSentenceLength: 3,
-			MaxSentenceLength: 500,
-		}
-		parser, err := tts.NewSentenceParser(parserConfig)
+		parser, err := tts.NewSentenceParser(ttsParserConfig())
 		if err != nil {
 			log.Error("parser creation failed", "error", err)
 			return ttsInitMsg{err: fmt.Errorf("failed to create parser: %w", err)}
@@ -296,6 +324,8 @@ func initTTSWithTimeout(engine string, ttsState *TTSState) tea.Msg {
 		}
 		log.Debug("speed controller set successfully")
 
+		controller.SetGapless(ttsConfig.GaplessConfig())
+
 		// Initialize the controller
 		log.Debug("initializing controller")
 		if err := controller.Initialize(); err != nil {
@@ -312,6 +342,9 @@ func initTTSWithTimeout(engine string, ttsState *TTSState) tea.Msg {
 		
 		// Register engine for cleanup
 		lifecycle.Register(tts.NewEngineLifecycle(ttsEngine, engine))
+		for lang, languageEngine := range languageEngines {
+			lifecycle.Register(tts.NewEngineLifecycle(languageEngine, engine+" ("+lang+")"))
+		}
 		
 		// Register queue if it exists
 		if queue := controller.GetQueue(); queue != nil {
@@ -334,13 +367,40 @@ func initTTSWithTimeout(engine string, ttsState *TTSState) tea.Msg {
 		return ttsInitMsg{err: nil}
 }
 
-// parseSentencesCmd parses sentences from markdown content
+// ttsParserConfig returns the parser settings shared by the controller and
+// the pager, so sentence indices line up with what is being spoken
+func ttsParserConfig() *tts.ParserConfig {
+	cfg, err := tts.LoadTTSConfig()
+	if err != nil {
+		cfg = tts.DefaultTTSConfig()
+	}
+	return cfg.ParserConfig()
+}
+
+// parseSentencesCmd parses sentences from markdown content, which may start
+// with front matter
 func parseSentencesCmd(content string) tea.Cmd {
 	return func() tea.Msg {
-		// TODO: Implement actual sentence parsing
-		// For now, return empty sentences
+		frontMatter, _, err := tts.ParseFrontMatter(content)
+		if err != nil {
+			log.Warn("TTS: ignoring front matter", "error", err)
+		}
+
+		parser, err := tts.NewSentenceParser(ttsParserConfig())
+		if err != nil {
+			return ttsSentencesParsedMsg{err: fmt.Errorf("failed to create parser: %w", err)}
+		}
+		
+		sentences, err := parser.ParseSentences(content)
+		if err != nil {
+			return ttsSentencesParsedMsg{err: fmt.Errorf("failed to parse sentences: %w", err)}
+		}
+		
 		return ttsSentencesParsedMsg{
-			sentences: []tts.Sentence{},
+			sentences: sentences,
+			hash:      tts.ContentHash(content),
+			text:      content,
+			document:  frontMatter.TTS,
 			err:       nil,
 		}
 	}
@@ -371,9 +431,48 @@ func playTTSCmd(controller *tts.Controller, text string) tea.Cmd {
 	}
 }
 
+// playFromTTSCmd starts TTS playback at a saved sentence
+func playFromTTSCmd(controller *tts.Controller, text string, sentence int) tea.Cmd {
+	return func() tea.Msg {
+		if controller == nil {
+			return ttsPlayMsg{err: fmt.Errorf("TTS controller not initialized")}
+		}
+		if err := controller.PlayFrom(text, sentence); err != nil {
+			return ttsPlayMsg{err: fmt.Errorf("failed to resume: %w", err)}
+		}
+		return ttsPlayMsg{err: nil}
+	}
+}
+
 // ttsMonitorMsg is sent periodically during playback monitoring
 type ttsMonitorMsg struct {
 	continueMonitoring bool
+	sentenceIndex      int
+	wordIndex          int
+}
+
+// spokenWord is the index of a word within a sentence's timing words
+type spokenWord struct {
+	sentence int
+	word     int
+}
+
+// activeWord returns the word being spoken in the current sentence, or -1
+// when it is unknown or word highlighting is off
+func (t *TTSState) activeWord() int {
+	if t.config == nil || !t.config.Playback.HighlightWords || t.currentWord.sentence != t.currentSentenceIndex {
+		return -1
+	}
+	return t.currentWord.word
+}
+
+// monitorInterval is how often playback is polled; following words needs
+// more frequent updates than following sentences
+func (t *TTSState) monitorInterval() time.Duration {
+	if t.config != nil && t.config.Playback.HighlightWords {
+		return 100 * time.Millisecond
+	}
+	return 500 * time.Millisecond
 }
 
 // monitorPlaybackCmd monitors playback and sends updates when it finishes
@@ -398,10 +497,10 @@ func monitorPlaybackCmd(controller *tts.Controller) tea.Cmd {
 				}
 				// Continue monitoring for the next segment
 				log.Debug("TTS: Playing next segment, continuing monitor")
-				return ttsMonitorMsg{continueMonitoring: true}
+				return ttsMonitorMsg{continueMonitoring: true, sentenceIndex: controller.CurrentSentence(), wordIndex: -1}
 			}
 			// Still playing, continue monitoring
-			return ttsMonitorMsg{continueMonitoring: true}
+			return ttsMonitorMsg{continueMonitoring: true, sentenceIndex: controller.CurrentSentence(), wordIndex: controller.CurrentWord()}
 		}
 		
 		return nil
@@ -467,8 +566,11 @@ func nextSentenceCmd(controller *tts.Controller, currentIndex int, totalSentence
 			}
 		}
 		
-		// Update the index
-		newIndex := currentIndex + 1
+		// Update the index, preferring the position reported by the queue
+		newIndex := controller.CurrentSentence()
+		if newIndex < 0 {
+			newIndex = currentIndex + 1
+		}
 		if newIndex >= totalSentences {
 			newIndex = totalSentences - 1
 		}
@@ -507,8 +609,11 @@ func prevSentenceCmd(controller *tts.Controller, currentIndex int) tea.Cmd {
 			}
 		}
 		
-		// Update the index
-		newIndex := currentIndex - 1
+		// Update the index, preferring the position reported by the queue
+		newIndex := controller.CurrentSentence()
+		if newIndex < 0 {
+			newIndex = currentIndex - 1
+		}
 		if newIndex < 0 {
 			newIndex = 0
 		}
@@ -537,6 +642,196 @@ func changeSpeedCmd(controller *tts.Controller, speed float64) tea.Cmd {
 	}
 }
 
+// loadLexicon applies the user lexicon and any project lexicon next to the
+// open document
+func (t *TTSState) loadLexicon() {
+	if t.controller == nil || t.config == nil {
+		return
+	}
+	lexicon, err := t.config.LoadLexicon(t.docPath)
+	if err != nil {
+		log.Warn("TTS: failed to load lexicon", "error", err)
+		return
+	}
+	t.controller.SetLexicon(lexicon)
+}
+
+// applyDocument switches to the voice and speed requested by the open
+// document's front matter, or back to the configured ones. The loaded
+// TTSConfig is left untouched.
+func (t *TTSState) applyDocument(doc tts.DocumentSettings) {
+	if t.controller == nil || t.config == nil {
+		return
+	}
+
+	if t.speedController != nil {
+		switch {
+		case doc.Speed > 0:
+			if t.baseSpeed == 0 {
+				t.baseSpeed = t.speedController.GetSpeed()
+			}
+			if err := t.speedController.SetSpeed(doc.Speed); err != nil {
+				log.Warn("TTS: ignoring document speed", "speed", doc.Speed, "error", err)
+			}
+		case t.baseSpeed > 0:
+			_ = t.speedController.SetSpeed(t.baseSpeed)
+			t.baseSpeed = 0
+		}
+	}
+
+	previous := t.docEngine
+	t.docEngine = nil
+	if doc.SelectsVoice(t.engine) {
+		engine, err := engines.NewEngine(t.engine, t.config.ForDocument(t.engine, doc))
+		if err == nil {
+			err = engine.Validate()
+		}
+		if err != nil {
+			log.Warn("TTS: ignoring document voice", "voice", doc.Voice, "language", doc.Language, "error", err)
+		} else {
+			t.docEngine = engine
+			tts.GetLifecycleManager().Register(tts.NewEngineLifecycle(engine, t.engine))
+		}
+	}
+	if previous == nil && t.docEngine == nil {
+		return
+	}
+
+	engine := t.baseEngine
+	if t.docEngine != nil {
+		engine = t.docEngine
+	}
+	if err := t.controller.UseEngine(engine); err != nil {
+		log.Warn("TTS: failed to switch voice", "error", err)
+		return
+	}
+	if cleaner, ok := previous.(interface{ Cleanup() error }); ok {
+		_ = cleaner.Cleanup()
+	}
+}
+
+// checkResume looks up saved progress for the open document and offers to
+// continue from it. It runs once both the engine and the sentences are ready.
+func (t *TTSState) checkResume() {
+	t.resumeOffer = nil
+	t.savedIndex = -1
+	if t.progress == nil || t.docPath == "" || t.docHash == "" {
+		return
+	}
+
+	progress, ok := t.progress.Get(t.docPath, t.docHash)
+	if !ok || progress.Sentence <= 0 || progress.Sentence >= t.totalSentences {
+		return
+	}
+	log.Debug("TTS: saved progress found", "path", t.docPath, "sentence", progress.Sentence)
+	t.resumeOffer = &progress
+}
+
+// acceptResume restores the saved voice and speed and returns the sentence
+// to continue from. Voices name something different in each engine, so a
+// voice saved with another engine is left alone.
+func (t *TTSState) acceptResume() int {
+	offer := t.resumeOffer
+	t.resumeOffer = nil
+	switch {
+	case offer.Engine != "" && offer.Engine != t.engine:
+		log.Info("TTS: keeping the current voice, progress was saved with another engine", "saved", offer.Engine, "current", t.engine)
+	case offer.Voice != "" && offer.Voice != t.voiceSetting():
+		// The saved voice replaces the configured or front matter one for
+		// the rest of this document
+		t.document.Voice = offer.Voice
+		t.applyDocument(t.document)
+	}
+	if offer.Speed > 0 && t.speedController != nil {
+		if err := t.speedController.SetSpeed(offer.Speed); err != nil {
+			log.Debug("TTS: ignoring saved speed", "speed", offer.Speed, "error", err)
+		}
+	}
+	return offer.Sentence
+}
+
+// voiceSetting returns the voice in use for the open document, as front
+// matter would select it
+func (t *TTSState) voiceSetting() string {
+	if t.config == nil {
+		return ""
+	}
+	return t.config.ForDocument(t.engine, t.document).VoiceSetting(t.engine)
+}
+
+// progressWriter applies progress saves and clears in the order they were
+// issued, although the commands running them may finish in any order
+type progressWriter struct {
+	mu      sync.Mutex
+	issued  uint64
+	applied map[string]uint64
+}
+
+// next returns the sequence number of a new write
+func (w *progressWriter) next() uint64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.issued++
+	return w.issued
+}
+
+// apply runs write unless a later write for the same document already ran
+func (w *progressWriter) apply(path string, seq uint64, write func() error) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if seq < w.applied[path] {
+		return nil
+	}
+	w.applied[path] = seq
+	return write()
+}
+
+// saveProgressCmd records the current sentence for the open document
+func (t *TTSState) saveProgressCmd() tea.Cmd {
+	if t.progress == nil || t.docPath == "" || t.docHash == "" || t.currentSentenceIndex == t.savedIndex {
+		return nil
+	}
+	t.savedIndex = t.currentSentenceIndex
+
+	speed := 1.0
+	if t.speedController != nil {
+		speed = t.speedController.GetSpeed()
+	}
+	progress := tts.ReadingProgress{
+		Path:     t.docPath,
+		Hash:     t.docHash,
+		Sentence: t.currentSentenceIndex,
+		Total:    t.totalSentences,
+		Speed:    speed,
+		Engine:   t.engine,
+		Voice:    t.voiceSetting(),
+	}
+	store, writes := t.progress, t.progressWrites
+	seq := writes.next()
+	return func() tea.Msg {
+		if err := writes.apply(progress.Path, seq, func() error { return store.Save(progress) }); err != nil {
+			log.Warn("TTS: failed to save reading progress", "error", err)
+		}
+		return nil
+	}
+}
+
+// clearProgressCmd forgets the progress of a document that was read to the end
+func (t *TTSState) clearProgressCmd() tea.Cmd {
+	if t.progress == nil || t.docPath == "" {
+		return nil
+	}
+	t.savedIndex = -1
+	store, path, writes := t.progress, t.docPath, t.progressWrites
+	seq := writes.next()
+	return func() tea.Msg {
+		if err := writes.apply(path, seq, func() error { return store.Remove(path) }); err != nil {
+			log.Warn("TTS: failed to clear reading progress", "error", err)
+		}
+		return nil
+	}
+}
+
 // increaseSpeedCmd increases playback speed by one step
 func (t *TTSState) increaseSpeedCmd() tea.Cmd {
 	if t.speedController == nil {
@@ -654,6 +949,15 @@ func (t *TTSState) RenderStatus() string {
 		parts = append(parts, timerStyle.Render(timerText))
 	}
 
+	// Resume offer for a document read in an earlier session
+	if t.resumeOffer != nil && !t.isPlaying && !t.isInitializing {
+		offerStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("214"))
+		parts = append(parts, offerStyle.Render(
+			fmt.Sprintf("Resume at %d/%d? y/n", t.resumeOffer.Sentence+1, t.totalSentences),
+		))
+	}
+
 	// Sentence position (only show when not loading and not playing)
 	if t.totalSentences > 0 && !t.isInitializing && !t.isSynthesizing && !t.isBuffering && (!t.isPlaying || t.isPaused) {
 		posStyle := lipgloss.NewStyle().
@@ -1,7 +1,10 @@
 package ui
 
 import (
+	"path/filepath"
 	"testing"
+
+	"github.com/dgnsrekt/glow-tts/pkg/tts"
 )
 
 func TestTTSState(t *testing.T) {
@@ -124,4 +127,195 @@ func contains(s, substr string) bool {
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+func TestTTSResumeOffer(t *testing.T) {
+	state := NewTTSState("piper")
+	state.progress = tts.NewProgressStore(filepath.Join(t.TempDir(), "progress.json"))
+	state.docPath = "/docs/spec.md"
+	state.docHash = tts.ContentHash("spec")
+	state.totalSentences = 20
+	state.currentSentenceIndex = 7
+	if err := state.speedController.SetSpeed(1.5); err != nil {
+		t.Fatal(err)
+	}
+
+	// Saving runs as a command; run it directly
+	if cmd := state.saveProgressCmd(); cmd == nil {
+		t.Fatal("Expected a save command")
+	} else {
+		cmd()
+	}
+	if cmd := state.saveProgressCmd(); cmd != nil {
+		t.Error("Expected no save when the sentence has not changed")
+	}
+
+	reopened := NewTTSState("piper")
+	reopened.progress = state.progress
+	reopened.docPath = state.docPath
+	reopened.docHash = state.docHash
+	reopened.totalSentences = 20
+	reopened.checkResume()
+	if reopened.resumeOffer == nil {
+		t.Fatal("Expected an offer to resume")
+	}
+	if sentence := reopened.acceptResume(); sentence != 7 {
+		t.Errorf("Expected to resume at sentence 7, got %d", sentence)
+	}
+	if reopened.speedController.GetSpeed() != 1.5 {
+		t.Errorf("Expected saved speed to be restored, got %.2f", reopened.speedController.GetSpeed())
+	}
+
+	// Edited documents start from the top
+	reopened.docHash = tts.ContentHash("spec, edited")
+	reopened.checkResume()
+	if reopened.resumeOffer != nil {
+		t.Error("Expected no offer for changed content")
+	}
+}
+
+func TestTTSResumeVoice(t *testing.T) {
+	newState := func(voice string) *TTSState {
+		state := NewTTSState("command")
+		state.config = tts.DefaultTTSConfig()
+		state.config.Engines.Command.Template = "cat"
+		state.config.Engines.Command.Voice = voice
+		controller, err := tts.NewController(tts.ControllerConfig{Engine: "command"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.controller = controller
+		state.docPath = "/docs/spec.md"
+		state.docHash = tts.ContentHash("spec")
+		state.totalSentences = 20
+		return state
+	}
+
+	state := newState("amy")
+	state.progress = tts.NewProgressStore(filepath.Join(t.TempDir(), "progress.json"))
+	state.currentSentenceIndex = 3
+	state.saveProgressCmd()()
+
+	// The voice configured since is replaced by the saved one
+	reopened := newState("bob")
+	reopened.progress = state.progress
+	reopened.checkResume()
+	if reopened.resumeOffer == nil || reopened.resumeOffer.Voice != "amy" {
+		t.Fatalf("Expected an offer with the saved voice, got %+v", reopened.resumeOffer)
+	}
+	reopened.acceptResume()
+	if reopened.docEngine == nil || reopened.docEngine.GetName() != "Command (cat, amy)" {
+		t.Errorf("Expected the saved voice to be restored, got %v", reopened.docEngine)
+	}
+	if voice := reopened.voiceSetting(); voice != "amy" {
+		t.Errorf("Expected later saves to keep the restored voice, got %q", voice)
+	}
+
+	// A voice saved with another engine means something else here
+	other := newState("bob")
+	other.engine = "espeak"
+	other.progress = state.progress
+	other.currentSentenceIndex = 5
+	other.saveProgressCmd()()
+	other.engine = "command"
+	other.checkResume()
+	other.acceptResume()
+	if other.docEngine != nil {
+		t.Errorf("Expected a voice saved with another engine to be ignored, got %s", other.docEngine.GetName())
+	}
+}
+
+func TestTTSProgressWriteOrder(t *testing.T) {
+	state := NewTTSState("piper")
+	state.progress = tts.NewProgressStore(filepath.Join(t.TempDir(), "progress.json"))
+	state.docPath = "/docs/spec.md"
+	state.docHash = tts.ContentHash("spec")
+	state.totalSentences = 20
+	state.currentSentenceIndex = 7
+
+	// The document is read to the end before the earlier save gets to run
+	save := state.saveProgressCmd()
+	clearCmd := state.clearProgressCmd()
+	clearCmd()
+	save()
+
+	if _, ok := state.progress.Get(state.docPath, state.docHash); ok {
+		t.Error("Expected a save issued before the clear not to bring the progress back")
+	}
+}
+
+func TestTTSDocumentSettings(t *testing.T) {
+	msg, ok := parseSentencesCmd("---\ntitle: Notes\ntts:\n  speed: 1.5\n---\nHello there.\n")().(ttsSentencesParsedMsg)
+	if !ok || msg.err != nil {
+		t.Fatalf("Expected parsed sentences, got %+v", msg)
+	}
+	if msg.document.Speed != 1.5 {
+		t.Errorf("Expected document speed 1.5, got %.2f", msg.document.Speed)
+	}
+	if len(msg.sentences) != 2 || msg.sentences[0].SpokenText() != "Notes." {
+		t.Errorf("Expected the title to be announced first, got %+v", msg.sentences)
+	}
+
+	state := NewTTSState("piper")
+	state.config = tts.DefaultTTSConfig()
+	controller, err := tts.NewController(tts.ControllerConfig{Engine: "piper"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.controller = controller
+
+	state.applyDocument(msg.document)
+	if state.speedController.GetSpeed() != 1.5 {
+		t.Errorf("Expected document speed, got %.2f", state.speedController.GetSpeed())
+	}
+
+	// The next document without settings returns to the previous speed
+	state.applyDocument(tts.DocumentSettings{})
+	if state.speedController.GetSpeed() != 1.0 {
+		t.Errorf("Expected speed to be restored, got %.2f", state.speedController.GetSpeed())
+	}
+	if state.config.Playback.DefaultSpeed != 1.0 {
+		t.Error("Document settings must not change the loaded configuration")
+	}
+}
+
+func TestTTSControlRequests(t *testing.T) {
+	state := NewTTSState("piper")
+	state.sentences = []tts.Sentence{{Text: "One."}, {Text: "Two."}}
+	state.totalSentences = 2
+	state.docPath = "/docs/spec.md"
+	m := model{tts: state, state: stateShowDocument}
+
+	request := func(method string) ttsControlReply {
+		msg := ttsControlMsg{method: method, reply: make(chan ttsControlReply, 1)}
+		if cmd := m.handleTTSControl(msg); cmd != nil {
+			t.Fatalf("Expected %s to be answered without running a command", method)
+		}
+		return <-msg.reply
+	}
+
+	if reply := request(tts.ControlStatus); reply.status.State != "stopped" || reply.status.Sentence != -1 || reply.status.Sentences != 2 {
+		t.Errorf("Unexpected status before playback: %+v", reply.status)
+	}
+
+	state.isPlaying = true
+	state.isStopped = false
+	state.currentSentenceIndex = 1
+	reply := request(tts.ControlStatus)
+	if reply.status.State != "playing" || reply.status.Sentence != 1 || reply.status.Text != "Two." || reply.status.Document != "/docs/spec.md" {
+		t.Errorf("Unexpected status while playing: %+v", reply.status)
+	}
+
+	// Playback cannot be driven until the engine is ready
+	if reply := request(tts.ControlPause); reply.err == nil {
+		t.Error("Expected an error while TTS is initializing")
+	}
+	state.isInitialized = true
+	if reply := request(tts.ControlPlay); reply.err != nil {
+		t.Errorf("Expected play to be a no-op while playing, got %v", reply.err)
+	}
+
+	m.state = stateShowStash
+	if reply := request(tts.ControlNext); reply.err == nil {
+		t.Error("Expected an error without an open document")
+	}
+}
@@ -126,6 +126,18 @@ type model struct {
 	localFileFinder chan gitcha.SearchResult
 }
 
+// ttsDocumentText returns the markdown to play for the open document.
+// The TTS copy keeps the front matter; the pager strips it for display.
+func (m model) ttsDocumentText() string {
+	if m.tts != nil && m.tts.docText != "" {
+		return m.tts.docText
+	}
+	if m.pager.rawMarkdownText != "" {
+		return m.pager.rawMarkdownText
+	}
+	return m.pager.currentDocument.Body
+}
+
 // unloadDocument unloads a document from the pager. Note that while this
 // method alters the model we also need to send along any commands returned.
 func (m *model) unloadDocument() []tea.Cmd {
@@ -133,6 +145,12 @@ func (m *model) unloadDocument() []tea.Cmd {
 	m.stash.viewState = stashStateReady
 	m.pager.unload()
 	m.pager.showHelp = false
+	if m.tts != nil {
+		m.tts.docPath = ""
+		m.tts.docHash = ""
+		m.tts.docText = ""
+		m.tts.resumeOffer = nil
+	}
 
 	var batch []tea.Cmd
 	// High performance rendering is deprecated in newer Bubble Tea versions
@@ -241,7 +259,7 @@ func (m model) Init() tea.Cmd {
 		
 		// Parse sentences for TTS if enabled
 		if m.tts != nil && m.tts.IsEnabled() {
-			cmds = append(cmds, parseSentencesCmd(body))
+			cmds = append(cmds, parseSentencesCmd(string(content)))
 		}
 	}
 
@@ -341,18 +359,13 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.tts.isPlaying {
 					return m, pauseTTSCmd(m.tts.controller)
 				} else {
-					// Get the raw markdown text from the pager (not the glamour-rendered version)
-					documentText := m.pager.rawMarkdownText
-					if documentText == "" {
-						// Fallback to currentDocument.Body if rawMarkdownText not set yet
-						documentText = m.pager.currentDocument.Body
-						log.Debug("rawMarkdownText empty, using currentDocument.Body", 
-							"bodyLength", len(documentText))
-					} else {
-						log.Debug("using rawMarkdownText", "length", len(documentText))
-					}
+					// Get the raw markdown text (not the glamour-rendered version)
+					documentText := m.ttsDocumentText()
 					log.Debug("TTS play command", "textLength", len(documentText))
 					
+					// Playing from the top declines any resume offer
+					m.tts.resumeOffer = nil
+					
 					// Set synthesizing state before playing
 					m.tts.SetLoadingState(true, false, "Synthesizing audio...")
 					
@@ -364,6 +377,29 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 			}
 
+		case "y":
+			// TTS: Continue from the saved sentence
+			if m.tts != nil && m.tts.resumeOffer != nil && m.tts.isInitialized && m.state == stateShowDocument {
+				m.tts.lastError = nil
+				sentence := m.tts.acceptResume()
+				documentText := m.ttsDocumentText()
+				if m.tts.controller != nil {
+					m.tts.controller.SetSpeed(m.tts.speedController.GetSpeed())
+				}
+				m.tts.SetLoadingState(true, false, "Synthesizing audio...")
+				return m, tea.Batch(
+					m.tts.loadingSpinner.Tick,
+					playFromTTSCmd(m.tts.controller, documentText, sentence),
+				)
+			}
+
+		case "n":
+			// TTS: Dismiss the resume offer
+			if m.tts != nil && m.tts.resumeOffer != nil && m.state == stateShowDocument {
+				m.tts.resumeOffer = nil
+				return m, nil
+			}
+
 		case "left":
 			// TTS: Previous sentence (when in document view with TTS)
 			if m.tts != nil && m.tts.IsEnabled() && m.state == stateShowDocument {
@@ -426,6 +462,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.pager.currentDocument = *msg
 		body := string(utils.RemoveFrontmatter([]byte(msg.Body)))
 		cmds = append(cmds, renderWithGlamour(m.pager, body))
+		
+		// Parse sentences for TTS so playback can be followed in the pager
+		if m.tts != nil && m.tts.IsEnabled() {
+			cmds = append(cmds, parseSentencesCmd(msg.Body))
+		}
 
 	case contentRenderedMsg:
 		m.state = stateShowDocument
@@ -475,6 +516,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				log.Debug("TTS state after init", 
 					"isInitialized", m.tts.isInitialized, 
 					"isInitializing", m.tts.isInitializing)
+				// Sentences may have been parsed before the engine was ready
+				m.tts.applyDocument(m.tts.document)
+				m.tts.loadLexicon()
+				m.tts.checkResume()
 			}
 			// Force a UI refresh by returning a no-op command
 			// This ensures the view is re-rendered with the updated TTS state
@@ -518,6 +563,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.tts.sentences = msg.sentences
 				m.tts.totalSentences = len(msg.sentences)
 				m.tts.currentSentenceIndex = 0
+				m.tts.docPath = m.pager.currentDocument.localPath
+				m.tts.docHash = msg.hash
+				m.tts.docText = msg.text
+				m.tts.document = msg.document
+				if m.tts.isInitialized {
+					m.tts.applyDocument(msg.document)
+					m.tts.loadLexicon()
+					m.tts.checkResume()
+				}
 			}
 		}
 
@@ -535,6 +589,10 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.tts.isPlaying = true
 				m.tts.isPaused = false
 				m.tts.isStopped = false
+				if idx := m.tts.controller.CurrentSentence(); idx >= 0 {
+					m.tts.currentSentenceIndex = idx
+				}
+				cmds = append(cmds, m.tts.saveProgressCmd())
 				// Record playback start time and start timer
 				m.tts.playbackStart = time.Now()
 				// Initialize and start timer
@@ -553,6 +611,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.tts.isPaused = true
 				// Stop the timer to pause it
 				cmds = append(cmds, m.tts.playbackTimer.Stop())
+				cmds = append(cmds, m.tts.saveProgressCmd())
 			}
 		}
 
@@ -579,6 +638,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, clearTTSErrorCmd(2*time.Second))
 			} else {
 				m.tts.currentSentenceIndex = msg.sentenceIndex
+				cmds = append(cmds, m.tts.saveProgressCmd())
 			}
 		}
 
@@ -590,6 +650,7 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				cmds = append(cmds, clearTTSErrorCmd(2*time.Second))
 			} else {
 				m.tts.currentSentenceIndex = msg.sentenceIndex
+				cmds = append(cmds, m.tts.saveProgressCmd())
 			}
 		}
 	
@@ -598,6 +659,9 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.tts.lastError = nil
 		}
 
+	case ttsControlMsg:
+		return m, m.handleTTSControl(msg)
+
 	case ttsSpeedChangeMsg:
 		if m.tts != nil {
 			if msg.err != nil {
@@ -617,6 +681,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ttsPlaybackFinishedMsg:
 		if m.tts != nil {
 			log.Debug("TTS playback finished")
+			// The document was read to the end, so there is nothing to resume
+			cmds = append(cmds, m.tts.clearProgressCmd())
 			m.tts.isPlaying = false
 			m.tts.isPaused = false
 			m.tts.isStopped = true
@@ -627,9 +693,14 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	
 	case ttsMonitorMsg:
 		if m.tts != nil && m.tts.isPlaying {
+			if msg.sentenceIndex >= 0 && msg.sentenceIndex < m.tts.totalSentences {
+				m.tts.currentSentenceIndex = msg.sentenceIndex
+				m.tts.currentWord = spokenWord{sentence: msg.sentenceIndex, word: msg.wordIndex}
+				cmds = append(cmds, m.tts.saveProgressCmd())
+			}
 			if msg.continueMonitoring {
 				// Continue monitoring playback after a delay
-				cmds = append(cmds, tea.Tick(500*time.Millisecond, func(time.Time) tea.Msg {
+				cmds = append(cmds, tea.Tick(m.tts.monitorInterval(), func(time.Time) tea.Msg {
 					// After delay, check playback status again
 					return monitorPlaybackCmd(m.tts.controller)()
 				}))
@@ -733,6 +804,38 @@ func findLocalFiles(m commonModel) tea.Cmd {
 	}
 }
 
+// FindMarkdownFiles returns the markdown files under dir in the order the
+// stash lists them, honouring cfg.ShowAllFiles the same way. Paths are
+// absolute.
+func FindMarkdownFiles(cfg Config, dir string) ([]string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ch chan gitcha.SearchResult
+	if cfg.ShowAllFiles {
+		ch, err = gitcha.FindAllFilesExcept(dir, markdownExtensions, nil)
+	} else {
+		ch, err = gitcha.FindFilesExcept(dir, markdownExtensions, ignorePatterns(commonModel{cfg: cfg}))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var mds []*markdown
+	for res := range ch {
+		mds = append(mds, localFileToMarkdown(dir, res))
+	}
+	sortMarkdowns(mds)
+
+	paths := make([]string, len(mds))
+	for i, md := range mds {
+		paths[i] = md.localPath
+	}
+	return paths, nil
+}
+
 func findNextLocalFile(m model) tea.Cmd {
 	return func() tea.Msg {
 		res, ok := <-m.localFileFinder
@@ -16,21 +16,22 @@ import (
 
 // RemoveFrontmatter removes the front matter header of a markdown file.
 func RemoveFrontmatter(content []byte) []byte {
-	if frontmatterBoundaries := detectFrontmatter(content); frontmatterBoundaries[0] == 0 {
-		return content[frontmatterBoundaries[1]:]
-	}
-	return content
+	_, body := SplitFrontmatter(content)
+	return body
 }
 
-var yamlPattern = regexp.MustCompile(`(?m)^---\r?\n(\s*\r?\n)?`)
-
-func detectFrontmatter(c []byte) []int {
-	if matches := yamlPattern.FindAllIndex(c, 2); len(matches) > 1 {
-		return []int{matches[0][0], matches[1][1]}
+// SplitFrontmatter separates the front matter header of a markdown file
+// from its body. The front matter is returned without its delimiters and
+// is nil when the file has none.
+func SplitFrontmatter(content []byte) (frontmatter, body []byte) {
+	if matches := yamlPattern.FindAllIndex(content, 2); len(matches) > 1 && matches[0][0] == 0 {
+		return content[matches[0][1]:matches[1][0]], content[matches[1][1]:]
 	}
-	return []int{-1, -1}
+	return nil, content
 }
 
+var yamlPattern = regexp.MustCompile(`(?m)^---\r?\n(\s*\r?\n)?`)
+
 // ExpandPath expands tilde and all environment variables from the given path.
 func ExpandPath(path string) string {
 	s, err := homedir.Expand(path)